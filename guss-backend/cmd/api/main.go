@@ -14,10 +14,18 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	_ "github.com/go-sql-driver/mysql"
+	"golang.org/x/crypto/bcrypt"
 
 	"guss-backend/internal/algo"
 	"guss-backend/internal/api"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/notify"
+	"guss-backend/internal/payment"
+	"guss-backend/internal/privacy"
 	"guss-backend/internal/repository"
 	"guss-backend/pkg/tcp"
 )
@@ -67,20 +75,109 @@ func (m *mockResponseWriter) WriteHeader(code int) {
 	fmt.Fprint(m.conn, "\r\n")
 }
 
+// days: 기간을 일 단위 플래그 값으로 변환
+func days(d time.Duration) int {
+	return int(d / (24 * time.Hour))
+}
+
+// newDynamoLogRepository: AWS 기본 자격 증명으로 DynamoDB 로그 저장소 생성 (테이블 접근과 보존 기간 정리용 인덱스까지 확인)
+func newDynamoLogRepository(region string) (repository.LogRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	client := dynamodb.NewFromConfig(cfg)
+	out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String("guss_logs")})
+	if err != nil {
+		return nil, err
+	}
+	// 보존 기간 정리는 LoggedAt 인덱스로만 조회하므로 인덱스가 없으면 시작하지 않는다.
+	for _, idx := range out.Table.GlobalSecondaryIndexes {
+		if aws.ToString(idx.IndexName) == repository.UserLogTimeIndex {
+			return repository.NewDynamoLogRepository(client), nil
+		}
+	}
+	return nil, fmt.Errorf("guss_logs에 %s 인덱스가 없습니다 (파티션 키 LogType(S), 정렬 키 LoggedAt(N))", repository.UserLogTimeIndex)
+}
+
 func main() {
 	port := flag.String("port", "9000", "API 서버 포트")
 	useMock := flag.Bool("mock", false, "Mock 데이터 사용 여부")
-	mysqlDSN := flag.String("dsn", "guss_user:1234@tcp(guss-prd-rds-2a.cbsocuc4ser6.ap-northeast-2.rds.amazonaws.com:3306)/guss", "MySQL 연결 정보")
+	mysqlDSN := flag.String("dsn", "guss_user:1234@tcp(guss-prd-rds-2a.cbsocuc4ser6.ap-northeast-2.rds.amazonaws.com:3306)/guss?parseTime=true", "MySQL 연결 정보 (DATETIME 스캔을 위해 parseTime=true 필요)")
 	maxConn := flag.Int("max_conn", 1000, "최대 동시 연결 수")
+	awsRegion := flag.String("aws_region", "ap-northeast-2", "활동 로그(DynamoDB guss_logs) 리전 (자격 증명은 AWS 기본 체인: 환경 변수/프로필/IAM 역할)")
+	refundFullHours := flag.Int("refund_full_hours", algo.DefaultRefundPolicy.FullRefundHours, "결제 후 전액 환불 가능 시간 (미사용 시)")
+	refundFee := flag.Float64("refund_fee", algo.DefaultRefundPolicy.CancellationFee, "부분 환불 위약금 비율 (0.1 = 10%)")
+	refundApproval := flag.Int64("refund_approval_threshold", algo.DefaultRefundPolicy.ApprovalThreshold, "관리자 승인이 필요한 환불 금액 기준 (원, 0이면 항상 자동)")
+	paymentSecret := flag.String("payment_secret", os.Getenv("GUSS_PAYMENT_SECRET"), "결제 웹훅 서명 키 (로컬 가짜 결제사용)")
+	jwtKeys := flag.String("jwt_keys", os.Getenv("GUSS_JWT_KEYS"), "JWT 서명 키 목록 (kid=HS256:비밀키;kid2=RS256:/path/key.pem;kid3=EdDSA:/path/key.pem)")
+	jwtActiveKID := flag.String("jwt_active_kid", os.Getenv("GUSS_JWT_ACTIVE_KID"), "새 토큰 서명에 사용할 kid (비우면 첫 번째 서명 가능 키)")
+	jwtIssuer := flag.String("jwt_issuer", auth.DefaultTokenConfig.Issuer, "JWT 발급자 (iss)")
+	jwtAudience := flag.String("jwt_audience", auth.DefaultTokenConfig.Audience, "JWT 대상 서비스 (aud)")
+	jwtLeeway := flag.Duration("jwt_leeway", auth.DefaultTokenConfig.Leeway, "JWT 시간 검증 시 허용할 시계 오차")
+	lockoutStore := flag.String("lockout_store", "memory", "로그인 실패 집계 저장소 (memory: 단일 서버 / mysql: 여러 서버 공유, off: 비활성)")
+	loginLockAt := flag.Int("login_lock_at", auth.DefaultLockoutPolicy.AccountLockAt, "계정 잠금까지 허용하는 연속 로그인 실패 횟수")
+	loginLockDuration := flag.Duration("login_lock_duration", auth.DefaultLockoutPolicy.LockDuration, "로그인 잠금 유지 시간")
+	trustProxy := flag.Bool("trust_proxy", false, "로드밸런서 뒤에서 X-Forwarded-For로 클라이언트 IP 판단 (로드밸런서 뒤라면 반드시 켜야 IP별 로그인 제한이 클라이언트별로 적용됨)")
+	passwordMinLength := flag.Int("password_min_length", auth.DefaultPasswordPolicy.MinLength, "비밀번호 최소 길이")
+	passwordMinClasses := flag.Int("password_min_classes", auth.DefaultPasswordPolicy.MinClasses, "비밀번호에 포함할 문자 종류 수 (영문 소문자/대문자/숫자/특수문자 중)")
+	bcryptCost := flag.Int("bcrypt_cost", auth.DefaultPasswordPolicy.BcryptCost, "bcrypt 비용 (올리면 기존 해시는 다음 로그인 때 갱신)")
+	breachedPasswords := flag.String("breached_passwords", os.Getenv("GUSS_BREACHED_PASSWORDS"), "유출 비밀번호 목록 파일 (평문 또는 SHA-1, 한 줄에 하나)")
+	notifySender := flag.String("notify_sender", "log", "인증 코드 발송 방식 (log: 발송 대신 서버 로그 출력, off: 비활성)")
+	retentionInterval := flag.Duration("retention_interval", 24*time.Hour, "보존 기간이 지난 개인정보 정리 주기 (0이면 비활성)")
+	retainAuthDays := flag.Int("retain_auth_days", days(privacy.DefaultRetentionPolicy.AuthData), "만료된 인증 코드/토큰, 로그인 실패 집계 보존 일수 (0이면 정리 안 함)")
+	retainReservationDays := flag.Int("retain_reservation_days", days(privacy.DefaultRetentionPolicy.Reservations), "예약 이력 보존 일수 (0이면 정리 안 함)")
+	retainPaymentDays := flag.Int("retain_payment_days", days(privacy.DefaultRetentionPolicy.PaymentRecords), "이용권/주문/환불 기록 보존 일수 (0이면 정리 안 함)")
+	retainWithdrawnDays := flag.Int("retain_withdrawn_days", days(privacy.DefaultRetentionPolicy.WithdrawnAccounts), "탈퇴 회원 이력 보존 일수 (0이면 정리 안 함)")
+	retainLogDays := flag.Int("retain_log_days", days(privacy.DefaultRetentionPolicy.ActivityLogs), "회원 활동 로그 보존 일수 (0이면 정리 안 함)")
 	flag.Parse()
 
+	// 키 교체: 새 키를 목록에 추가하고 jwt_active_kid를 바꾼 뒤, 기존 토큰이 모두 만료되면 이전 키를 제거한다.
+	var keySet *auth.KeySet
+	var err error
+	if *jwtKeys == "" {
+		keySet, err = auth.NewDevKeySet()
+	} else {
+		keySet, err = auth.ParseKeySet(*jwtKeys, *jwtActiveKID)
+	}
+	if err != nil {
+		log.Fatalf("JWT 키 설정 실패: %v", err)
+	}
+	auth.SetKeySet(keySet)
+	auth.SetTokenConfig(auth.TokenConfig{Issuer: *jwtIssuer, Audience: *jwtAudience, Leeway: *jwtLeeway})
+	log.Printf("--- [AUTH] JWT 활성 서명 키: %s ---", keySet.ActiveKID())
+
+	passwordPolicy := auth.DefaultPasswordPolicy
+	passwordPolicy.MinLength = *passwordMinLength
+	passwordPolicy.MinClasses = *passwordMinClasses
+	passwordPolicy.BcryptCost = *bcryptCost
+	if *bcryptCost < bcrypt.MinCost || *bcryptCost > bcrypt.MaxCost {
+		log.Fatalf("bcrypt_cost는 %d~%d 사이여야 합니다", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	if *breachedPasswords != "" {
+		passwordPolicy.Breached, err = auth.LoadBreachedPasswords(*breachedPasswords)
+		if err != nil {
+			log.Fatalf("유출 비밀번호 목록 로드 실패: %v", err)
+		}
+		log.Printf("--- [AUTH] 유출 비밀번호 목록: %d건 ---", len(passwordPolicy.Breached))
+	}
+	auth.SetPasswordPolicy(passwordPolicy)
+
 	var repo repository.Repository
 	var logRepo repository.LogRepository
+	var attemptStore auth.AttemptStore
 
 	if *useMock {
 		log.Println("--- [NOTICE] Mock 테스트 모드로 실행 중입니다 ---")
 		repo = repository.NewMockRepository()
 		logRepo = repository.NewMockLogRepository()
+		if *lockoutStore == "mysql" {
+			log.Println("--- [WARN] Mock 모드에서는 lockout_store=mysql 대신 메모리 저장소를 사용합니다 ---")
+			*lockoutStore = "memory"
+		}
 	} else {
 		log.Println("--- [DATABASE] MySQL 연결 시도 중... ---")
 		db, err := sql.Open("mysql", *mysqlDSN)
@@ -96,21 +193,129 @@ func main() {
 
 		db.SetMaxOpenConns(*maxConn)
 		repo = repository.NewMySQLRepository(db)
-		logRepo = repository.NewMockLogRepository()
+		if *lockoutStore == "mysql" {
+			attemptStore = repository.NewMySQLAttemptStore(db)
+		}
+
+		// 활동 로그는 열람/보존 기간 정리 대상이므로 운영 모드에서 Mock으로 대체하지 않는다.
+		logRepo, err = newDynamoLogRepository(*awsRegion)
+		if err != nil {
+			log.Fatalf("활동 로그 저장소(DynamoDB) 연결 실패: %v", err)
+		}
+		log.Printf("--- [DATABASE] 활동 로그: DynamoDB %s ---", *awsRegion)
 	}
 
 	server := &api.Server{
 		Repo:    repo,
 		LogRepo: logRepo,
 		Algo:    &algo.RealTimeCalculator{},
+
+		FreezePolicy: algo.DefaultFreezePolicy,
+		RefundPolicy: algo.RefundPolicy{
+			FullRefundHours:   *refundFullHours,
+			CancellationFee:   *refundFee,
+			ApprovalThreshold: *refundApproval,
+		},
+	}
+
+	switch *lockoutStore {
+	case "memory":
+		attemptStore = auth.NewMemoryAttemptStore()
+	case "mysql":
+	case "off":
+		log.Println("--- [WARN] 로그인 실패 제한이 비활성화되었습니다 ---")
+	default:
+		log.Fatalf("알 수 없는 lockout_store: %s", *lockoutStore)
+	}
+	if attemptStore != nil {
+		policy := auth.DefaultLockoutPolicy
+		policy.AccountLockAt = *loginLockAt
+		policy.LockDuration = *loginLockDuration
+		server.LoginGuard = &auth.LoginGuard{Store: attemptStore, Policy: policy}
+	}
+	server.TrustProxy = *trustProxy
+
+	// 문자/이메일 발송 업체 연동 전까지는 로그 발송기만 지원한다.
+	switch *notifySender {
+	case "log":
+		log.Println("--- [WARN] notify_sender=log: 인증 코드가 발송되지 않고 서버 로그에 출력됩니다 (운영 사용 금지) ---")
+		server.Notifier = notify.NewLogSender()
+	case "off":
+	default:
+		log.Fatalf("알 수 없는 notify_sender: %s", *notifySender)
+	}
+
+	// 여러 서버에서 동시에 돌아도 정리 결과는 같으므로 서버마다 실행한다.
+	if *retentionInterval > 0 {
+		engine := &privacy.RetentionEngine{
+			Repo: repo,
+			Logs: logRepo,
+			Policy: privacy.RetentionPolicy{
+				AuthData:          time.Duration(*retainAuthDays) * 24 * time.Hour,
+				Reservations:      time.Duration(*retainReservationDays) * 24 * time.Hour,
+				PaymentRecords:    time.Duration(*retainPaymentDays) * 24 * time.Hour,
+				WithdrawnAccounts: time.Duration(*retainWithdrawnDays) * 24 * time.Hour,
+				ActivityLogs:      time.Duration(*retainLogDays) * 24 * time.Hour,
+			},
+		}
+		go engine.Start(context.Background(), *retentionInterval)
+	} else {
+		log.Println("--- [WARN] 보존 기간 정리가 비활성화되었습니다 ---")
+	}
+
+	// 실제 결제사 연동 전까지는 로컬 가짜 결제사가 자기 자신에게 웹훅을 보낸다.
+	if *paymentSecret == "" {
+		log.Println("--- [WARN] payment_secret 미설정: 결제 기능이 비활성화됩니다 ---")
+	} else {
+		server.Payments = payment.NewFakeProvider(*paymentSecret, "http://127.0.0.1:"+*port+"/api/payments/webhook")
 	}
 
 	mux := http.NewServeMux()
+
+	// adminRoute: 관리자 API 공통 체인 (토큰 검증 → 세부 권한 확인 → 호출자 지점 범위 고정)
+	adminRoute := func(perm string, h http.Handler) http.Handler {
+		return server.AuthMiddleware(server.RequirePermission(perm)(server.GymScopeMiddleware(h)))
+	}
 	mux.Handle("/reserve", server.AuthMiddleware(http.HandlerFunc(server.HandleReserve)))
 	
 	adminHandler := http.HandlerFunc(server.HandleDashboard)
-	mux.Handle("/admin/dashboard", server.AuthMiddleware(server.AdminMiddleware(adminHandler)))
-	mux.Handle("/admin/sales", server.AuthMiddleware(server.AdminMiddleware(http.HandlerFunc(server.HandleGetSales))))
+	mux.Handle("/admin/dashboard", adminRoute(auth.PermDashboardView, adminHandler))
+	salesHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			server.HandleGetSales(w, r)
+		case http.MethodPost:
+			server.RequirePermission(auth.PermSalesWrite)(http.HandlerFunc(server.HandleCreateSale)).ServeHTTP(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.Handle("/admin/sales", adminRoute(auth.PermSalesView, salesHandler))
+	mux.Handle("/admin/sales/report", adminRoute(auth.PermSalesView, http.HandlerFunc(server.HandleGetSalesReport)))
+	mux.Handle("/admin/products", adminRoute(auth.PermProductManage, http.HandlerFunc(server.HandleAdminProducts)))
+	mux.Handle("/admin/products/", adminRoute(auth.PermProductManage, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		server.HandleUpdateProduct(w, r)
+	})))
+	mux.Handle("/admin/passes", adminRoute(auth.PermPassIssue, http.HandlerFunc(server.HandleIssuePass)))
+	mux.Handle("/admin/passes/", adminRoute(auth.PermRefundManage, http.HandlerFunc(server.HandleAdminPassRefund)))
+	mux.Handle("/admin/coupons", adminRoute(auth.PermCouponManage, http.HandlerFunc(server.HandleAdminCoupons)))
+	mux.Handle("/admin/coupons/report", adminRoute(auth.PermCouponManage, http.HandlerFunc(server.HandleCouponReport)))
+	mux.Handle("/admin/coupons/", adminRoute(auth.PermCouponManage, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		server.HandleUpdateCoupon(w, r)
+	})))
+	mux.Handle("/admin/refunds", adminRoute(auth.PermRefundManage, http.HandlerFunc(server.HandleAdminRefunds)))
+	mux.Handle("/admin/refunds/", adminRoute(auth.PermRefundManage, http.HandlerFunc(server.HandleAdminRefundAction)))
+	mux.Handle("/admin/reservations/", adminRoute(auth.PermReservationManage, http.HandlerFunc(server.HandleMarkNoShow)))
+	mux.Handle("/admin/login-locks/unlock", adminRoute(auth.PermAccountUnlock, http.HandlerFunc(server.HandleUnlockLogin)))
+	mux.Handle("/admin/inventory", adminRoute(auth.PermInventoryView, http.HandlerFunc(server.HandleGetInventoryValuation)))
 	
 	registerRoutes(mux, server)
 
@@ -157,6 +362,7 @@ func main() {
 					return
 				}
 
+				req.RemoteAddr = c.RemoteAddr().String() // http.ReadRequest는 접속 주소를 채우지 않음
 				w := &mockResponseWriter{conn: c, header: make(http.Header)}
 				srv.Handler.ServeHTTP(w, req)
 			}(conn)
@@ -168,41 +374,88 @@ func main() {
 
 func registerRoutes(mux *http.ServeMux, s *api.Server) {
 	mux.HandleFunc("/api/register", s.HandleRegister)
+	mux.HandleFunc("/api/register/verify", s.HandleVerifyRegistration)
 	mux.HandleFunc("/api/login", s.HandleLogin)
+	mux.HandleFunc("/api/admin/login", s.HandleAdminLogin)
+	mux.HandleFunc("/api/admin/login/mfa", s.HandleAdminLoginMFA)
+	mux.HandleFunc("/api/admin/mfa/setup", s.HandleAdminMFASetup) // mfa_setup 챌린지 또는 Bearer 토큰으로 직접 인증
+	mux.HandleFunc("/api/admin/mfa/activate", s.HandleAdminMFAActivate)
+	mux.Handle("/api/admin/mfa", s.AuthMiddleware(http.HandlerFunc(s.HandleDisableAdminMFA)))
+	mux.HandleFunc("/api/password-reset/request", s.HandleRequestPasswordReset)
+	mux.HandleFunc("/api/password-reset/confirm", s.HandleConfirmPasswordReset)
+	mux.HandleFunc("/api/token/refresh", s.HandleRefreshToken)
+	mux.HandleFunc("/api/logout", s.HandleLogout)
+	mux.HandleFunc("/.well-known/jwks.json", s.HandleJWKS)
 	mux.HandleFunc("/api/gyms", s.HandleGetGyms)
 	mux.HandleFunc("/api/gyms/", s.HandleGetGymDetail)
 	mux.Handle("/api/reserve", s.AuthMiddleware(http.HandlerFunc(s.HandleReserve)))
+	mux.HandleFunc("/api/products", s.HandleGetProducts)
+	mux.Handle("/api/me", s.AuthMiddleware(http.HandlerFunc(s.HandleMe)))
+	mux.Handle("/api/me/password", s.AuthMiddleware(http.HandlerFunc(s.HandleChangePassword)))
+	mux.Handle("/api/me/phone/verify", s.AuthMiddleware(http.HandlerFunc(s.HandleVerifyPhoneChange)))
+	mux.Handle("/api/me/export", s.AuthMiddleware(http.HandlerFunc(s.HandleExportMyData)))
+	mux.Handle("/api/me/passes", s.AuthMiddleware(http.HandlerFunc(s.HandleGetMyPasses)))
+	mux.Handle("/api/me/passes/", s.AuthMiddleware(http.HandlerFunc(s.HandleMyPass)))
+	mux.Handle("/api/me/receipts", s.AuthMiddleware(http.HandlerFunc(s.HandleGetMyReceipts)))
+	mux.Handle("/api/me/receipts/", s.AuthMiddleware(http.HandlerFunc(s.HandleGetMyReceipt)))
+	mux.Handle("/api/coupons/", s.AuthMiddleware(http.HandlerFunc(s.HandleCheckCoupon)))
+	mux.Handle("/api/orders", s.AuthMiddleware(http.HandlerFunc(s.HandleCheckout)))
+	mux.Handle("/api/me/orders", s.AuthMiddleware(http.HandlerFunc(s.HandleGetMyOrders)))
+	mux.Handle("/api/me/orders/", s.AuthMiddleware(http.HandlerFunc(s.HandleMyOrder)))
+	mux.HandleFunc("/api/payments/webhook", s.HandlePaymentWebhook) // 결제사 서명으로 인증
 
 	mux.HandleFunc("/api/dashboard", s.HandleDashboard)
 
-	// 기구 관련 라우트 통합 처리
-	mux.HandleFunc("/api/equipments", func(w http.ResponseWriter, r *http.Request) {
+	// 기구 관련 라우트 통합 처리 (조회는 로그인 유저, 등록/수정/삭제는 equipment:manage 권한 + 핸들러에서 지점 범위 확인)
+	manageEquipment := s.RequirePermission(auth.PermEquipmentManage)
+	mux.Handle("/api/equipments", s.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			s.HandleGetEquipments(w, r)
 		case http.MethodPost:
-			s.HandleAddEquipment(w, r)
+			manageEquipment(http.HandlerFunc(s.HandleAddEquipment)).ServeHTTP(w, r)
 		case http.MethodPut:
-			s.HandleUpdateEquipment(w, r)
+			manageEquipment(http.HandlerFunc(s.HandleUpdateEquipment)).ServeHTTP(w, r)
 		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
 		}
-	})
+	})))
 
 	// /api/equipments/{id} 형태의 경로 처리 (삭제 및 수정용)
-	mux.HandleFunc("/api/equipments/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/api/equipments/", s.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodDelete:
-			s.HandleDeleteEquipment(w, r)
+			manageEquipment(http.HandlerFunc(s.HandleDeleteEquipment)).ServeHTTP(w, r)
 		case http.MethodPut:
-			s.HandleUpdateEquipment(w, r)
+			manageEquipment(http.HandlerFunc(s.HandleUpdateEquipment)).ServeHTTP(w, r)
 		case http.MethodOptions:
 			return // CORS 대응
 		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
 		}
-	})
+	})))
+
+	// 기구 단위 시간 예약 (체육관 예약이 있는 회원만)
+	mux.Handle("/api/equipment-bookings", s.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.HandleGetEquipmentBookings(w, r)
+		case http.MethodPost:
+			s.HandleCreateEquipmentBooking(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})))
+	mux.Handle("/api/equipment-bookings/", s.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		s.HandleCancelEquipmentBooking(w, r)
+	})))
+	mux.Handle("/api/check-out", s.AuthMiddleware(http.HandlerFunc(s.HandleCheckOut)))
 
-	mux.HandleFunc("/api/reservations", s.HandleGetReservations)
-	mux.HandleFunc("/api/sales", s.HandleGetSales)
+	// 예약 현황/매출은 관리자 API와 같은 체인 (지점 관리자는 gym_id와 무관하게 자기 지점만 조회)
+	mux.Handle("/api/reservations", s.AuthMiddleware(s.RequirePermission(auth.PermReservationManage)(s.GymScopeMiddleware(http.HandlerFunc(s.HandleGetReservations)))))
+	mux.Handle("/api/sales", s.AuthMiddleware(s.RequirePermission(auth.PermSalesView)(s.GymScopeMiddleware(http.HandlerFunc(s.HandleGetSales)))))
 }
@@ -0,0 +1,13 @@
+-- 001. 기구 자산 정보 (단가, 공급업체, 보증 만료일, 감가상각 방식/내용연수/잔존가치)
+-- 기존 운영 DB용이며 번호 순서대로 한 번씩 적용한다. 신규 설치는 schema.sql에 이미 반영되어 있다.
+-- 기존 기구는 단가 0원, 정액법 5년으로 채워지므로 재고 평가 전에 관리자 화면에서 실제 값을 입력해야 한다.
+
+USE guss;
+
+ALTER TABLE equipment_table
+    ADD COLUMN purchase_price BIGINT DEFAULT 0 AFTER purchase_date,
+    ADD COLUMN supplier VARCHAR(100) AFTER purchase_price,
+    ADD COLUMN warranty_expiry DATE AFTER supplier,
+    ADD COLUMN depreciation_method VARCHAR(20) DEFAULT 'straight_line' AFTER warranty_expiry,
+    ADD COLUMN useful_life_years INT DEFAULT 5 AFTER depreciation_method,
+    ADD COLUMN salvage_value BIGINT DEFAULT 0 AFTER useful_life_years;
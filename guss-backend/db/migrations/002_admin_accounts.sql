@@ -0,0 +1,12 @@
+-- 002. 관리자 테이블 (담당 지점 fk_guss_number로 관리자 API의 쓰기 범위를 제한)
+-- 로그인 코드는 이전부터 admin_table을 조회했지만 schema.sql에 정의가 없었으므로, 수동으로 만든 DB에서는 그대로 유지된다.
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS admin_table (
+    admin_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    admin_id VARCHAR(50) UNIQUE NOT NULL,
+    admin_pw VARCHAR(255) NOT NULL,
+    fk_guss_number BIGINT NULL,
+    FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
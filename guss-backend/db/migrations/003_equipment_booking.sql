@@ -0,0 +1,18 @@
+-- 003. 기구 30분 단위 예약
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS equip_booking_table (
+    booking_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_equip_id BIGINT NOT NULL,
+    fk_user_number BIGINT NOT NULL,
+    fk_revs_number BIGINT NOT NULL,
+    unit_no INT NOT NULL,
+    start_time DATETIME NOT NULL,
+    end_time DATETIME NOT NULL,
+    booking_status VARCHAR(20) DEFAULT 'BOOKED',
+    INDEX idx_equip_unit_time (fk_equip_id, unit_no, start_time),
+    FOREIGN KEY (fk_equip_id) REFERENCES equipment_table(equip_id) ON DELETE CASCADE,
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE,
+    FOREIGN KEY (fk_revs_number) REFERENCES revs_table(revs_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,34 @@
+-- 004. 지점별 상품과 회원 이용권 (예약 시 이용권 차감)
+-- 적용 후 예약에는 유효한 이용권이 필요하므로, 기존 회원에게는 관리자 화면의 이용권 발급으로 먼저 지급한다.
+
+USE guss;
+
+ALTER TABLE revs_table ADD COLUMN fk_pass_number BIGINT NULL AFTER revs_status;
+
+CREATE TABLE IF NOT EXISTS product_table (
+    product_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_guss_number BIGINT NOT NULL,
+    product_name VARCHAR(100) NOT NULL,
+    product_type VARCHAR(20) NOT NULL,
+    product_price BIGINT NOT NULL,
+    visit_count INT NULL,
+    validity_days INT NOT NULL,
+    is_active TINYINT(1) DEFAULT 1,
+    FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS pass_table (
+    pass_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_user_number BIGINT NOT NULL,
+    fk_product_number BIGINT NOT NULL,
+    fk_guss_number BIGINT NOT NULL,
+    remaining_visits INT NULL,
+    starts_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    pass_status VARCHAR(20) DEFAULT 'ACTIVE',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_pass_user_gym (fk_user_number, fk_guss_number, pass_status),
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE,
+    FOREIGN KEY (fk_product_number) REFERENCES product_table(product_number),
+    FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,23 @@
+-- 005. 이용권 일시정지/양도 이력
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS pass_freeze_table (
+    freeze_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_pass_number BIGINT NOT NULL,
+    start_at DATETIME NOT NULL,
+    end_at DATETIME NOT NULL,
+    freeze_days INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_freeze_pass (fk_pass_number, start_at),
+    FOREIGN KEY (fk_pass_number) REFERENCES pass_table(pass_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS pass_transfer_table (
+    transfer_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_pass_number BIGINT NOT NULL,
+    from_user_number BIGINT NOT NULL,
+    to_user_number BIGINT NOT NULL,
+    transferred_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (fk_pass_number) REFERENCES pass_table(pass_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,25 @@
+-- 006. 온라인 결제 주문 (결제사 웹훅 확인 후 이용권 발급 + 매출 기록)
+
+USE guss;
+
+-- 매출을 구매 회원/주문과 연결 (기존 매출과 현장 판매는 NULL)
+ALTER TABLE sales_table
+    ADD COLUMN fk_user_number BIGINT NULL,
+    ADD COLUMN fk_order_number BIGINT NULL;
+
+CREATE TABLE IF NOT EXISTS order_table (
+    order_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_user_number BIGINT NOT NULL,
+    fk_guss_number BIGINT NOT NULL,
+    fk_product_number BIGINT NOT NULL,
+    order_amount BIGINT NOT NULL,
+    order_status VARCHAR(20) DEFAULT 'PENDING',
+    payment_id VARCHAR(64) NULL UNIQUE,
+    fk_pass_number BIGINT NULL,
+    fk_sales_number BIGINT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    paid_at DATETIME NULL,
+    INDEX idx_order_user (fk_user_number),
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE,
+    FOREIGN KEY (fk_product_number) REFERENCES product_table(product_number)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,21 @@
+-- 007. 환불 요청/승인 이력
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS refund_table (
+    refund_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_order_number BIGINT NOT NULL,
+    fk_user_number BIGINT NOT NULL,
+    fk_guss_number BIGINT NOT NULL,
+    paid_amount BIGINT NOT NULL,
+    refund_amount BIGINT NOT NULL,
+    refund_status VARCHAR(20) DEFAULT 'REQUESTED',
+    refund_reason VARCHAR(255) NULL,
+    admin_note VARCHAR(255) NULL,
+    processed_by VARCHAR(50) NULL,
+    fk_sales_number BIGINT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    processed_at DATETIME NULL,
+    INDEX idx_refund_status (fk_guss_number, refund_status),
+    FOREIGN KEY (fk_order_number) REFERENCES order_table(order_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,49 @@
+-- 008. 쿠폰 (주문에 할인 전 가격/할인 금액/사용 쿠폰 기록)
+
+USE guss;
+
+ALTER TABLE order_table
+    ADD COLUMN list_price BIGINT NOT NULL DEFAULT 0 AFTER order_amount,
+    ADD COLUMN discount_amount BIGINT NOT NULL DEFAULT 0 AFTER list_price,
+    ADD COLUMN fk_coupon_number BIGINT NULL AFTER discount_amount;
+
+-- 기존 주문은 할인 없이 결제된 것이므로 정가 = 결제 금액
+UPDATE order_table SET list_price = order_amount WHERE list_price = 0;
+
+CREATE TABLE IF NOT EXISTS coupon_table (
+    coupon_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    coupon_code VARCHAR(40) NOT NULL UNIQUE,
+    coupon_name VARCHAR(100) NOT NULL,
+    discount_type VARCHAR(10) NOT NULL,
+    discount_value BIGINT NOT NULL DEFAULT 0,
+    max_discount BIGINT NOT NULL DEFAULT 0,
+    product_type VARCHAR(20) NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    total_limit INT NOT NULL DEFAULT 0,
+    per_user_limit INT NOT NULL DEFAULT 1,
+    first_purchase_only TINYINT(1) DEFAULT 0,
+    is_active TINYINT(1) DEFAULT 1,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS coupon_target_table (
+    fk_coupon_number BIGINT NOT NULL,
+    target_type VARCHAR(10) NOT NULL,
+    target_id BIGINT NOT NULL,
+    PRIMARY KEY (fk_coupon_number, target_type, target_id),
+    FOREIGN KEY (fk_coupon_number) REFERENCES coupon_table(coupon_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS coupon_redemption_table (
+    redemption_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_coupon_number BIGINT NOT NULL,
+    fk_user_number BIGINT NOT NULL,
+    fk_order_number BIGINT NOT NULL UNIQUE,
+    discount_amount BIGINT NOT NULL,
+    redemption_status VARCHAR(20) DEFAULT 'RESERVED',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_redemption_coupon (fk_coupon_number, redemption_status),
+    FOREIGN KEY (fk_coupon_number) REFERENCES coupon_table(coupon_number),
+    FOREIGN KEY (fk_order_number) REFERENCES order_table(order_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,34 @@
+-- 009. 지점별 일련번호 영수증 (공급가액/부가세 분리)
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS receipt_table (
+    receipt_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_guss_number BIGINT NOT NULL,
+    receipt_seq BIGINT NOT NULL,
+    fk_sales_number BIGINT NOT NULL UNIQUE,
+    fk_user_number BIGINT NULL,
+    fk_order_number BIGINT NULL,
+    issued_at DATETIME NOT NULL,
+    total_amount BIGINT NOT NULL,
+    supply_amount BIGINT NOT NULL,
+    vat_amount BIGINT NOT NULL,
+    UNIQUE KEY uk_receipt_seq (fk_guss_number, receipt_seq),
+    INDEX idx_receipt_user (fk_user_number),
+    FOREIGN KEY (fk_sales_number) REFERENCES sales_table(sales_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS receipt_line_table (
+    line_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_receipt_number BIGINT NOT NULL,
+    line_description VARCHAR(200) NOT NULL,
+    quantity INT NOT NULL DEFAULT 1,
+    unit_price BIGINT NOT NULL,
+    line_amount BIGINT NOT NULL,
+    FOREIGN KEY (fk_receipt_number) REFERENCES receipt_table(receipt_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS receipt_seq_table (
+    fk_guss_number BIGINT PRIMARY KEY,
+    last_seq BIGINT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,18 @@
+-- 010. 리프레시 토큰 (해시 저장, family 단위 재사용 감지)
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS refresh_token_table (
+    token_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    family_id CHAR(32) NOT NULL,
+    principal_type VARCHAR(10) NOT NULL,
+    principal_number BIGINT NOT NULL,
+    principal_id VARCHAR(50) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    revoked_at DATETIME NULL,
+    INDEX idx_refresh_family (family_id),
+    INDEX idx_refresh_principal (principal_type, principal_number)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,37 @@
+-- 011. 역할 기반 권한 (하드코딩된 관리자 아이디 대신 admin_table.admin_role과 역할별 권한으로 결정)
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS role_table (
+    role_code VARCHAR(20) PRIMARY KEY,
+    role_name VARCHAR(50) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS role_permission_table (
+    fk_role_code VARCHAR(20) NOT NULL,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (fk_role_code, permission),
+    FOREIGN KEY (fk_role_code) REFERENCES role_table(role_code) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT IGNORE INTO role_table (role_code, role_name)
+VALUES
+('USER', '일반 회원'),
+('GYM_STAFF', '지점 직원'),
+('GYM_ADMIN', '지점 관리자'),
+('SUPER_ADMIN', '최고 관리자');
+
+INSERT IGNORE INTO role_permission_table (fk_role_code, permission)
+VALUES
+('GYM_STAFF', 'dashboard:view'), ('GYM_STAFF', 'reservation:manage'), ('GYM_STAFF', 'pass:issue'), ('GYM_STAFF', 'inventory:view'),
+('GYM_ADMIN', 'dashboard:view'), ('GYM_ADMIN', 'reservation:manage'), ('GYM_ADMIN', 'equipment:manage'), ('GYM_ADMIN', 'inventory:view'),
+('GYM_ADMIN', 'product:manage'), ('GYM_ADMIN', 'pass:issue'), ('GYM_ADMIN', 'sales:view'), ('GYM_ADMIN', 'sales:write'),
+('GYM_ADMIN', 'coupon:manage'), ('GYM_ADMIN', 'refund:manage'),
+('SUPER_ADMIN', 'dashboard:view'), ('SUPER_ADMIN', 'reservation:manage'), ('SUPER_ADMIN', 'equipment:manage'), ('SUPER_ADMIN', 'inventory:view'),
+('SUPER_ADMIN', 'product:manage'), ('SUPER_ADMIN', 'pass:issue'), ('SUPER_ADMIN', 'sales:view'), ('SUPER_ADMIN', 'sales:write'),
+('SUPER_ADMIN', 'coupon:manage'), ('SUPER_ADMIN', 'refund:manage'), ('SUPER_ADMIN', 'gym:all');
+
+ALTER TABLE admin_table ADD COLUMN admin_role VARCHAR(20) NOT NULL DEFAULT 'GYM_ADMIN' AFTER admin_pw;
+
+-- 이전에는 아이디가 super_admin인 계정만 최고 관리자였다.
+UPDATE admin_table SET admin_role = 'SUPER_ADMIN' WHERE admin_id = 'super_admin';
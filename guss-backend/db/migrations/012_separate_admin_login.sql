@@ -0,0 +1,32 @@
+-- 012. 회원/관리자 로그인 분리 (기존 운영 DB용, 한 번만 적용, 역할 테이블은 011에서 생성)
+-- 신규 설치는 schema.sql에 이미 반영되어 있으므로 적용하지 않는다.
+--
+-- 이전 로그인은 user_table을 먼저 조회하고 없을 때만 admin_table을 조회했기 때문에
+-- 관리자와 같은 아이디로 가입한 회원이 관리자 계정을 가렸다. 이제 회원은 /api/login,
+-- 관리자는 /api/admin/login으로 각자의 테이블만 조회하며, 권한은 아이디가 아닌 admin_role로 결정된다.
+--
+-- 적용: mysql -u <user> -p guss < db/migrations/012_separate_admin_login.sql
+
+USE guss;
+
+START TRANSACTION;
+
+-- 1. 적용 전 점검: 관리자 아이디를 가리고 있던 회원 계정 (분리 후에는 두 계정 모두 각자 로그인 가능)
+SELECT u.user_number, u.user_id, a.admin_number
+FROM user_table u
+JOIN admin_table a ON a.admin_id = u.user_id;
+
+-- 2. 이전 로그인에서 관리자로 취급되던 회원 계정(admin, super_admin)을 관리자 테이블로 이전
+-- 같은 아이디의 관리자가 이미 있으면 그 관리자 계정을 그대로 쓴다. 회원 계정은 일반 회원으로 남는다.
+INSERT INTO admin_table (admin_id, admin_pw, admin_role, fk_guss_number)
+SELECT u.user_id, u.user_pw, IF(u.user_id = 'super_admin', 'SUPER_ADMIN', 'GYM_ADMIN'), NULL
+FROM user_table u
+LEFT JOIN admin_table a ON a.admin_id = u.user_id
+WHERE u.user_id IN ('admin', 'super_admin') AND a.admin_number IS NULL;
+
+-- 3. 이전 방식으로 발급된 관리자 세션은 권한이 바뀌므로 모두 만료시켜 새 엔드포인트로 다시 로그인하게 한다.
+UPDATE refresh_token_table SET revoked_at = UTC_TIMESTAMP()
+WHERE revoked_at IS NULL
+  AND (principal_type = 'ADMIN' OR principal_id IN ('admin', 'super_admin'));
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- 013. 로그인 실패 제한 (계정별/IP별 실패 집계와 관리자 잠금 해제 권한)
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS login_attempt_table (
+    attempt_key VARCHAR(120) PRIMARY KEY,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure DATETIME NOT NULL,
+    locked_until DATETIME NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT IGNORE INTO role_permission_table (fk_role_code, permission)
+VALUES
+('GYM_ADMIN', 'account:unlock'),
+('SUPER_ADMIN', 'account:unlock');
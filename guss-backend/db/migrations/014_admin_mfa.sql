@@ -0,0 +1,29 @@
+-- 014. 관리자 OTP 2단계 인증 (지점 관리자/최고 관리자는 필수, 직원은 선택)
+-- 적용 후 GYM_ADMIN/SUPER_ADMIN은 다음 로그인 때 OTP 등록을 마쳐야 토큰이 발급된다.
+
+USE guss;
+
+ALTER TABLE role_table ADD COLUMN mfa_required TINYINT(1) NOT NULL DEFAULT 0;
+UPDATE role_table SET mfa_required = 1 WHERE role_code IN ('GYM_ADMIN', 'SUPER_ADMIN');
+
+CREATE TABLE IF NOT EXISTS admin_mfa_table (
+    fk_admin_number BIGINT PRIMARY KEY,
+    totp_secret VARCHAR(64) NOT NULL,
+    enabled_at DATETIME NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (fk_admin_number) REFERENCES admin_table(admin_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS admin_recovery_code_table (
+    code_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_admin_number BIGINT NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at DATETIME NULL,
+    UNIQUE KEY uq_recovery_code (fk_admin_number, code_hash),
+    FOREIGN KEY (fk_admin_number) REFERENCES admin_table(admin_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 기존 관리자 세션은 2단계 인증 없이 발급된 것이므로 폐기 (다음 로그인부터 OTP 적용)
+UPDATE refresh_token_table SET revoked_at = UTC_TIMESTAMP()
+WHERE principal_type = 'ADMIN' AND revoked_at IS NULL;
@@ -0,0 +1,15 @@
+-- 015. 비밀번호 재설정 코드 (문자 인증)
+
+USE guss;
+
+CREATE TABLE IF NOT EXISTS password_reset_table (
+    reset_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_user_number BIGINT NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    INDEX idx_reset_user (fk_user_number, used_at),
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
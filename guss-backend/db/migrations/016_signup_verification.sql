@@ -0,0 +1,23 @@
+-- 016. 회원가입 휴대폰 인증 (번호 형식 통일, 번호 중복 금지, 가입 대기 테이블)
+
+USE guss;
+
+-- 번호를 숫자만 남기는 형식으로 통일한 뒤 중복을 막는다.
+-- UNIQUE 추가가 실패하면 아래 조회로 중복 번호를 확인해 정리한 후 다시 실행한다.
+--   SELECT user_phone, COUNT(*) FROM user_table GROUP BY user_phone HAVING COUNT(*) > 1;
+UPDATE user_table SET user_phone = REPLACE(REPLACE(user_phone, '-', ''), ' ', '');
+ALTER TABLE user_table ADD UNIQUE KEY user_phone (user_phone);
+ALTER TABLE user_table ADD COLUMN phone_verified_at DATETIME NULL;
+
+CREATE TABLE IF NOT EXISTS signup_verification_table (
+    signup_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    user_name VARCHAR(50) NOT NULL,
+    user_phone VARCHAR(20) NOT NULL,
+    user_pw VARCHAR(255) NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_signup_phone (user_phone)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
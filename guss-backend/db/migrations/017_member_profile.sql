@@ -0,0 +1,18 @@
+-- 017. 회원 정보 관리 (휴대폰 번호 변경 인증, 탈퇴 회원 익명화)
+
+USE guss;
+
+ALTER TABLE user_table ADD COLUMN deleted_at DATETIME NULL;
+
+CREATE TABLE IF NOT EXISTS phone_change_table (
+    change_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_user_number BIGINT NOT NULL,
+    new_phone VARCHAR(20) NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    INDEX idx_phone_change_user (fk_user_number, used_at),
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
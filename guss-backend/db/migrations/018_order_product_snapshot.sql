@@ -0,0 +1,19 @@
+-- 018. 주문 시점 상품 정보 보관 (결제 확인 전 상품이 수정/삭제돼도 구매한 조건대로 이용권과 영수증을 만든다)
+
+USE guss;
+
+ALTER TABLE order_table
+    ADD COLUMN product_name VARCHAR(100) NOT NULL DEFAULT '' AFTER fk_product_number,
+    ADD COLUMN product_type VARCHAR(20) NOT NULL DEFAULT '' AFTER product_name,
+    ADD COLUMN visit_count INT NOT NULL DEFAULT 0 AFTER product_type,
+    ADD COLUMN validity_days INT NOT NULL DEFAULT 0 AFTER visit_count,
+    ADD COLUMN coupon_code VARCHAR(40) NOT NULL DEFAULT '' AFTER fk_coupon_number;
+
+-- 기존 주문은 현재 상품/쿠폰 정보로 채운다
+UPDATE order_table o JOIN product_table p ON p.product_number = o.fk_product_number
+   SET o.product_name = p.product_name, o.product_type = p.product_type,
+       o.visit_count = COALESCE(p.visit_count, 0), o.validity_days = p.validity_days
+ WHERE o.product_name = '';
+UPDATE order_table o JOIN coupon_table c ON c.coupon_number = o.fk_coupon_number
+   SET o.coupon_code = c.coupon_code
+ WHERE o.coupon_code = '';
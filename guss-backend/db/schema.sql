@@ -1,4 +1,4 @@
--- 데이터베이스 생성 및 선택
+-- 데이터베이스 생성 및 선택 (신규 설치용, 기존 DB는 db/migrations의 파일을 번호 순서대로 적용)
 CREATE DATABASE IF NOT EXISTS guss DEFAULT CHARACTER SET utf8mb4;
 USE guss;
 
@@ -6,9 +6,11 @@ USE guss;
 CREATE TABLE user_table (
     user_number BIGINT AUTO_INCREMENT PRIMARY KEY,
     user_name VARCHAR(50) NOT NULL,
-    user_phone VARCHAR(20) NOT NULL,
+    user_phone VARCHAR(20) UNIQUE NOT NULL,  -- 숫자만 저장 (01012345678), 계정당 하나
     user_id VARCHAR(50) UNIQUE NOT NULL,
-    user_pw VARCHAR(255) NOT NULL -- 해시된 비밀번호 저장
+    user_pw VARCHAR(255) NOT NULL, -- 해시된 비밀번호 저장
+    phone_verified_at DATETIME NULL,          -- 문자 인증 시각 (인증 기능 도입 전 가입자는 NULL)
+    deleted_at DATETIME NULL                  -- 탈퇴 시각 (행은 남겨 예약/매출 이력을 유지하고 개인정보만 익명화)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
 -- 2. 체육관 테이블: 운영 시간 컬럼(open/close_time) 포함
@@ -33,6 +35,12 @@ CREATE TABLE equipment_table (
     equip_quantity INT DEFAULT 0,
     equip_status VARCHAR(20) DEFAULT 'active', -- 'active' / 'maintenance'
     purchase_date DATE,
+    purchase_price BIGINT DEFAULT 0,                       -- 단가 (원)
+    supplier VARCHAR(100),                                 -- 공급업체
+    warranty_expiry DATE,                                  -- 보증 만료일
+    depreciation_method VARCHAR(20) DEFAULT 'straight_line', -- 'straight_line' / 'declining_balance'
+    useful_life_years INT DEFAULT 5,                       -- 내용연수
+    salvage_value BIGINT DEFAULT 0,                        -- 잔존가치
     FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
@@ -42,7 +50,8 @@ CREATE TABLE revs_table (
     fk_user_number BIGINT NOT NULL,
     fk_guss_number BIGINT NOT NULL,
     revs_time DATETIME DEFAULT CURRENT_TIMESTAMP,
-    revs_status VARCHAR(20) DEFAULT 'CONFIRMED',
+    revs_status VARCHAR(20) DEFAULT 'CONFIRMED', -- 'CONFIRMED' / 'COMPLETED'(퇴실) / 'NO_SHOW'(미방문)
+    fk_pass_number BIGINT NULL,                  -- 예약 시 차감한 이용권
     FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE,
     FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -51,13 +60,331 @@ CREATE TABLE revs_table (
 CREATE TABLE sales_table (
     sales_number BIGINT AUTO_INCREMENT PRIMARY KEY,
     fk_guss_number BIGINT NOT NULL,
-    sales_type VARCHAR(50), -- 'DAILY', 'MONTHLY' 등
+    sales_type VARCHAR(50), -- 상품 유형 ('DAY_PASS', 'VISIT_PASS', 'MEMBERSHIP') 또는 수동 기록 유형
     sales_amount INT DEFAULT 0,
     sales_date DATETIME DEFAULT CURRENT_TIMESTAMP,
+    fk_user_number BIGINT NULL,   -- 구매 회원 (수동 기록은 NULL)
+    fk_order_number BIGINT NULL,  -- 온라인 결제 주문 (현장 판매/수동 기록은 NULL)
+    FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+
+
+-- 6. 관리자 테이블: 권한은 admin_role(role_table)로 결정, fk_guss_number는 담당 지점 (SUPER_ADMIN은 NULL)
+CREATE TABLE admin_table (
+    admin_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    admin_id VARCHAR(50) UNIQUE NOT NULL,
+    admin_pw VARCHAR(255) NOT NULL,
+    admin_role VARCHAR(20) NOT NULL DEFAULT 'GYM_ADMIN', -- 'GYM_STAFF' / 'GYM_ADMIN' / 'SUPER_ADMIN'
+    fk_guss_number BIGINT NULL,
+    FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 7. 기구 예약 테이블: 고수요 기구를 30분 단위로 예약 (unit_no는 같은 기구 중 몇 번째 대인지)
+CREATE TABLE equip_booking_table (
+    booking_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_equip_id BIGINT NOT NULL,
+    fk_user_number BIGINT NOT NULL,
+    fk_revs_number BIGINT NOT NULL,              -- 기구 예약의 근거가 되는 체육관 예약
+    unit_no INT NOT NULL,
+    start_time DATETIME NOT NULL,
+    end_time DATETIME NOT NULL,
+    booking_status VARCHAR(20) DEFAULT 'BOOKED', -- 'BOOKED' / 'CANCELLED' / 'RELEASED'(퇴실 시 반납)
+    INDEX idx_equip_unit_time (fk_equip_id, unit_no, start_time),
+    FOREIGN KEY (fk_equip_id) REFERENCES equipment_table(equip_id) ON DELETE CASCADE,
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE,
+    FOREIGN KEY (fk_revs_number) REFERENCES revs_table(revs_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 8. 상품 테이블: 지점별 이용권 카탈로그 (일일권 / N회권 / 월회원권)
+CREATE TABLE product_table (
+    product_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_guss_number BIGINT NOT NULL,
+    product_name VARCHAR(100) NOT NULL,
+    product_type VARCHAR(20) NOT NULL,  -- 'DAY_PASS' / 'VISIT_PASS' / 'MEMBERSHIP'
+    product_price BIGINT NOT NULL,
+    visit_count INT NULL,               -- 이용 가능 횟수 (NULL이면 기간 내 무제한)
+    validity_days INT NOT NULL,         -- 구매일로부터 유효 기간(일)
+    is_active TINYINT(1) DEFAULT 1,     -- 판매 중지 시 0 (기존 이용권은 유지)
     FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
+-- 9. 회원 이용권 테이블: 예약 시 잔여 횟수 차감
+CREATE TABLE pass_table (
+    pass_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_user_number BIGINT NOT NULL,
+    fk_product_number BIGINT NOT NULL,
+    fk_guss_number BIGINT NOT NULL,
+    remaining_visits INT NULL,                -- NULL이면 무제한 (월회원권)
+    starts_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    pass_status VARCHAR(20) DEFAULT 'ACTIVE', -- 'ACTIVE' / 'USED_UP' / 'CANCELLED'
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_pass_user_gym (fk_user_number, fk_guss_number, pass_status),
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE,
+    FOREIGN KEY (fk_product_number) REFERENCES product_table(product_number),
+    FOREIGN KEY (fk_guss_number) REFERENCES guss_table(guss_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 10. 이용권 일시정지 이력: 정지 일수만큼 pass_table.expires_at 연장, 정지 기간 중 예약 불가
+CREATE TABLE pass_freeze_table (
+    freeze_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_pass_number BIGINT NOT NULL,
+    start_at DATETIME NOT NULL,  -- 한국 시간 자정 기준 (UTC 저장)
+    end_at DATETIME NOT NULL,    -- 미포함
+    freeze_days INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_freeze_pass (fk_pass_number, start_at),
+    FOREIGN KEY (fk_pass_number) REFERENCES pass_table(pass_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 11. 이용권 양도 이력
+CREATE TABLE pass_transfer_table (
+    transfer_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_pass_number BIGINT NOT NULL,
+    from_user_number BIGINT NOT NULL,
+    to_user_number BIGINT NOT NULL,
+    transferred_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (fk_pass_number) REFERENCES pass_table(pass_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 12. 주문 테이블: 결제 대기(PENDING) -> 결제사 웹훅 확인 후 PAID (이용권 발급 + 매출 기록)
+CREATE TABLE order_table (
+    order_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_user_number BIGINT NOT NULL,
+    fk_guss_number BIGINT NOT NULL,
+    fk_product_number BIGINT NOT NULL,
+    product_name VARCHAR(100) NOT NULL DEFAULT '', -- 주문 시점 상품 정보 (이후 상품 수정과 무관하게 이용권/영수증 발급)
+    product_type VARCHAR(20) NOT NULL DEFAULT '',
+    visit_count INT NOT NULL DEFAULT 0,            -- 0이면 기간 내 무제한
+    validity_days INT NOT NULL DEFAULT 0,
+    order_amount BIGINT NOT NULL,                 -- 실제 결제 금액 (할인 후)
+    list_price BIGINT NOT NULL DEFAULT 0,         -- 할인 전 상품 가격
+    discount_amount BIGINT NOT NULL DEFAULT 0,
+    fk_coupon_number BIGINT NULL,
+    coupon_code VARCHAR(40) NOT NULL DEFAULT '',  -- 주문 시점 쿠폰 코드 (영수증 표기용)
+    order_status VARCHAR(20) DEFAULT 'PENDING', -- 'PENDING' / 'PAID' / 'FAILED' / 'REFUNDED'
+    payment_id VARCHAR(64) NULL UNIQUE,         -- 결제사 결제 ID
+    fk_pass_number BIGINT NULL,
+    fk_sales_number BIGINT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    paid_at DATETIME NULL,
+    INDEX idx_order_user (fk_user_number),
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE,
+    FOREIGN KEY (fk_product_number) REFERENCES product_table(product_number)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 13. 환불 테이블: 주문 단위 환불 요청/승인 이력 (환불 금액은 sales_table에 음수로도 기록)
+CREATE TABLE refund_table (
+    refund_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_order_number BIGINT NOT NULL,
+    fk_user_number BIGINT NOT NULL,
+    fk_guss_number BIGINT NOT NULL,
+    paid_amount BIGINT NOT NULL,                  -- 결제 금액
+    refund_amount BIGINT NOT NULL,                -- 환불 정책으로 계산된 금액
+    refund_status VARCHAR(20) DEFAULT 'REQUESTED', -- 'REQUESTED' / 'PROCESSING' / 'PROVIDER_DONE'(결제사 환불 완료, 기록 대기) / 'REFUNDED' / 'REJECTED'
+    refund_reason VARCHAR(255) NULL,
+    admin_note VARCHAR(255) NULL,
+    processed_by VARCHAR(50) NULL,                -- 승인/거절 관리자 ID
+    fk_sales_number BIGINT NULL,                  -- 환불 매출(음수) 기록
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    processed_at DATETIME NULL,
+    INDEX idx_refund_status (fk_guss_number, refund_status),
+    FOREIGN KEY (fk_order_number) REFERENCES order_table(order_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 14. 쿠폰 테이블: 프로모션 코드 (정률/정액/무료)
+CREATE TABLE coupon_table (
+    coupon_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    coupon_code VARCHAR(40) NOT NULL UNIQUE,      -- 대문자로 저장
+    coupon_name VARCHAR(100) NOT NULL,
+    discount_type VARCHAR(10) NOT NULL,           -- 'PERCENT' / 'FIXED' / 'FREE'
+    discount_value BIGINT NOT NULL DEFAULT 0,     -- PERCENT: %, FIXED: 원
+    max_discount BIGINT NOT NULL DEFAULT 0,       -- 정률 할인 상한 (0이면 없음)
+    product_type VARCHAR(20) NULL,                -- 특정 상품 유형만 (NULL이면 전체)
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    total_limit INT NOT NULL DEFAULT 0,           -- 전체 사용 한도 (0이면 무제한)
+    per_user_limit INT NOT NULL DEFAULT 1,        -- 회원당 사용 한도 (0이면 무제한)
+    first_purchase_only TINYINT(1) DEFAULT 0,     -- 첫 구매 회원 전용
+    is_active TINYINT(1) DEFAULT 1,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 15. 쿠폰 적용 대상: 지정된 지점/상품에만 사용 가능 (대상이 없으면 전체)
+CREATE TABLE coupon_target_table (
+    fk_coupon_number BIGINT NOT NULL,
+    target_type VARCHAR(10) NOT NULL,             -- 'GYM' / 'PRODUCT'
+    target_id BIGINT NOT NULL,
+    PRIMARY KEY (fk_coupon_number, target_type, target_id),
+    FOREIGN KEY (fk_coupon_number) REFERENCES coupon_table(coupon_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 16. 쿠폰 사용 이력: 주문당 1건, 결제 실패 시 RELEASED로 한도 반환
+CREATE TABLE coupon_redemption_table (
+    redemption_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_coupon_number BIGINT NOT NULL,
+    fk_user_number BIGINT NOT NULL,
+    fk_order_number BIGINT NOT NULL UNIQUE,
+    discount_amount BIGINT NOT NULL,
+    redemption_status VARCHAR(20) DEFAULT 'RESERVED', -- 'RESERVED' / 'REDEEMED' / 'RELEASED'
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_redemption_coupon (fk_coupon_number, redemption_status),
+    FOREIGN KEY (fk_coupon_number) REFERENCES coupon_table(coupon_number),
+    FOREIGN KEY (fk_order_number) REFERENCES order_table(order_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 17. 영수증 테이블: 매출 한 건당 한 장, 지점별 일련번호 (공급가액/부가세 분리 저장)
+CREATE TABLE receipt_table (
+    receipt_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_guss_number BIGINT NOT NULL,
+    receipt_seq BIGINT NOT NULL,                  -- 지점별 일련번호 (receipt_seq_table에서 발급)
+    fk_sales_number BIGINT NOT NULL UNIQUE,
+    fk_user_number BIGINT NULL,
+    fk_order_number BIGINT NULL,
+    issued_at DATETIME NOT NULL,
+    total_amount BIGINT NOT NULL,                 -- 부가세 포함 (환불 영수증은 음수)
+    supply_amount BIGINT NOT NULL,
+    vat_amount BIGINT NOT NULL,
+    UNIQUE KEY uk_receipt_seq (fk_guss_number, receipt_seq),
+    INDEX idx_receipt_user (fk_user_number),
+    FOREIGN KEY (fk_sales_number) REFERENCES sales_table(sales_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 18. 영수증 항목
+CREATE TABLE receipt_line_table (
+    line_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_receipt_number BIGINT NOT NULL,
+    line_description VARCHAR(200) NOT NULL,
+    quantity INT NOT NULL DEFAULT 1,
+    unit_price BIGINT NOT NULL,
+    line_amount BIGINT NOT NULL,
+    FOREIGN KEY (fk_receipt_number) REFERENCES receipt_table(receipt_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 19. 지점별 영수증 일련번호 카운터 (행 잠금으로 트랜잭션 간 번호 중복/누락 방지)
+CREATE TABLE receipt_seq_table (
+    fk_guss_number BIGINT PRIMARY KEY,
+    last_seq BIGINT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
+-- 20. 리프레시 토큰 테이블: 토큰 원문 대신 SHA-256 해시 저장, family_id 단위로 재사용 감지 시 일괄 폐기
+CREATE TABLE refresh_token_table (
+    token_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    family_id CHAR(32) NOT NULL,
+    principal_type VARCHAR(10) NOT NULL,          -- 'USER' / 'ADMIN'
+    principal_number BIGINT NOT NULL,             -- user_number 또는 admin_number
+    principal_id VARCHAR(50) NOT NULL,            -- 로그인 ID (재발급 시 권한 재조회용)
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    used_at DATETIME NULL,                        -- 교체(rotation)된 시각
+    revoked_at DATETIME NULL,                     -- 로그아웃/재사용 감지로 폐기된 시각
+    INDEX idx_refresh_family (family_id),
+    INDEX idx_refresh_principal (principal_type, principal_number)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 21. 역할 테이블: 일반 회원(USER)은 user_table, 나머지 역할은 admin_table.admin_role로 부여
+CREATE TABLE role_table (
+    role_code VARCHAR(20) PRIMARY KEY,
+    role_name VARCHAR(50) NOT NULL,
+    mfa_required TINYINT(1) NOT NULL DEFAULT 0 -- 1이면 OTP 2단계 인증을 등록해야 로그인 완료
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 22. 역할별 세부 권한: 로그인/토큰 재발급 시 조회되어 JWT에 포함됨
+CREATE TABLE role_permission_table (
+    fk_role_code VARCHAR(20) NOT NULL,
+    permission VARCHAR(50) NOT NULL, -- 'sales:view', 'refund:manage', 'gym:all'(전 지점) 등
+    PRIMARY KEY (fk_role_code, permission),
+    FOREIGN KEY (fk_role_code) REFERENCES role_table(role_code) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 23. 로그인 실패 집계: 여러 서버가 공유하는 계정별('acct:USER:아이디')/IP별('ip:주소') 실패 횟수와 잠금 시각
+CREATE TABLE login_attempt_table (
+    attempt_key VARCHAR(120) PRIMARY KEY,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure DATETIME NOT NULL,
+    locked_until DATETIME NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 24. 관리자 OTP(TOTP) 2단계 인증: enabled_at이 NULL이면 등록만 시작하고 아직 확인 코드를 입력하지 않은 상태
+CREATE TABLE admin_mfa_table (
+    fk_admin_number BIGINT PRIMARY KEY,
+    totp_secret VARCHAR(64) NOT NULL,            -- Base32 비밀키 (DB 접근 권한으로 보호)
+    enabled_at DATETIME NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,    -- 같은 OTP 코드 재사용 방지
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (fk_admin_number) REFERENCES admin_table(admin_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 25. OTP 복구 코드: 원문은 등록 시 한 번만 보여주고 SHA-256 해시만 저장, 1회용
+CREATE TABLE admin_recovery_code_table (
+    code_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_admin_number BIGINT NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at DATETIME NULL,
+    UNIQUE KEY uq_recovery_code (fk_admin_number, code_hash),
+    FOREIGN KEY (fk_admin_number) REFERENCES admin_table(admin_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 26. 비밀번호 재설정 코드: 회원 휴대폰으로 보낸 6자리 코드의 SHA-256 해시, 1회용/10분 유효
+CREATE TABLE password_reset_table (
+    reset_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_user_number BIGINT NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,              -- 오입력 횟수 (한도 초과 시 used_at을 채워 폐기)
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    used_at DATETIME NULL,                        -- 사용/폐기 시각 (새 코드 발급 시 이전 코드도 폐기)
+    INDEX idx_reset_user (fk_user_number, used_at),
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 27. 가입 대기: 휴대폰 인증 전까지 가입 정보를 보관하고 인증이 끝나면 user_table에 넣은 뒤 삭제 (번호당 1건)
+CREATE TABLE signup_verification_table (
+    signup_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    user_name VARCHAR(50) NOT NULL,
+    user_phone VARCHAR(20) NOT NULL,
+    user_pw VARCHAR(255) NOT NULL,                -- 해시된 비밀번호
+    code_hash CHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at DATETIME NOT NULL,                 -- 오입력 한도를 넘기면 즉시 만료 처리
+    created_at DATETIME NOT NULL,
+    INDEX idx_signup_phone (user_phone)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 28. 휴대폰 번호 변경 대기: 새 번호로 보낸 코드를 확인해야 user_table에 반영 (회원당 최신 1건만 유효)
+CREATE TABLE phone_change_table (
+    change_number BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fk_user_number BIGINT NOT NULL,
+    new_phone VARCHAR(20) NOT NULL,               -- 숫자만 (01012345678)
+    code_hash CHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    used_at DATETIME NULL,                        -- 반영/폐기 시각 (새 요청 시 이전 건도 폐기)
+    INDEX idx_phone_change_user (fk_user_number, used_at),
+    FOREIGN KEY (fk_user_number) REFERENCES user_table(user_number) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 기본 역할 및 권한 (internal/auth/permissions.go의 DefaultRolePermissions와 동일하게 유지)
+INSERT INTO role_table (role_code, role_name, mfa_required)
+VALUES
+('USER', '일반 회원', 0),
+('GYM_STAFF', '지점 직원', 0),
+('GYM_ADMIN', '지점 관리자', 1),
+('SUPER_ADMIN', '최고 관리자', 1);
+
+INSERT INTO role_permission_table (fk_role_code, permission)
+VALUES
+('GYM_STAFF', 'dashboard:view'), ('GYM_STAFF', 'reservation:manage'), ('GYM_STAFF', 'pass:issue'), ('GYM_STAFF', 'inventory:view'),
+('GYM_ADMIN', 'dashboard:view'), ('GYM_ADMIN', 'reservation:manage'), ('GYM_ADMIN', 'equipment:manage'), ('GYM_ADMIN', 'inventory:view'),
+('GYM_ADMIN', 'product:manage'), ('GYM_ADMIN', 'pass:issue'), ('GYM_ADMIN', 'sales:view'), ('GYM_ADMIN', 'sales:write'),
+('GYM_ADMIN', 'coupon:manage'), ('GYM_ADMIN', 'refund:manage'), ('GYM_ADMIN', 'account:unlock'),
+('SUPER_ADMIN', 'dashboard:view'), ('SUPER_ADMIN', 'reservation:manage'), ('SUPER_ADMIN', 'equipment:manage'), ('SUPER_ADMIN', 'inventory:view'),
+('SUPER_ADMIN', 'product:manage'), ('SUPER_ADMIN', 'pass:issue'), ('SUPER_ADMIN', 'sales:view'), ('SUPER_ADMIN', 'sales:write'),
+('SUPER_ADMIN', 'coupon:manage'), ('SUPER_ADMIN', 'refund:manage'), ('SUPER_ADMIN', 'account:unlock'), ('SUPER_ADMIN', 'gym:all');
 
 -- 테스트 체육관 5개 주입
 INSERT INTO guss_table (guss_name, guss_address, guss_phone, guss_size, guss_open_time, guss_close_time) 
@@ -69,8 +396,22 @@ VALUES
 ('잠실 스포츠 콤플렉스', '서울 송파구 올림픽로 25', '02-444-5555', 80, '06:00', '24:00');
 
 -- 1번 체육관(명지대) 샘플 기구 주입
-INSERT INTO equipment_table (fk_guss_number, equip_name, equip_category, equip_quantity, equip_status, purchase_date)
+INSERT INTO equipment_table (fk_guss_number, equip_name, equip_category, equip_quantity, equip_status, purchase_date,
+                             purchase_price, supplier, warranty_expiry, depreciation_method, useful_life_years, salvage_value)
 VALUES 
-(1, '천국의 계단', '유산소', 2, 'active', '2025-01-10'),
-(1, '레그 프레스', '하체', 1, 'active', '2024-12-20'),
-(1, '덤벨 세트', '프리웨이트', 10, 'active', '2025-01-05');
+(1, '천국의 계단', '유산소', 2, 'active', '2025-01-10', 8900000, '스텝밀코리아', '2027-01-10', 'straight_line', 7, 900000),
+(1, '레그 프레스', '하체', 1, 'active', '2024-12-20', 6200000, '헬스원', '2026-12-20', 'declining_balance', 8, 600000),
+(1, '덤벨 세트', '프리웨이트', 10, 'active', '2025-01-05', 150000, '아이언짐', NULL, 'straight_line', 10, 0);
+
+-- 1번 체육관(명지대) 샘플 상품 주입
+INSERT INTO product_table (fk_guss_number, product_name, product_type, product_price, visit_count, validity_days)
+VALUES
+(1, '일일권', 'DAY_PASS', 10000, 1, 1),
+(1, '10회권', 'VISIT_PASS', 80000, 10, 90),
+(1, '1개월 회원권', 'MEMBERSHIP', 100000, NULL, 30);
+
+-- 테스트 쿠폰: 첫 구매 일일권 무료 / 전 상품 10% 할인 (최대 2만원)
+INSERT INTO coupon_table (coupon_code, coupon_name, discount_type, discount_value, max_discount, product_type, starts_at, ends_at, total_limit, per_user_limit, first_purchase_only)
+VALUES
+('FIRSTDAY', '첫 방문 일일권 무료', 'FREE', 0, 0, 'DAY_PASS', '2026-01-01 00:00:00', '2027-01-01 00:00:00', 0, 1, 1),
+('WELCOME10', '신규 오픈 10% 할인', 'PERCENT', 10, 20000, NULL, '2026-01-01 00:00:00', '2027-01-01 00:00:00', 500, 1, 0);
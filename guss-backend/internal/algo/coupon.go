@@ -0,0 +1,61 @@
+package algo
+
+import (
+	"errors"
+	"guss-backend/internal/domain"
+	"time"
+)
+
+var (
+	ErrCouponInactive      = errors.New("사용할 수 없는 쿠폰입니다")
+	ErrCouponExpired       = errors.New("쿠폰 사용 기간이 아닙니다")
+	ErrCouponNotApplicable = errors.New("이 상품에는 사용할 수 없는 쿠폰입니다")
+)
+
+// CouponDiscount: 상품에 쿠폰을 적용했을 때 할인 금액 (사용 한도/첫 구매 여부는 저장소에서 잠금 후 확인)
+// 정률 할인은 10원 미만 절사 후 상한 적용, 할인 금액은 상품 가격을 넘지 않는다.
+func CouponDiscount(c *domain.Coupon, p *domain.Product, now time.Time) (int64, error) {
+	if !c.IsActive {
+		return 0, ErrCouponInactive
+	}
+	if now.Before(c.StartsAt) || !now.Before(c.EndsAt) {
+		return 0, ErrCouponExpired
+	}
+	if c.ProductType != "" && c.ProductType != p.Type {
+		return 0, ErrCouponNotApplicable
+	}
+	if len(c.GymIDs) > 0 && !containsID(c.GymIDs, p.GymID) {
+		return 0, ErrCouponNotApplicable
+	}
+	if len(c.ProductNumbers) > 0 && !containsID(c.ProductNumbers, p.ProductNumber) {
+		return 0, ErrCouponNotApplicable
+	}
+
+	var discount int64
+	switch c.DiscountType {
+	case domain.DiscountPercent:
+		discount = p.Price * c.DiscountValue / 100 / 10 * 10
+		if c.MaxDiscount > 0 && discount > c.MaxDiscount {
+			discount = c.MaxDiscount
+		}
+	case domain.DiscountFixed:
+		discount = c.DiscountValue
+	case domain.DiscountFree:
+		discount = p.Price
+	default:
+		return 0, ErrCouponInactive
+	}
+	if discount > p.Price {
+		discount = p.Price
+	}
+	return discount, nil
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
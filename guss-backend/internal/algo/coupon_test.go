@@ -0,0 +1,52 @@
+package algo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"guss-backend/internal/domain"
+)
+
+func TestCouponDiscount(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, KST)
+	product := &domain.Product{ProductNumber: 3, GymID: 1, Type: "MEMBERSHIP", Price: 99990}
+	coupon := func(edit func(c *domain.Coupon)) *domain.Coupon {
+		c := &domain.Coupon{DiscountType: domain.DiscountPercent, DiscountValue: 10, IsActive: true,
+			StartsAt: now.AddDate(0, 0, -1), EndsAt: now.AddDate(0, 0, 1)}
+		edit(c)
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		coupon  *domain.Coupon
+		want    int64
+		wantErr error
+	}{
+		{"정률 할인 10원 미만 절사", coupon(func(c *domain.Coupon) {}), 9990, nil},
+		{"정률 할인 상한", coupon(func(c *domain.Coupon) { c.DiscountValue = 50; c.MaxDiscount = 20000 }), 20000, nil},
+		{"상한 0은 제한 없음", coupon(func(c *domain.Coupon) { c.DiscountValue = 50 }), 49990, nil},
+		{"정액 할인", coupon(func(c *domain.Coupon) { c.DiscountType = domain.DiscountFixed; c.DiscountValue = 5000 }), 5000, nil},
+		{"정액 할인이 가격보다 크면 가격까지", coupon(func(c *domain.Coupon) { c.DiscountType = domain.DiscountFixed; c.DiscountValue = 150000 }), 99990, nil},
+		{"전액 무료", coupon(func(c *domain.Coupon) { c.DiscountType = domain.DiscountFree }), 99990, nil},
+		{"상품 유형 일치", coupon(func(c *domain.Coupon) { c.ProductType = "MEMBERSHIP" }), 9990, nil},
+		{"상품 유형 불일치", coupon(func(c *domain.Coupon) { c.ProductType = "DAY_PASS" }), 0, ErrCouponNotApplicable},
+		{"지점 목록 포함", coupon(func(c *domain.Coupon) { c.GymIDs = []int64{2, 1} }), 9990, nil},
+		{"지점 목록 제외", coupon(func(c *domain.Coupon) { c.GymIDs = []int64{2} }), 0, ErrCouponNotApplicable},
+		{"상품 목록 포함", coupon(func(c *domain.Coupon) { c.ProductNumbers = []int64{3} }), 9990, nil},
+		{"상품 목록 제외", coupon(func(c *domain.Coupon) { c.ProductNumbers = []int64{4, 5} }), 0, ErrCouponNotApplicable},
+		{"비활성 쿠폰", coupon(func(c *domain.Coupon) { c.IsActive = false }), 0, ErrCouponInactive},
+		{"시작 전", coupon(func(c *domain.Coupon) { c.StartsAt = now.Add(time.Minute) }), 0, ErrCouponExpired},
+		{"종료 시각은 미포함", coupon(func(c *domain.Coupon) { c.EndsAt = now }), 0, ErrCouponExpired},
+		{"알 수 없는 할인 방식", coupon(func(c *domain.Coupon) { c.DiscountType = "BOGO" }), 0, ErrCouponInactive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CouponDiscount(tt.coupon, product, now)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("CouponDiscount() = %d, %v; want %d, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package algo
+
+import (
+	"math"
+	"time"
+)
+
+// 감가상각 방식 (equipment_table.depreciation_method 값)
+const (
+	DepreciationStraightLine     = "straight_line"     // 정액법
+	DepreciationDecliningBalance = "declining_balance" // 정률법 (이중체감법)
+)
+
+const daysPerYear = 365.25
+
+// DepreciationResult: 기준일 시점의 단가 기준 감가상각 결과
+type DepreciationResult struct {
+	Cost                    int64
+	AccumulatedDepreciation int64
+	BookValue               int64
+	ElapsedYears            float64
+}
+
+// IsValidDepreciationMethod: 지원하는 감가상각 방식인지 확인
+func IsValidDepreciationMethod(method string) bool {
+	return method == DepreciationStraightLine || method == DepreciationDecliningBalance
+}
+
+// Depreciate: 취득가, 잔존가치, 내용연수와 취득일을 받아 asOf 시점의 장부가를 계산
+// 내용연수가 0 이하이거나 취득일이 미래인 경우 감가상각하지 않음
+func Depreciate(method string, cost, salvage int64, lifeYears int, purchased, asOf time.Time) DepreciationResult {
+	res := DepreciationResult{Cost: cost, BookValue: cost}
+	if cost <= 0 {
+		res.BookValue = 0
+		return res
+	}
+	if salvage < 0 {
+		salvage = 0
+	}
+	if salvage > cost {
+		salvage = cost
+	}
+
+	elapsed := asOf.Sub(purchased).Hours() / 24 / daysPerYear
+	if elapsed <= 0 || lifeYears <= 0 {
+		return res
+	}
+	res.ElapsedYears = math.Round(elapsed*100) / 100
+
+	var book float64
+	switch method {
+	case DepreciationDecliningBalance:
+		book = decliningBalanceBook(float64(cost), float64(salvage), lifeYears, elapsed)
+	default:
+		// 정액법: (취득가 - 잔존가치) / 내용연수 를 매년 균등 상각
+		ratio := elapsed / float64(lifeYears)
+		if ratio > 1 {
+			ratio = 1
+		}
+		book = float64(cost) - float64(cost-salvage)*ratio
+	}
+
+	res.BookValue = int64(math.Round(book))
+	res.AccumulatedDepreciation = cost - res.BookValue
+	return res
+}
+
+// decliningBalanceBook: 이중체감법 장부가 - 매년 초 장부가의 2/내용연수를 상각하다가
+// 남은 상각액을 잔여 내용연수로 나눈 정액 상각액이 더 커지는 해부터 정액법으로 전환한다.
+// 연도 중간은 그 해 상각액을 경과 비율만큼 반영하고, 잔존가치 아래로는 내려가지 않는다.
+func decliningBalanceBook(cost, salvage float64, lifeYears int, elapsed float64) float64 {
+	rate := 2.0 / float64(lifeYears)
+	if rate > 1 {
+		rate = 1
+	}
+	book := cost
+	for year := 0; year < lifeYears && elapsed > 0; year++ {
+		charge := math.Max(book*rate, (book-salvage)/float64(lifeYears-year))
+		if charge > book-salvage {
+			charge = book - salvage
+		}
+		if elapsed < 1 {
+			return book - charge*elapsed
+		}
+		book -= charge
+		elapsed--
+	}
+	return math.Max(book, salvage)
+}
@@ -0,0 +1,75 @@
+package algo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecliningBalanceBook(t *testing.T) {
+	tests := []struct {
+		name      string
+		cost      float64
+		salvage   float64
+		lifeYears int
+		elapsed   float64
+		want      float64
+	}{
+		// 내용연수 5년 -> 정률 40%: 1,000,000 -> 600,000 -> 360,000 -> 216,000
+		{"정률 구간", 1000000, 0, 5, 3, 216000},
+		// 4년차 정률 상각(86,400)보다 정액 상각(216,000/2 = 108,000)이 커서 정액법으로 전환
+		{"전환 해 중간", 1000000, 0, 5, 3.5, 162000},
+		{"전환 후", 1000000, 0, 5, 4, 108000},
+		{"내용연수 종료", 1000000, 0, 5, 5, 0},
+		// 마지막 해 정률 상각(51,840)이 잔존가치까지 남은 금액(29,600)보다 커도 잔존가치에서 멈춤
+		{"잔존가치 하한", 1000000, 100000, 5, 5, 100000},
+		{"내용연수 초과", 1000000, 100000, 5, 12, 100000},
+		{"내용연수 1년 (정률 100%)", 1000000, 100000, 1, 0.5, 550000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := decliningBalanceBook(tt.cost, tt.salvage, tt.lifeYears, tt.elapsed)
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("decliningBalanceBook(%v, %v, %d, %v) = %v, want %v", tt.cost, tt.salvage, tt.lifeYears, tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDepreciate(t *testing.T) {
+	purchased := time.Date(2020, 1, 1, 0, 0, 0, 0, KST)
+	years := func(y float64) time.Time {
+		return purchased.Add(time.Duration(y * daysPerYear * 24 * float64(time.Hour)))
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		cost      int64
+		salvage   int64
+		lifeYears int
+		asOf      time.Time
+		wantBook  int64
+	}{
+		{"정액법 중간", DepreciationStraightLine, 1000000, 100000, 5, years(2.5), 550000},
+		{"정액법 잔존가치 하한", DepreciationStraightLine, 1000000, 100000, 5, years(8), 100000},
+		{"정률법 전환 해", DepreciationDecliningBalance, 1000000, 0, 5, years(3.5), 162000},
+		{"정률법 잔존가치 하한", DepreciationDecliningBalance, 1000000, 100000, 5, years(6), 100000},
+		{"내용연수 0", DepreciationDecliningBalance, 1000000, 100000, 0, years(3), 1000000},
+		{"내용연수 음수", DepreciationStraightLine, 1000000, 100000, -1, years(3), 1000000},
+		{"미래 취득일", DepreciationStraightLine, 1000000, 100000, 5, years(-1), 1000000},
+		{"잔존가치가 취득가 초과", DepreciationStraightLine, 1000000, 2000000, 5, years(3), 1000000},
+		{"취득가 0", DepreciationStraightLine, 0, 0, 5, years(3), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Depreciate(tt.method, tt.cost, tt.salvage, tt.lifeYears, purchased, tt.asOf)
+			if got.BookValue != tt.wantBook {
+				t.Errorf("BookValue = %d, want %d", got.BookValue, tt.wantBook)
+			}
+			if got.AccumulatedDepreciation != tt.cost-tt.wantBook {
+				t.Errorf("AccumulatedDepreciation = %d, want %d", got.AccumulatedDepreciation, tt.cost-tt.wantBook)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+	"guss-backend/internal/domain"
+	"time"
+)
+
+// FreezePolicy: 회원권 일시정지 규칙
+type FreezePolicy struct {
+	MinDays           int // 1회 최소 정지 일수
+	MaxDays           int // 1회 최대 정지 일수
+	MaxFreezesPerYear int // 연간(한국 시간 기준 달력 연도) 최대 정지 횟수
+	MaxDaysPerYear    int // 연간 누적 최대 정지 일수
+}
+
+var DefaultFreezePolicy = FreezePolicy{
+	MinDays:           7,
+	MaxDays:           60,
+	MaxFreezesPerYear: 2,
+	MaxDaysPerYear:    90,
+}
+
+// IsFreezable: 일시정지/양도가 가능한 상품 유형인지 (일일권은 불가)
+func IsFreezable(productType string) bool {
+	return productType == domain.ProductMembership || productType == domain.ProductVisitPass
+}
+
+// FreezeWindow: 시작일(한국 시간 자정)부터 days일 동안의 정지 구간 [start, end)
+func FreezeWindow(startDate time.Time, days int) (time.Time, time.Time) {
+	d := startDate.In(KST)
+	start := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, KST)
+	return start, start.AddDate(0, 0, days)
+}
+
+// CheckFreeze: 새 정지 요청이 정책과 기존 정지 이력에 맞는지 검사
+func (p FreezePolicy) CheckFreeze(pass *domain.Pass, start time.Time, days int, now time.Time, history []domain.PassFreeze) error {
+	if !IsFreezable(pass.ProductType) {
+		return errors.New("일일권은 일시정지할 수 없습니다")
+	}
+	if pass.Status != domain.PassActive || !now.Before(pass.ExpiresAt) {
+		return errors.New("사용 중인 이용권만 일시정지할 수 있습니다")
+	}
+	if days < p.MinDays || days > p.MaxDays {
+		return fmt.Errorf("정지 기간은 %d일 이상 %d일 이하여야 합니다", p.MinDays, p.MaxDays)
+	}
+
+	today, _ := FreezeWindow(now, 0)
+	start, end := FreezeWindow(start, days)
+	if start.Before(today) {
+		return errors.New("지난 날짜부터 일시정지할 수 없습니다")
+	}
+	if !start.Before(pass.ExpiresAt) {
+		return errors.New("정지 시작일이 이용권 만료일 이후입니다")
+	}
+
+	count, total := 0, days
+	for _, f := range history {
+		if start.Before(f.EndAt) && f.StartAt.Before(end) {
+			return errors.New("기존 일시정지 기간과 겹칩니다")
+		}
+		if f.StartAt.In(KST).Year() == start.Year() {
+			count++
+			total += f.Days
+		}
+	}
+	if count >= p.MaxFreezesPerYear {
+		return fmt.Errorf("해당 연도 일시정지 가능 횟수(%d회)를 모두 사용했습니다", p.MaxFreezesPerYear)
+	}
+	if total > p.MaxDaysPerYear {
+		return fmt.Errorf("해당 연도 일시정지 가능 일수(%d일)를 초과합니다", p.MaxDaysPerYear)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package algo
+
+import (
+	"guss-backend/internal/domain"
+	"math"
+	"time"
+)
+
+// RefundPolicy: 이용권 환불 규칙 (온라인 주문과 현장 발급 이용권에 같이 적용)
+// 요청서의 "X시간 전 취소 시 전액 환불"은 이용 시작 시각을 기준으로 하지만, 이 시스템의 이용권은
+// 구매 즉시 시작되고 예약도 방문 시점에 바로 확정되어 미리 잡힌 이용 시각이 없다.
+// 그래서 결제(현장 발급) 후 FullRefundHours 이내에 한 번도 쓰지 않았으면 전액 환불하는 숙려 기간으로 적용한다.
+type RefundPolicy struct {
+	FullRefundHours   int     // 결제 후 이 시간 이내이고 한 번도 사용하지 않았으면 전액 환불
+	CancellationFee   float64 // 그 이후 부분 환불 시 공제하는 위약금 비율 (0.1 = 10%)
+	ApprovalThreshold int64   // 환불 금액이 이 값을 넘으면 관리자 승인 필요 (0이면 항상 자동 환불)
+}
+
+var DefaultRefundPolicy = RefundPolicy{
+	FullRefundHours:   24,
+	CancellationFee:   0.1,
+	ApprovalThreshold: 100000,
+}
+
+// RefundUsage: 환불 계산에 필요한 이용권 사용 이력
+type RefundUsage struct {
+	Visits  int // 입장(예약) 횟수 (미방문 제외)
+	NoShows int // 미방문 처리된 예약 수
+}
+
+// RefundQuote: 환불 계산 결과 (Amount가 0이면 환불 불가, Reason에 사유)
+type RefundQuote struct {
+	Amount int64  `json:"amount"`
+	Full   bool   `json:"full"`
+	Reason string `json:"reason"`
+}
+
+// NeedsApproval: 관리자 승인이 필요한 환불 금액인지
+func (p RefundPolicy) NeedsApproval(amount int64) bool {
+	return p.ApprovalThreshold > 0 && amount > p.ApprovalThreshold
+}
+
+// Quote: 결제 금액/시각(현장 발급은 발급 시각)과 이용권 상태로 환불 가능 금액 계산
+// 미방문 이력이 있으면 환불 불가, 기한 내 미사용이면 전액, 그 외에는 남은 이용분에서 위약금을 뺀 금액(10원 미만 절사).
+func (p RefundPolicy) Quote(paid int64, paidAt time.Time, product *domain.Product, pass *domain.Pass, usage RefundUsage, now time.Time) RefundQuote {
+	if pass.Status == domain.PassCancelled {
+		return RefundQuote{Reason: "이미 취소된 이용권입니다"}
+	}
+	if paid <= 0 {
+		return RefundQuote{Reason: "결제 금액이 없는 주문(무료 쿠폰 등)은 환불되지 않습니다"}
+	}
+	if usage.NoShows > 0 {
+		return RefundQuote{Reason: "미방문(노쇼) 이력이 있는 이용권은 환불되지 않습니다"}
+	}
+	if usage.Visits == 0 && now.Before(paidAt.Add(time.Duration(p.FullRefundHours)*time.Hour)) {
+		return RefundQuote{Amount: paid, Full: true, Reason: "결제 후 기한 내 미사용 전액 환불"}
+	}
+	if pass.Status == domain.PassUsedUp || !now.Before(pass.ExpiresAt) {
+		return RefundQuote{Reason: "만료되었거나 모두 사용한 이용권입니다"}
+	}
+
+	ratio := 1.0
+	switch {
+	case product.VisitCount > 0:
+		// 횟수권(일일권 포함): 남은 횟수 비율
+		if pass.RemainingVisits != nil {
+			ratio = float64(*pass.RemainingVisits) / float64(product.VisitCount)
+		}
+	case usage.Visits > 0:
+		// 기간제 회원권: 사용을 시작했으면 남은 기간 비율 (정지로 연장된 기간 포함)
+		total := pass.ExpiresAt.Sub(pass.StartsAt)
+		if total > 0 {
+			ratio = float64(pass.ExpiresAt.Sub(now)) / float64(total)
+		}
+	}
+	ratio = math.Max(0, math.Min(1, ratio))
+
+	amount := int64(math.Floor(float64(paid)*ratio*(1-p.CancellationFee))) / 10 * 10
+	if amount <= 0 {
+		return RefundQuote{Reason: "환불 가능한 잔여 금액이 없습니다"}
+	}
+	return RefundQuote{Amount: amount, Reason: "잔여 이용분 부분 환불 (위약금 공제)"}
+}
@@ -0,0 +1,73 @@
+package algo
+
+import (
+	"testing"
+	"time"
+
+	"guss-backend/internal/domain"
+)
+
+func TestRefundPolicyQuote(t *testing.T) {
+	policy := RefundPolicy{FullRefundHours: 24, CancellationFee: 0.1, ApprovalThreshold: 100000}
+	paidAt := time.Date(2026, 10, 1, 10, 0, 0, 0, KST)
+	visits := func(n int) *int { return &n }
+
+	visitPass := &domain.Product{Type: "VISIT_PASS", VisitCount: 10, ValidityDays: 90}
+	membership := &domain.Product{Type: "MEMBERSHIP", ValidityDays: 30}
+	activePass := func(remaining *int, days int) *domain.Pass {
+		return &domain.Pass{Status: domain.PassActive, RemainingVisits: remaining, StartsAt: paidAt, ExpiresAt: paidAt.AddDate(0, 0, days)}
+	}
+
+	tests := []struct {
+		name     string
+		paid     int64
+		product  *domain.Product
+		pass     *domain.Pass
+		usage    RefundUsage
+		now      time.Time
+		want     int64
+		wantFull bool
+	}{
+		{"미방문 이력", 100000, visitPass, activePass(visits(10), 90), RefundUsage{NoShows: 1}, paidAt.Add(time.Hour), 0, false},
+		{"기한 내 미사용 전액", 100000, visitPass, activePass(visits(10), 90), RefundUsage{}, paidAt.Add(23 * time.Hour), 100000, true},
+		{"기한 경계는 부분 환불", 100000, visitPass, activePass(visits(10), 90), RefundUsage{}, paidAt.Add(24 * time.Hour), 90000, false},
+		{"기한 내라도 사용했으면 부분 환불", 120000, visitPass, activePass(visits(5), 90), RefundUsage{Visits: 5}, paidAt.Add(time.Hour), 54000, false},
+		{"횟수권 남은 횟수 비율", 120000, visitPass, activePass(visits(5), 90), RefundUsage{Visits: 5}, paidAt.AddDate(0, 0, 10), 54000, false},
+		{"기간제 남은 기간 비율", 100000, membership, activePass(nil, 30), RefundUsage{Visits: 3}, paidAt.AddDate(0, 0, 15), 45000, false},
+		{"기간제 미사용은 기간 비율 없이 위약금만", 100000, membership, activePass(nil, 30), RefundUsage{}, paidAt.AddDate(0, 0, 15), 90000, false},
+		{"위약금 후 10원 미만 절사", 12345, membership, activePass(nil, 30), RefundUsage{}, paidAt.AddDate(0, 0, 2), 11110, false},
+		{"만료된 이용권", 100000, membership, activePass(nil, 30), RefundUsage{Visits: 3}, paidAt.AddDate(0, 0, 30), 0, false},
+		{"모두 사용한 이용권", 100000, visitPass, &domain.Pass{Status: domain.PassUsedUp, RemainingVisits: visits(0), StartsAt: paidAt, ExpiresAt: paidAt.AddDate(0, 0, 90)}, RefundUsage{Visits: 10}, paidAt.AddDate(0, 0, 10), 0, false},
+		{"취소된 이용권", 100000, visitPass, &domain.Pass{Status: domain.PassCancelled}, RefundUsage{}, paidAt.Add(time.Hour), 0, false},
+		{"무료 주문", 0, visitPass, activePass(visits(10), 90), RefundUsage{}, paidAt.Add(time.Hour), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := policy.Quote(tt.paid, paidAt, tt.product, tt.pass, tt.usage, tt.now)
+			if q.Amount != tt.want || q.Full != tt.wantFull {
+				t.Errorf("Quote() = %d원 (full=%v, %s), want %d원 (full=%v)", q.Amount, q.Full, q.Reason, tt.want, tt.wantFull)
+			}
+			if q.Amount == 0 && q.Reason == "" {
+				t.Error("환불 불가 사유가 비어 있음")
+			}
+		})
+	}
+}
+
+func TestRefundPolicyNeedsApproval(t *testing.T) {
+	tests := []struct {
+		threshold int64
+		amount    int64
+		want      bool
+	}{
+		{100000, 100000, false},
+		{100000, 100001, true},
+		{0, 1000000, false},
+	}
+	for _, tt := range tests {
+		p := RefundPolicy{ApprovalThreshold: tt.threshold}
+		if got := p.NeedsApproval(tt.amount); got != tt.want {
+			t.Errorf("기준 %d원, 환불 %d원: NeedsApproval = %v, want %v", tt.threshold, tt.amount, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package algo
+
+import (
+	"guss-backend/internal/domain"
+	"math"
+	"sort"
+	"time"
+)
+
+// 매출 집계 기간 단위
+const (
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly" // 월요일 시작
+	PeriodMonthly = "monthly"
+)
+
+// 매출 집계 그룹 기준
+const (
+	GroupByGym     = "gym"
+	GroupByType    = "type"
+	GroupByGymType = "gym_type"
+)
+
+func IsValidPeriod(period string) bool {
+	return period == PeriodDaily || period == PeriodWeekly || period == PeriodMonthly
+}
+
+func IsValidGroupBy(groupBy string) bool {
+	return groupBy == GroupByGym || groupBy == GroupByType || groupBy == GroupByGymType
+}
+
+// PeriodStart: t가 속한 기간의 시작 시각 (한국 시간 자정 기준)
+func PeriodStart(t time.Time, period string) time.Time {
+	t = t.In(KST)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, KST)
+	switch period {
+	case PeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7 // 월요일=0
+		return day.AddDate(0, 0, -offset)
+	case PeriodMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, KST)
+	default:
+		return day
+	}
+}
+
+// PrevPeriodStart: 기간 시작 시각 기준 직전 기간의 시작 시각
+func PrevPeriodStart(start time.Time, period string) time.Time {
+	switch period {
+	case PeriodWeekly:
+		return start.AddDate(0, 0, -7)
+	case PeriodMonthly:
+		return start.AddDate(0, -1, 0)
+	default:
+		return start.AddDate(0, 0, -1)
+	}
+}
+
+// nextPeriodStart: 기간 시작 시각 기준 다음 기간의 시작 시각
+func nextPeriodStart(start time.Time, period string) time.Time {
+	switch period {
+	case PeriodWeekly:
+		return start.AddDate(0, 0, 7)
+	case PeriodMonthly:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+type revenueKey struct {
+	start     time.Time
+	gymID     int64
+	salesType string
+}
+
+// AggregateRevenue: 매출 목록을 기간/그룹별로 합산하고 직전 기간 대비 증감률을 계산
+// since 이전 기간의 버킷은 증감률 계산에만 쓰고 결과에서는 제외 (since가 zero면 모두 포함)
+// since~until 사이 매출이 없는 기간도 그룹마다 0원 버킷으로 채워, 매출이 끊긴 기간과 그 다음 기간의 증감률이 빠지지 않게 한다.
+// (since/until이 zero면 매출이 있는 첫/마지막 기간까지)
+// 환불(음수 REFUND 매출)은 순매출에서 빼되 판매 건수/객단가에는 넣지 않고 환불 건수/금액으로 따로 집계한다.
+func AggregateRevenue(sales []domain.Sale, period, groupBy string, since, until time.Time) []domain.RevenueBucket {
+	type acc struct {
+		total        int64
+		count        int
+		refundCount  int
+		refundAmount int64
+	}
+	buckets := map[revenueKey]*acc{}
+
+	for _, s := range sales {
+		key := revenueKey{start: PeriodStart(s.SalesDate, period)}
+		if groupBy == GroupByGym || groupBy == GroupByGymType {
+			key.gymID = s.GymID
+		}
+		if groupBy == GroupByType || groupBy == GroupByGymType {
+			key.salesType = s.SalesType
+		}
+		a, ok := buckets[key]
+		if !ok {
+			a = &acc{}
+			buckets[key] = a
+		}
+		a.total += s.SalesAmount
+		if s.SalesType == domain.SalesTypeRefund {
+			a.refundCount++
+			a.refundAmount -= s.SalesAmount
+		} else {
+			a.count++
+		}
+	}
+
+	var firstStart time.Time
+	if !since.IsZero() {
+		firstStart = PeriodStart(since, period)
+	}
+
+	// 그룹별로 빈 기간을 0원 버킷으로 채움 (첫 기간의 증감률을 위해 직전 기간부터)
+	var minStart, maxStart time.Time
+	groups := map[revenueKey]bool{}
+	for key := range buckets {
+		if minStart.IsZero() || key.start.Before(minStart) {
+			minStart = key.start
+		}
+		if key.start.After(maxStart) {
+			maxStart = key.start
+		}
+		group := key
+		group.start = time.Time{}
+		groups[group] = true
+	}
+	fillFrom, fillTo := minStart, maxStart
+	if !firstStart.IsZero() {
+		fillFrom = PrevPeriodStart(firstStart, period)
+	}
+	if !until.IsZero() {
+		fillTo = PeriodStart(until, period)
+	}
+	for group := range groups {
+		for start := fillFrom; !start.After(fillTo); start = nextPeriodStart(start, period) {
+			key := group
+			key.start = start
+			if _, ok := buckets[key]; !ok {
+				buckets[key] = &acc{}
+			}
+		}
+	}
+
+	result := []domain.RevenueBucket{}
+	for key, a := range buckets {
+		if !firstStart.IsZero() && key.start.Before(firstStart) {
+			continue
+		}
+		b := domain.RevenueBucket{
+			PeriodStart:  key.start.Format("2006-01-02"),
+			GymID:        key.gymID,
+			SalesType:    key.salesType,
+			Total:        a.total,
+			Count:        a.count,
+			RefundCount:  a.refundCount,
+			RefundAmount: a.refundAmount,
+		}
+		b.AverageTicket = AverageTicket(a.total+a.refundAmount, a.count)
+
+		prevKey := key
+		prevKey.start = PrevPeriodStart(key.start, period)
+		if prev, ok := buckets[prevKey]; ok {
+			b.PrevTotal = prev.total
+		}
+		if b.PrevTotal != 0 {
+			rate := math.Round(float64(b.Total-b.PrevTotal)/math.Abs(float64(b.PrevTotal))*10000) / 100
+			b.ChangeRate = &rate
+		}
+		result = append(result, b)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].PeriodStart != result[j].PeriodStart {
+			return result[i].PeriodStart < result[j].PeriodStart
+		}
+		if result[i].GymID != result[j].GymID {
+			return result[i].GymID < result[j].GymID
+		}
+		return result[i].SalesType < result[j].SalesType
+	})
+	return result
+}
+
+// AverageTicket: 판매 1건당 평균 금액 (환불 전 판매 금액 기준, 건수가 없으면 0)
+func AverageTicket(gross int64, count int) int64 {
+	if count == 0 {
+		return 0
+	}
+	return int64(math.Round(float64(gross) / float64(count)))
+}
@@ -0,0 +1,128 @@
+package algo
+
+import (
+	"testing"
+	"time"
+
+	"guss-backend/internal/domain"
+)
+
+func TestPeriodStartUsesKST(t *testing.T) {
+	tests := []struct {
+		name   string
+		at     time.Time
+		period string
+		want   string
+	}{
+		// 2026-10-18 15:00 UTC = 2026-10-19 00:00 KST (월요일)
+		{"UTC 전날이지만 한국은 다음 날", time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC), PeriodDaily, "2026-10-19"},
+		{"한국 자정 직전", time.Date(2026, 10, 18, 14, 59, 59, 0, time.UTC), PeriodDaily, "2026-10-18"},
+		{"주 단위는 월요일 시작", time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC), PeriodWeekly, "2026-10-19"},
+		{"일요일은 앞 주 월요일", time.Date(2026, 10, 18, 14, 59, 59, 0, time.UTC), PeriodWeekly, "2026-10-12"},
+		{"월 경계", time.Date(2026, 10, 31, 15, 0, 0, 0, time.UTC), PeriodMonthly, "2026-11-01"},
+		{"월 경계 직전", time.Date(2026, 10, 31, 14, 59, 59, 0, time.UTC), PeriodMonthly, "2026-10-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PeriodStart(tt.at, tt.period)
+			if got.Format("2006-01-02") != tt.want || got.Location() != KST || got.Hour() != 0 {
+				t.Errorf("PeriodStart(%v, %s) = %v, want %s 00:00 KST", tt.at, tt.period, got, tt.want)
+			}
+		})
+	}
+}
+
+func kstDay(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 12, 0, 0, 0, KST)
+}
+
+func TestAggregateRevenue(t *testing.T) {
+	sales := []domain.Sale{
+		{GymID: 1, SalesType: "MEMBERSHIP", SalesAmount: 100000, SalesDate: kstDay(9, 10)},
+		{GymID: 1, SalesType: "MEMBERSHIP", SalesAmount: 100000, SalesDate: kstDay(10, 1)},
+		{GymID: 1, SalesType: "DAY_PASS", SalesAmount: 20000, SalesDate: kstDay(10, 2)},
+		{GymID: 1, SalesType: domain.SalesTypeRefund, SalesAmount: -50000, SalesDate: kstDay(10, 3)},
+		{GymID: 2, SalesType: "DAY_PASS", SalesAmount: 10000, SalesDate: kstDay(10, 5)},
+	}
+	rate := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		groupBy string
+		since   time.Time
+		want    []domain.RevenueBucket
+	}{
+		{
+			name:    "지점별 - 환불은 건수/객단가에서 제외",
+			groupBy: GroupByGym,
+			since:   kstDay(10, 1),
+			want: []domain.RevenueBucket{
+				{PeriodStart: "2026-10-01", GymID: 1, Total: 70000, Count: 2, AverageTicket: 60000, RefundCount: 1, RefundAmount: 50000,
+					PrevTotal: 100000, ChangeRate: rate(-30)},
+				{PeriodStart: "2026-10-01", GymID: 2, Total: 10000, Count: 1, AverageTicket: 10000},
+			},
+		},
+		{
+			name:    "유형별 - 환불은 별도 유형",
+			groupBy: GroupByType,
+			since:   kstDay(10, 1),
+			want: []domain.RevenueBucket{
+				{PeriodStart: "2026-10-01", SalesType: "DAY_PASS", Total: 30000, Count: 2, AverageTicket: 15000},
+				{PeriodStart: "2026-10-01", SalesType: "MEMBERSHIP", Total: 100000, Count: 1, AverageTicket: 100000, PrevTotal: 100000, ChangeRate: rate(0)},
+				{PeriodStart: "2026-10-01", SalesType: domain.SalesTypeRefund, Total: -50000, RefundCount: 1, RefundAmount: 50000},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AggregateRevenue(sales, PeriodMonthly, tt.groupBy, tt.since, kstDay(10, 31))
+			if len(got) != len(tt.want) {
+				t.Fatalf("버킷 %d개, want %d개: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				assertBucket(t, got[i], want)
+			}
+		})
+	}
+}
+
+func TestAggregateRevenueFillsEmptyPeriods(t *testing.T) {
+	sales := []domain.Sale{
+		{GymID: 1, SalesType: "DAY_PASS", SalesAmount: 10000, SalesDate: kstDay(7, 15)},
+		{GymID: 1, SalesType: "DAY_PASS", SalesAmount: 30000, SalesDate: kstDay(9, 15)},
+	}
+	rate := func(v float64) *float64 { return &v }
+
+	got := AggregateRevenue(sales, PeriodMonthly, GroupByGym, kstDay(7, 1), kstDay(10, 31))
+	want := []domain.RevenueBucket{
+		{PeriodStart: "2026-07-01", GymID: 1, Total: 10000, Count: 1, AverageTicket: 10000},
+		{PeriodStart: "2026-08-01", GymID: 1, PrevTotal: 10000, ChangeRate: rate(-100)},
+		{PeriodStart: "2026-09-01", GymID: 1, Total: 30000, Count: 1, AverageTicket: 30000},
+		{PeriodStart: "2026-10-01", GymID: 1, PrevTotal: 30000, ChangeRate: rate(-100)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("버킷 %d개, want %d개: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		assertBucket(t, got[i], want[i])
+	}
+}
+
+func assertBucket(t *testing.T, got, want domain.RevenueBucket) {
+	t.Helper()
+	gotRate, wantRate := got.ChangeRate, want.ChangeRate
+	got.ChangeRate, want.ChangeRate = nil, nil
+	if got != want {
+		t.Errorf("버킷 = %+v, want %+v", got, want)
+	}
+	if (gotRate == nil) != (wantRate == nil) || (gotRate != nil && *gotRate != *wantRate) {
+		t.Errorf("%s 지점 %d %s 증감률 = %v, want %v", want.PeriodStart, want.GymID, want.SalesType, fmtRate(gotRate), fmtRate(wantRate))
+	}
+}
+
+func fmtRate(r *float64) interface{} {
+	if r == nil {
+		return nil
+	}
+	return *r
+}
@@ -0,0 +1,14 @@
+package algo
+
+import "time"
+
+// KST: 매출 집계/일자 필터의 기준 시간대 (Asia/Seoul)
+// 컨테이너 이미지에 tzdata가 없을 수 있어 로드 실패 시 고정 오프셋(+09:00)을 사용 (한국은 서머타임 없음)
+var KST = loadSeoul()
+
+func loadSeoul() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Seoul"); err == nil {
+		return loc
+	}
+	return time.FixedZone("KST", 9*60*60)
+}
@@ -0,0 +1,18 @@
+package algo
+
+import "math"
+
+// VATRate: 부가가치세율 (판매가는 부가세 포함 금액)
+const VATRate = 0.1
+
+// SplitVAT: 부가세 포함 금액을 공급가액과 부가세로 분리 (공급가액은 원 단위 반올림, 부가세는 나머지)
+// 환불처럼 음수 금액은 절댓값으로 나눈 뒤 부호를 되돌려 정상 영수증과 대칭이 되게 한다.
+func SplitVAT(total int64) (supply, vat int64) {
+	sign := int64(1)
+	if total < 0 {
+		sign, total = -1, -total
+	}
+	supply = int64(math.Round(float64(total) / (1 + VATRate)))
+	vat = total - supply
+	return sign * supply, sign * vat
+}
@@ -0,0 +1,266 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/notify"
+	"guss-backend/internal/repository"
+)
+
+const testPassword = "Guss-test-pw1"
+
+var setupAuthOnce sync.Once
+
+// testAuthRepo: Mock 저장소에 실제 bcrypt 해시를 가진 회원/관리자와 가입 대기 건을 얹은 테스트용 저장소
+type testAuthRepo struct {
+	repository.Repository
+	user    *domain.User
+	admins  map[string]*domain.Admin
+	pending *domain.PendingSignup
+}
+
+func (r *testAuthRepo) GetUserByID(id string) (*domain.User, error) {
+	if id != r.user.UserID {
+		return nil, repository.ErrNotFound
+	}
+	u := *r.user
+	return &u, nil
+}
+
+func (r *testAuthRepo) GetUserByNumber(userNum int64) (*domain.User, error) {
+	if userNum != r.user.UserNumber {
+		return nil, repository.ErrNotFound
+	}
+	u := *r.user
+	return &u, nil
+}
+
+func (r *testAuthRepo) GetAdminByID(id string) (*domain.Admin, error) {
+	a, ok := r.admins[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (r *testAuthRepo) CreatePendingSignup(ps *domain.PendingSignup) error {
+	ps.SignupNumber = 7
+	r.pending = ps
+	return nil
+}
+
+func (r *testAuthRepo) GetPendingSignup(phone string) (*domain.PendingSignup, error) {
+	if r.pending == nil || r.pending.UserPhone != phone {
+		return nil, repository.ErrNotFound
+	}
+	return r.pending, nil
+}
+
+func (r *testAuthRepo) CompleteSignup(signupNum int64) (*domain.User, error) {
+	ps := r.pending
+	return &domain.User{UserNumber: 2, UserID: ps.UserID, UserName: ps.UserName, UserPhone: ps.UserPhone, UserPW: ps.PasswordHash}, nil
+}
+
+// captureSender: 발송된 인증 코드를 테스트에서 꺼내 쓰기 위한 Sender
+type captureSender struct {
+	last notify.Message
+}
+
+func (c *captureSender) Send(_ context.Context, msg notify.Message) error {
+	c.last = msg
+	return nil
+}
+
+func newTestAuthServer(t *testing.T) (*Server, *testAuthRepo) {
+	t.Helper()
+	setupAuthOnce.Do(func() {
+		keys, err := auth.NewDevKeySet()
+		if err != nil {
+			t.Fatalf("JWT 키 생성 실패: %v", err)
+		}
+		auth.SetKeySet(keys)
+		policy := auth.DefaultPasswordPolicy
+		policy.BcryptCost = bcrypt.MinCost
+		auth.SetPasswordPolicy(policy)
+	})
+
+	hash, err := auth.HashPassword(testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &testAuthRepo{
+		Repository: repository.NewMockRepository(),
+		user:       &domain.User{UserNumber: 1, UserID: "member01", UserName: "회원", UserPhone: "01012345678", UserPW: hash},
+		admins: map[string]*domain.Admin{
+			"manager01": {AdminNumber: 1, AdminID: "manager01", AdminPW: hash, AdminRole: auth.RoleGymAdmin, FKGussID: sql.NullInt64{Int64: 1, Valid: true}},
+			"staff01":   {AdminNumber: 2, AdminID: "staff01", AdminPW: hash, AdminRole: auth.RoleGymStaff, FKGussID: sql.NullInt64{Int64: 1, Valid: true}},
+		},
+	}
+	s := &Server{
+		Repo:       repo,
+		LogRepo:    repository.NewMockLogRepository(),
+		LoginGuard: &auth.LoginGuard{Store: auth.NewMemoryAttemptStore(), Policy: auth.DefaultLockoutPolicy},
+	}
+	return s, repo
+}
+
+func doJSON(t *testing.T, h http.Handler, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.RemoteAddr = "192.0.2.1:5000"
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// assertNoSecrets: 응답 JSON 어디에도 비밀번호/해시 키나 저장된 해시 값이 없는지 확인
+func assertNoSecrets(t *testing.T, rec *httptest.ResponseRecorder, hashes ...string) map[string]interface{} {
+	t.Helper()
+	raw := rec.Body.String()
+	for _, h := range hashes {
+		if h != "" && strings.Contains(raw, h) {
+			t.Fatalf("응답에 저장된 해시가 포함됨: %s", raw)
+		}
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("JSON 응답이 아님: %v (%s)", err, raw)
+	}
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				key := strings.ToLower(k)
+				if key == "user_pw" || key == "admin_pw" || strings.Contains(key, "hash") || strings.Contains(key, "password") {
+					t.Fatalf("응답에 금지된 키 %q가 포함됨: %s", k, raw)
+				}
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(body)
+
+	obj, _ := body.(map[string]interface{})
+	return obj
+}
+
+func TestRegisterResponsesOmitPassword(t *testing.T) {
+	s, repo := newTestAuthServer(t)
+	sender := &captureSender{}
+	s.Notifier = sender
+
+	rec := doJSON(t, http.HandlerFunc(s.HandleRegister), http.MethodPost, "/api/register", "",
+		signupRequest{UserID: "newmember", UserName: "새회원", UserPhone: "010-5555-6666", UserPW: testPassword})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("가입 요청 상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	assertNoSecrets(t, rec, repo.pending.PasswordHash, repo.pending.CodeHash)
+
+	_, rest, found := strings.Cut(sender.last.Body, "인증번호는 ")
+	if !found {
+		t.Fatalf("인증 문자 형식이 다름: %q", sender.last.Body)
+	}
+	code := strings.Fields(rest)[0]
+	rec = doJSON(t, http.HandlerFunc(s.HandleVerifyRegistration), http.MethodPost, "/api/register/verify", "",
+		map[string]string{"user_phone": "01055556666", "code": code})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("가입 인증 상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	body := assertNoSecrets(t, rec, repo.pending.PasswordHash, repo.pending.CodeHash)
+	if body["user_number"] != float64(2) {
+		t.Errorf("user_number = %v, want 2", body["user_number"])
+	}
+}
+
+func TestLoginAndMeResponsesOmitPassword(t *testing.T) {
+	s, repo := newTestAuthServer(t)
+	login := http.HandlerFunc(s.HandleLogin)
+
+	rec := doJSON(t, login, http.MethodPost, "/api/login", "", loginRequest{UserID: "member01", UserPW: "wrong-password"})
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("틀린 비밀번호 상태 코드 %d", rec.Code)
+	}
+	assertNoSecrets(t, rec, repo.user.UserPW)
+
+	rec = doJSON(t, login, http.MethodPost, "/api/login", "", loginRequest{UserID: "member01", UserPW: testPassword})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("로그인 상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	body := assertNoSecrets(t, rec, repo.user.UserPW)
+	token, _ := body["token"].(string)
+	if token == "" {
+		t.Fatalf("토큰 없음: %s", rec.Body.String())
+	}
+
+	me := s.AuthMiddleware(http.HandlerFunc(s.HandleMe))
+	rec = doJSON(t, me, http.MethodGet, "/api/me", token, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/api/me 상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	assertNoSecrets(t, rec, repo.user.UserPW)
+
+	rec = doJSON(t, me, http.MethodPatch, "/api/me", token, map[string]string{"user_name": "바뀐이름"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/api/me 수정 상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	assertNoSecrets(t, rec, repo.user.UserPW)
+}
+
+func TestAdminLoginResponsesOmitPassword(t *testing.T) {
+	s, repo := newTestAuthServer(t)
+	login := http.HandlerFunc(s.HandleAdminLogin)
+
+	// 지점 관리자는 OTP 등록 전이라 2단계 인증 토큰만 받는다.
+	rec := doJSON(t, login, http.MethodPost, "/api/admin/login", "", loginRequest{UserID: "manager01", UserPW: testPassword})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("관리자 로그인 상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	body := assertNoSecrets(t, rec, repo.admins["manager01"].AdminPW)
+	if body["status"] != "mfa_setup_required" {
+		t.Errorf("status = %v, want mfa_setup_required", body["status"])
+	}
+
+	// 직원은 2단계 인증 없이 바로 세션을 받는다.
+	rec = doJSON(t, login, http.MethodPost, "/api/admin/login", "", loginRequest{UserID: "staff01", UserPW: testPassword})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("직원 로그인 상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	body = assertNoSecrets(t, rec, repo.admins["staff01"].AdminPW)
+	if body["user_role"] != auth.RoleGymStaff {
+		t.Errorf("user_role = %v, want %s", body["user_role"], auth.RoleGymStaff)
+	}
+
+	rec = doJSON(t, login, http.MethodPost, "/api/admin/login", "", loginRequest{UserID: "nobody", UserPW: testPassword})
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("없는 관리자 상태 코드 %d", rec.Code)
+	}
+	assertNoSecrets(t, rec)
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"encoding/json"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/domain"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,40}$`)
+
+// HandleCheckCoupon: 쿠폰 적용 미리보기 (GET /api/coupons/{code}?product_number=)
+// 기간/대상/할인 금액만 계산하며, 사용 한도와 첫 구매 조건은 결제 시 주문 생성 단계에서 확인한다.
+func (s *Server) HandleCheckCoupon(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	code := normalizeCouponCode(strings.TrimPrefix(r.URL.Path, "/api/coupons/"))
+	productNum, _ := strconv.ParseInt(r.URL.Query().Get("product_number"), 10, 64)
+
+	coupon, err := s.Repo.GetCouponByCode(code)
+	if err != nil {
+		s.errorJSON(w, "존재하지 않는 쿠폰입니다.", http.StatusNotFound)
+		return
+	}
+	product, err := s.Repo.GetProductByID(productNum)
+	if err != nil {
+		s.repoErrorJSON(w, err, "상품 조회 실패")
+		return
+	}
+
+	discount, err := algo.CouponDiscount(coupon, product, time.Now())
+	if err != nil {
+		s.errorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":                coupon.Code,
+		"name":                coupon.Name,
+		"list_price":          product.Price,
+		"discount":            discount,
+		"amount":              product.Price - discount,
+		"first_purchase_only": coupon.FirstPurchaseOnly,
+	})
+}
+
+// HandleAdminCoupons: 관리자용 쿠폰 목록 조회 및 등록
+// 지점 관리자는 자기 지점 전용 쿠폰만 만들 수 있고, 목록에는 자기 지점에서 쓸 수 있는 쿠폰이 보인다.
+func (s *Server) HandleAdminCoupons(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		list, err := s.Repo.GetCoupons(scope)
+		if err != nil {
+			s.errorJSON(w, "쿠폰 조회 실패", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(list)
+
+	case http.MethodPost:
+		c := domain.Coupon{IsActive: true, PerUserLimit: 1}
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+			return
+		}
+		if scope != 0 {
+			c.GymIDs = []int64{scope}
+		}
+		c.Code = normalizeCouponCode(c.Code)
+		if msg := validateCoupon(&c); msg != "" {
+			s.errorJSON(w, msg, http.StatusBadRequest)
+			return
+		}
+		if _, err := s.Repo.GetCouponByCode(c.Code); err == nil {
+			s.errorJSON(w, "이미 사용 중인 쿠폰 코드입니다.", http.StatusConflict)
+			return
+		}
+		if err := s.Repo.CreateCoupon(&c); err != nil {
+			s.errorJSON(w, "쿠폰 등록 실패", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[SUCCESS] 쿠폰 등록: %s (%s %d)", c.Code, c.DiscountType, c.DiscountValue)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":        "success",
+			"coupon_number": c.CouponNumber,
+		})
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleUpdateCoupon: 쿠폰 수정 (PUT /admin/coupons/{id}, 요청에 없는 필드는 기존 값 유지, 코드는 변경 불가)
+func (s *Server) HandleUpdateCoupon(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	id, _ := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+
+	c, err := s.Repo.GetCouponByID(id)
+	if err != nil {
+		s.repoErrorJSON(w, err, "쿠폰 조회 실패")
+		return
+	}
+	// 지점 관리자는 자기 지점 전용 쿠폰만 수정 가능 (전 지점 공통 쿠폰은 본사 관리)
+	if scope != 0 && !(len(c.GymIDs) == 1 && c.GymIDs[0] == scope) {
+		s.errorJSON(w, "다른 지점 또는 전 지점 공통 쿠폰은 수정할 수 없습니다.", http.StatusForbidden)
+		return
+	}
+
+	code := c.Code
+	body, err := io.ReadAll(r.Body)
+	if err != nil || json.Unmarshal(body, c) != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+	c.CouponNumber, c.Code = id, code
+	if scope != 0 {
+		c.GymIDs = []int64{scope}
+	}
+
+	if msg := validateCoupon(c); msg != "" {
+		s.errorJSON(w, msg, http.StatusBadRequest)
+		return
+	}
+	if err := s.Repo.UpdateCoupon(c); err != nil {
+		s.repoErrorJSON(w, err, "쿠폰 수정 실패")
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// HandleCouponReport: 쿠폰별 사용 실적 (기본 최근 30일, from/to는 한국 시간 날짜)
+func (s *Server) HandleCouponReport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	f, msg := parseSalesFilter(r)
+	if msg != "" {
+		s.errorJSON(w, msg, http.StatusBadRequest)
+		return
+	}
+	// parseSalesFilter는 종료일을 다음 날 0시(미포함)로 바꿔 두므로 응답의 종료일은 하루 전 날짜로 표시한다.
+	lastDay := f.To.AddDate(0, 0, -1)
+	if f.To.IsZero() {
+		f.To = time.Now()
+		lastDay = f.To
+	}
+	if f.From.IsZero() {
+		f.From = f.To.AddDate(0, 0, -30)
+	}
+	if scope == 0 {
+		scope = f.GymID
+	}
+
+	list, err := s.Repo.GetCouponUsage(scope, f.From, f.To)
+	if err != nil {
+		s.errorJSON(w, "쿠폰 실적 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"from":    f.From.In(algo.KST).Format(dateLayout),
+		"to":      lastDay.In(algo.KST).Format(dateLayout),
+		"coupons": list,
+	})
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validateCoupon(c *domain.Coupon) string {
+	c.Name = strings.TrimSpace(c.Name)
+	if !couponCodePattern.MatchString(c.Code) {
+		return "쿠폰 코드는 영문 대문자/숫자/-/_ 3~40자여야 합니다."
+	}
+	if c.Name == "" {
+		return "쿠폰 이름을 입력해 주세요."
+	}
+	if c.StartsAt.IsZero() || c.EndsAt.IsZero() || !c.StartsAt.Before(c.EndsAt) {
+		return "쿠폰 사용 기간이 올바르지 않습니다."
+	}
+	if c.TotalLimit < 0 || c.PerUserLimit < 0 || c.MaxDiscount < 0 {
+		return "사용 한도와 할인 상한은 0 이상이어야 합니다."
+	}
+	if c.ProductType != "" && c.ProductType != domain.ProductDayPass &&
+		c.ProductType != domain.ProductVisitPass && c.ProductType != domain.ProductMembership {
+		return "상품 유형은 DAY_PASS, VISIT_PASS, MEMBERSHIP 중 하나여야 합니다."
+	}
+
+	switch c.DiscountType {
+	case domain.DiscountPercent:
+		if c.DiscountValue < 1 || c.DiscountValue > 100 {
+			return "정률 할인은 1~100% 사이여야 합니다."
+		}
+	case domain.DiscountFixed:
+		if c.DiscountValue <= 0 {
+			return "정액 할인 금액은 0보다 커야 합니다."
+		}
+	case domain.DiscountFree:
+		c.DiscountValue = 0
+	default:
+		return "할인 방식은 PERCENT, FIXED, FREE 중 하나여야 합니다."
+	}
+
+	if c.GymIDs == nil {
+		c.GymIDs = []int64{}
+	}
+	if c.ProductNumbers == nil {
+		c.ProductNumbers = []int64{}
+	}
+	return ""
+}
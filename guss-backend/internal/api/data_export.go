@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"guss-backend/internal/privacy"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HandleExportMyData: 내 개인정보 내려받기 (GET /api/me/export)
+// 프로필, 예약, 이용권, 결제, 활동 로그를 하나의 JSON 파일로 내려준다.
+func (s *Server) HandleExportMyData(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+	user, ok := s.currentMember(w, claims)
+	if !ok {
+		return
+	}
+
+	archive, err := privacy.BuildArchive(s.Repo, s.LogRepo, user)
+	if err != nil {
+		log.Printf("[PRIVACY ERROR] %s 개인정보 내보내기 실패: %v", user.UserID, err)
+		s.errorJSON(w, "개인정보 내보내기 실패", http.StatusInternalServerError)
+		return
+	}
+
+	s.audit(user.UserID, "DATA_EXPORTED")
+	log.Printf("[PRIVACY] %s 개인정보 내보내기", user.UserID)
+
+	// 검증 도입 전 가입한 아이디에는 헤더에 쓸 수 없는 문자가 있을 수 있어 회원 번호로 파일명을 만든다.
+	filename := "guss-data-" + strconv.FormatInt(user.UserNumber, 10) + "-" + time.Now().Format("20060102") + ".json"
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Cache-Control", "no-store")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(archive)
+}
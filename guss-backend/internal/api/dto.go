@@ -0,0 +1,68 @@
+package api
+
+import "guss-backend/internal/domain"
+
+// API 요청/응답 형식: domain 엔티티(DB 행)를 그대로 디코딩/인코딩하지 않고 엔드포인트별 타입을 거친다.
+// 비밀번호/토큰 해시, OTP 비밀키 같은 값은 이 타입들에 필드 자체가 없으므로 응답에 실릴 수 없다.
+
+// loginRequest: 회원/관리자 로그인 (POST /api/login, /api/admin/login)
+type loginRequest struct {
+	UserID string `json:"user_id"`
+	UserPW string `json:"user_pw"`
+}
+
+// signupRequest: 회원가입 1단계 (POST /api/register)
+type signupRequest struct {
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	UserPhone string `json:"user_phone"`
+	UserPW    string `json:"user_pw"`
+}
+
+// signupResponse: 회원가입 2단계 완료 (POST /api/register/verify)
+type signupResponse struct {
+	Status     string `json:"status"`
+	UserNumber int64  `json:"user_number"`
+}
+
+// userResponse: 회원 본인 정보 (GET/PATCH /api/me)
+type userResponse struct {
+	UserNumber    int64  `json:"user_number"`
+	UserID        string `json:"user_id"`
+	UserName      string `json:"user_name"`
+	UserPhone     string `json:"user_phone"`
+	PhoneVerified bool   `json:"phone_verified"`
+	PendingPhone  string `json:"pending_phone,omitempty"` // 인증을 기다리는 새 번호
+}
+
+func newUserResponse(u *domain.User) userResponse {
+	return userResponse{
+		UserNumber:    u.UserNumber,
+		UserID:        u.UserID,
+		UserName:      u.UserName,
+		UserPhone:     u.UserPhone,
+		PhoneVerified: u.PhoneVerifiedAt != nil,
+	}
+}
+
+// sessionResponse: 로그인/토큰 재발급/OTP 로그인 공통 응답
+type sessionResponse struct {
+	Status       string   `json:"status"`
+	Token        string   `json:"token"`
+	RefreshToken string   `json:"refresh_token"`
+	ExpiresIn    int      `json:"expires_in"`
+	UserName     string   `json:"user_name"`
+	UserRole     string   `json:"user_role"`
+	Permissions  []string `json:"permissions"`
+	GymID        int64    `json:"gym_id"` // 프론트엔드에서 지점 필터링에 사용
+
+	RecoveryCodes          []string `json:"recovery_codes,omitempty"`           // 로그인 중 OTP를 활성화한 경우 (원문은 이때만 전달)
+	RecoveryCodesRemaining *int     `json:"recovery_codes_remaining,omitempty"` // 복구 코드로 로그인한 경우
+}
+
+// mfaChallengeResponse: 비밀번호 확인 후 2단계 인증 대기 응답
+type mfaChallengeResponse struct {
+	Status         string `json:"status"` // mfa_required / mfa_setup_required
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int    `json:"expires_in"`
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	bookingSlot      = 30 * time.Minute // 기구 예약 단위 (30분 블록)
+	maxBookingBlocks = 2                // 한 번에 잡을 수 있는 최대 블록 수 (1시간)
+	bookingHorizon   = 24 * time.Hour   // 지금부터 24시간 이내 시간대만 예약 가능
+)
+
+// HandleCreateEquipmentBooking: 체육관 예약이 있는 회원이 특정 기구를 30분 단위로 예약
+func (s *Server) HandleCreateEquipmentBooking(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		EquipID   int64  `json:"equip_id"`
+		StartTime string `json:"start_time"` // RFC3339 (예: 2026-01-20T19:00:00+09:00)
+		Blocks    int    `json:"blocks"`
+		UnitNo    int    `json:"unit_no"` // 0이면 빈 기구 자동 배정
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorJSON(w, "잘못된 요청 형식입니다.", http.StatusBadRequest)
+		return
+	}
+	if req.EquipID <= 0 {
+		s.errorJSON(w, "기구 ID가 유효하지 않습니다.", http.StatusBadRequest)
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		s.errorJSON(w, "시작 시간 형식이 올바르지 않습니다. (RFC3339)", http.StatusBadRequest)
+		return
+	}
+	if !start.Truncate(bookingSlot).Equal(start) {
+		s.errorJSON(w, "기구 예약은 정각 또는 30분 단위로만 시작할 수 있습니다.", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	if start.Before(now.Truncate(bookingSlot)) || start.After(now.Add(bookingHorizon)) {
+		s.errorJSON(w, "현재 시간대부터 24시간 이내만 예약할 수 있습니다.", http.StatusBadRequest)
+		return
+	}
+
+	if req.Blocks == 0 {
+		req.Blocks = 1
+	}
+	if req.Blocks < 0 || req.Blocks > maxBookingBlocks {
+		s.errorJSON(w, "한 번에 최대 1시간(30분 x 2)까지 예약할 수 있습니다.", http.StatusBadRequest)
+		return
+	}
+
+	booking := &domain.EquipmentBooking{
+		EquipID:    req.EquipID,
+		UserNumber: claims.UserNumber,
+		UnitNo:     req.UnitNo,
+		StartTime:  start.UTC(),
+		EndTime:    start.Add(time.Duration(req.Blocks) * bookingSlot).UTC(),
+	}
+	if err := s.Repo.CreateEquipmentBooking(booking); err != nil {
+		s.repoErrorJSON(w, err, "기구 예약 실패")
+		return
+	}
+
+	log.Printf("[SUCCESS] 유저 %d번 -> 기구 %d번(%d호기) %s 예약 완료",
+		claims.UserNumber, booking.EquipID, booking.UnitNo, booking.StartTime.Format(time.RFC3339))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "success",
+		"booking": booking,
+	})
+}
+
+// HandleGetEquipmentBookings: 기구의 예약된 시간대 조회 (다른 회원 정보는 노출하지 않음)
+func (s *Server) HandleGetEquipmentBookings(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := r.Context().Value(UserContextKey).(*auth.Claims)
+	if !ok {
+		s.errorJSON(w, "인증 정보가 없습니다.", http.StatusUnauthorized)
+		return
+	}
+
+	equipID, _ := strconv.ParseInt(r.URL.Query().Get("equip_id"), 10, 64)
+	if equipID <= 0 {
+		s.errorJSON(w, "기구 ID가 유효하지 않습니다.", http.StatusBadRequest)
+		return
+	}
+
+	from := time.Now().Truncate(bookingSlot)
+	list, err := s.Repo.GetEquipmentBookings(equipID, from, from.Add(bookingHorizon))
+	if err != nil {
+		s.errorJSON(w, "기구 예약 조회 실패", http.StatusInternalServerError)
+		return
+	}
+
+	type slot struct {
+		BookingNumber int64     `json:"booking_number,omitempty"`
+		UnitNo        int       `json:"unit_no"`
+		StartTime     time.Time `json:"start_time"`
+		EndTime       time.Time `json:"end_time"`
+		Mine          bool      `json:"mine"`
+	}
+	slots := []slot{}
+	for _, b := range list {
+		sl := slot{UnitNo: b.UnitNo, StartTime: b.StartTime, EndTime: b.EndTime}
+		// 관리자 토큰의 번호는 admin_number이므로 회원 토큰일 때만 본인 예약을 표시한다.
+		if claims.Role == auth.RoleUser && b.UserNumber == claims.UserNumber {
+			sl.BookingNumber = b.BookingNumber
+			sl.Mine = true
+		}
+		slots = append(slots, sl)
+	}
+	json.NewEncoder(w).Encode(slots)
+}
+
+// HandleCancelEquipmentBooking: 본인 기구 예약 취소 (DELETE /api/equipment-bookings/{id})
+func (s *Server) HandleCancelEquipmentBooking(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	id, _ := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err := s.Repo.CancelEquipmentBooking(claims.UserNumber, id); err != nil {
+		s.repoErrorJSON(w, err, "기구 예약 취소 실패")
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// HandleCheckOut: 퇴실 처리 (체육관 예약 완료 + 남은 기구 예약 반납)
+func (s *Server) HandleCheckOut(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.Repo.CheckOut(claims.UserNumber); err != nil {
+		s.repoErrorJSON(w, err, "퇴실 처리 실패")
+		return
+	}
+
+	log.Printf("[SUCCESS] 유저 %d번 퇴실 완료", claims.UserNumber)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
@@ -2,10 +2,14 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"guss-backend/internal/algo"
 	"guss-backend/internal/auth" // JWT 및 Bcrypt 인증 패키지
 	"guss-backend/internal/domain"
+	"guss-backend/internal/notify"
+	"guss-backend/internal/payment"
 	"guss-backend/internal/repository"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
@@ -16,11 +20,47 @@ import (
 type contextKey string
 
 const UserContextKey contextKey = "user"
+const GymScopeContextKey contextKey = "gym_scope" // 관리 가능한 지점 ID (0 = 전 지점)
 
 type Server struct {
 	Repo    repository.Repository
 	LogRepo repository.LogRepository
 	Algo    any
+
+	FreezePolicy algo.FreezePolicy       // 회원권 일시정지 규칙
+	RefundPolicy algo.RefundPolicy       // 주문 환불 규칙
+	Payments     payment.PaymentProvider // 온라인 결제사 (nil이면 결제 비활성)
+	LoginGuard   *auth.LoginGuard        // 로그인 실패 제한 (nil이면 비활성)
+	TrustProxy   bool                    // 로드밸런서의 X-Forwarded-For로 클라이언트 IP 판단
+	Notifier     notify.Sender           // 인증 코드 문자/이메일 발송 (nil이면 비활성)
+}
+
+// audit: 감사 로그 기록 (로그 저장 실패가 요청 자체를 실패시키지는 않음)
+func (s *Server) audit(userID, action string) {
+	if s.LogRepo == nil {
+		return
+	}
+	if err := s.LogRepo.SaveUserLog(userID, action); err != nil {
+		log.Printf("[AUDIT ERROR] %s %s: %v", userID, action, err)
+	}
+}
+
+// repoErrorJSON: 저장소 에러를 HTTP 상태 코드로 변환 (미분류 에러는 fallback 메시지로 500)
+func (s *Server) repoErrorJSON(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		s.errorJSON(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, repository.ErrGymScopeDenied):
+		s.errorJSON(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, repository.ErrBookingConflict), errors.Is(err, repository.ErrRefundExists), errors.Is(err, repository.ErrRefundNotPending),
+		errors.Is(err, repository.ErrCouponExhausted), errors.Is(err, repository.ErrCouponAlreadyUsed):
+		s.errorJSON(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, repository.ErrNoActiveReservation), errors.Is(err, repository.ErrEquipmentUnavailable),
+		errors.Is(err, repository.ErrCouponNotEligible):
+		s.errorJSON(w, err.Error(), http.StatusBadRequest)
+	default:
+		s.errorJSON(w, fallback, http.StatusInternalServerError)
+	}
 }
 
 // errorJSON: 공통 에러 응답 처리용 헬퍼 함수
@@ -31,115 +71,105 @@ func (s *Server) errorJSON(w http.ResponseWriter, message string, code int) {
 	json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
 
-// HandleLogin: 유저/관리자 통합 로그인 및 지점별 권한 부여
+// errorCodeJSON: 클라이언트가 분기해야 하는 에러용 (메시지와 함께 고정된 error_code 전달)
+func (s *Server) errorCodeJSON(w http.ResponseWriter, message, errorCode string, code int) {
+	log.Printf("[ERROR] 코드: %d, 에러코드: %s, 메시지: %s", code, errorCode, message)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message, "error_code": errorCode})
+}
+
+// fieldErrorsJSON: 입력값 검증 실패 (필드 이름 -> 사유를 fields에 담아 화면에서 항목별로 표시)
+func (s *Server) fieldErrorsJSON(w http.ResponseWriter, message, errorCode string, fields map[string]string, code int) {
+	log.Printf("[ERROR] 코드: %d, 에러코드: %s, 필드: %v", code, errorCode, fields)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{"error": message, "error_code": errorCode, "fields": fields})
+}
+
+// HandleLogin: 일반 회원 로그인 (user_table만 조회)
+// 관리자는 /api/admin/login을 사용하므로 회원 아이디가 관리자 아이디와 같아도 서로 가리지 않는다.
 func (s *Server) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	s.login(w, r, domain.PrincipalUser)
+}
+
+// HandleAdminLogin: 관리자/직원 로그인 (admin_table만 조회, 역할과 담당 지점 권한 부여)
+func (s *Server) HandleAdminLogin(w http.ResponseWriter, r *http.Request) {
+	s.login(w, r, domain.PrincipalAdmin)
+}
+
+// login: 로그인 공통 처리 - 짧은 액세스 토큰과 함께 교체형 리프레시 토큰을 발급한다.
+func (s *Server) login(w http.ResponseWriter, r *http.Request, principalType string) {
 	w.Header().Set("Content-Type", "application/json")
 
-	var input struct {
-		UserID string `json:"user_id"`
-		UserPW string `json:"user_pw"`
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
 	}
 
+	var input loginRequest
+
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		s.errorJSON(w, "잘못된 요청 형식입니다.", http.StatusBadRequest)
 		return
 	}
 
-	var userNumber int64
-	var userName string
-	var hashedPassword string
-	var role string = "USER"
-	var gymID int64 = 0 // 관리자의 담당 지점 ID (0은 전체 권한 의미)
+	// 계정별/IP별 실패 횟수에 따라 대기 또는 잠금 (없는 아이디도 똑같이 집계해 계정 존재 여부를 드러내지 않음)
+	ip := s.clientIP(r)
+	accountKey, ipKey := auth.AccountAttemptKey(principalType, input.UserID), auth.IPAttemptKey(ip)
+	if !s.checkLoginThrottle(w, accountKey, ipKey) {
+		return
+	}
 
-	// 1. 먼저 일반 유저 테이블에서 조회
-	user, err := s.Repo.GetUserByID(input.UserID)
-	if err == nil {
-		userNumber = user.UserNumber
-		userName = user.UserName
-		hashedPassword = user.UserPW
-		// 아이디가 admin인 유저는 USER 테이블에 있더라도 ADMIN으로 취급
-		if user.UserID == "admin" {
-			role = "ADMIN"
-		}
-	} else {
-		// 2. 유저가 없으면 관리자 전용 테이블(admin_table) 조회
-		admin, err := s.Repo.GetAdminByID(input.UserID)
-		if err != nil {
-			s.errorJSON(w, "아이디 또는 비밀번호가 일치하지 않습니다.", http.StatusUnauthorized)
-			return
-		}
-		userNumber = admin.AdminNumber
-		userName = "관리자(" + admin.AdminID + ")"
-		hashedPassword = admin.AdminPW
-		role = "ADMIN"
-
-		// [중요] sql.NullInt64 안전하게 처리 (super_admin은 NULL이므로 Valid가 false)
-		if admin.FKGussID.Valid {
-			gymID = admin.FKGussID.Int64 // 지점 관리자
-		} else {
-			gymID = 0 // 최고 관리자 (NULL)
-		}
+	p, err := s.loadPrincipal(principalType, input.UserID)
+	if err != nil {
+		// 없는 아이디도 같은 시간이 걸리도록 더미 해시와 비교
+		auth.CheckDummyPassword(input.UserPW)
 	}
 
-	// 3. 비밀번호 검증 (Bcrypt)
-	if !auth.CheckPasswordHash(input.UserPW, hashedPassword) {
+	// 비밀번호 검증 (Bcrypt)
+	if err != nil || !auth.CheckPasswordHash(input.UserPW, p.PasswordHash) {
+		s.recordLoginFailure(input.UserID, accountKey, ipKey, ip)
 		s.errorJSON(w, "아이디 또는 비밀번호가 일치하지 않습니다.", http.StatusUnauthorized)
 		return
 	}
+	s.releaseLoginAttempt(accountKey, ipKey)
+	s.upgradePasswordHash(p, input.UserPW)
 
-	// 4. 최고 관리자 ID 별도 판단 (로직 보강 가능)
-	if input.UserID == "super_admin" {
-		role = "SUPER_ADMIN"
+	// 관리자는 OTP 등록 여부/역할에 따라 2단계 인증 토큰만 받고 여기서 끝난다.
+	// 실패 집계는 2단계까지 통과해야 초기화된다 (비밀번호만 아는 상태로 OTP를 계속 시도하지 못하도록).
+	if p.Type == domain.PrincipalAdmin && s.beginAdminMFA(w, p) {
+		return
 	}
+	s.recordLoginSuccess(accountKey)
 
-	// 5. 토큰 생성 및 응답
-	token, err := auth.GenerateToken(userNumber, input.UserID, role)
+	session, err := s.issueSession(p)
 	if err != nil {
 		s.errorJSON(w, "토큰 생성 실패", http.StatusInternalServerError)
 		return
 	}
-
-	log.Printf("[LOGIN] %s 접속 (Role: %s, GymID: %d)", input.UserID, role, gymID)
-
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":    "success",
-		"token":     token,
-		"user_name": userName,
-		"user_role": role,
-		"gym_id":    gymID, // 프론트엔드에서 지점 필터링에 사용
-	})
+	json.NewEncoder(w).Encode(session)
 }
 
-// HandleRegister: 회원가입 (Bcrypt 적용)
-func (s *Server) HandleRegister(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	var u domain.User
-
-	if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
-		s.errorJSON(w, "데이터 형식 오류", 400)
+// upgradePasswordHash: 저장된 해시의 bcrypt 비용이 현재 설정보다 낮으면 방금 확인한 평문으로 다시 해싱
+// 실패해도 로그인은 계속 진행하고 다음 로그인 때 다시 시도한다.
+func (s *Server) upgradePasswordHash(p *principal, password string) {
+	if !auth.NeedsRehash(p.PasswordHash) {
 		return
 	}
-
-	hashedPW, err := auth.HashPassword(u.UserPW)
-	if err != nil {
-		s.errorJSON(w, "비밀번호 처리 중 오류 발생", 500)
-		return
+	newHash, err := auth.HashPassword(password)
+	if err == nil {
+		err = s.Repo.RehashPassword(p.Type, p.Number, p.PasswordHash, newHash)
 	}
-	u.UserPW = hashedPW
-
-	if err := s.Repo.CreateUser(&u); err != nil {
-		s.errorJSON(w, "회원가입 실패 (아이디 중복 등)", 500)
+	if err != nil {
+		log.Printf("[AUTH] %s 비밀번호 해시 갱신 실패: %v", p.LoginID, err)
 		return
 	}
-
-	log.Printf("[SUCCESS] 신규 유저 가입: %s (No: %d)", u.UserName, u.UserNumber)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":      "success",
-		"user_number": u.UserNumber,
-	})
+	p.PasswordHash = newHash
+	log.Printf("[AUTH] %s 비밀번호 해시 비용 상향", p.LoginID)
 }
 
-// HandleReserve: 중복 예약 방지 로직 적용
+// HandleReserve: 중복 예약 방지 및 이용권 차감 후 예약
 func (s *Server) HandleReserve(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -154,14 +184,17 @@ func (s *Server) HandleReserve(w http.ResponseWriter, r *http.Request) {
 		req.GymID = req.FkGussNumber
 	}
 
-	claims, ok := r.Context().Value(UserContextKey).(*auth.Claims)
+	claims, ok := s.memberClaims(w, r)
 	if !ok {
-		s.errorJSON(w, "인증 정보가 없습니다.", http.StatusUnauthorized)
 		return
 	}
 
 	_, err := s.Repo.CreateReservation(claims.UserNumber, req.GymID)
 	if err != nil {
+		if errors.Is(err, repository.ErrNoValidPass) {
+			s.errorJSON(w, err.Error(), http.StatusPaymentRequired)
+			return
+		}
 		s.errorJSON(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -181,15 +214,6 @@ func (s *Server) HandleDashboard(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(stats)
 }
 
-// HandleGetSales: 매출 데이터 조회 (향후 DynamoDB 마이그레이션 대상)
-func (s *Server) HandleGetSales(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	logs := []map[string]interface{}{
-		{"type": "일일권", "amount": 10000, "date": time.Now().Format("2006-01-02")},
-	}
-	json.NewEncoder(w).Encode(logs)
-}
-
 // --- 공통 조회 핸들러들 ---
 
 func (s *Server) HandleGetGyms(w http.ResponseWriter, r *http.Request) {
@@ -223,10 +247,18 @@ func (s *Server) HandleGetGymDetail(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// HandleGetEquipments: 지점별 기구 목록 (회원은 원하는 지점 조회, 지점 소속 직원/관리자는 자기 지점으로 고정)
 func (s *Server) HandleGetEquipments(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	idStr := r.URL.Query().Get("gymId")
-	id, _ := strconv.ParseInt(idStr, 10, 64)
+	id := queryGymID(r)
+	if claims, ok := r.Context().Value(UserContextKey).(*auth.Claims); ok && claims.Role != auth.RoleUser {
+		scope, ok := adminGymScope(claims)
+		if !ok {
+			s.errorJSON(w, "해당 지점에 대한 관리 권한이 없습니다.", http.StatusForbidden)
+			return
+		}
+		id = scopedGymID(scope, id)
+	}
 
 	list, err := s.Repo.GetEquipmentsByGymID(id)
 	if err != nil {
@@ -237,8 +269,24 @@ func (s *Server) HandleGetEquipments(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) HandleAddEquipment(w http.ResponseWriter, r *http.Request) {
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
 	var eq domain.Equipment
 	json.NewDecoder(r.Body).Decode(&eq)
+
+	// 지점 관리자는 요청 본문의 gym_id와 무관하게 자기 지점에만 등록
+	eq.GymID = scopedGymID(scope, eq.GymID)
+	if eq.GymID <= 0 {
+		s.errorJSON(w, "체육관 ID가 유효하지 않습니다.", 400)
+		return
+	}
+	if msg := validateEquipmentAsset(&eq); msg != "" {
+		s.errorJSON(w, msg, 400)
+		return
+	}
 	if err := s.Repo.AddEquipment(&eq); err != nil {
 		s.errorJSON(w, "등록 실패", 500)
 		return
@@ -246,30 +294,64 @@ func (s *Server) HandleAddEquipment(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
 }
 
+// HandleUpdateEquipment: 기존 기구 정보 위에 요청 필드만 덮어써서 수정 (누락된 회계 필드 보존)
 func (s *Server) HandleUpdateEquipment(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	var eq domain.Equipment
 
-	if err := json.NewDecoder(r.Body).Decode(&eq); err != nil {
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
 		s.errorJSON(w, "데이터 형식 오류", 400)
 		return
 	}
 
-	if eq.ID <= 0 {
+	var target struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(body, &target); err != nil {
+		s.errorJSON(w, "데이터 형식 오류", 400)
+		return
+	}
+
+	if target.ID <= 0 {
 		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 		if len(parts) > 0 {
 			id, _ := strconv.ParseInt(parts[len(parts)-1], 10, 64)
-			eq.ID = id
+			target.ID = id
 		}
 	}
 
-	if eq.ID <= 0 {
+	if target.ID <= 0 {
 		s.errorJSON(w, "수정할 기구 ID를 찾을 수 없습니다.", 400)
 		return
 	}
 
-	if err := s.Repo.UpdateEquipment(&eq); err != nil {
-		s.errorJSON(w, "DB 수정 실패", 500)
+	eq, err := s.Repo.GetEquipmentByID(target.ID)
+	if err != nil {
+		s.errorJSON(w, "기구를 찾을 수 없습니다.", 404)
+		return
+	}
+	if scope != 0 && eq.GymID != scope {
+		s.errorJSON(w, repository.ErrGymScopeDenied.Error(), http.StatusForbidden)
+		return
+	}
+	if err := json.Unmarshal(body, eq); err != nil {
+		s.errorJSON(w, "데이터 형식 오류", 400)
+		return
+	}
+	eq.ID = target.ID
+
+	if msg := validateEquipmentAsset(eq); msg != "" {
+		s.errorJSON(w, msg, 400)
+		return
+	}
+
+	if err := s.Repo.UpdateEquipment(scope, eq); err != nil {
+		s.repoErrorJSON(w, err, "DB 수정 실패")
 		return
 	}
 
@@ -277,24 +359,54 @@ func (s *Server) HandleUpdateEquipment(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
 }
 
+// validateEquipmentAsset: 감가상각 방식 기본값 적용 및 회계 필드 검증 (문제 없으면 빈 문자열)
+func validateEquipmentAsset(eq *domain.Equipment) string {
+	if eq.DepreciationMethod == "" {
+		eq.DepreciationMethod = algo.DepreciationStraightLine
+	}
+	if !algo.IsValidDepreciationMethod(eq.DepreciationMethod) {
+		return "지원하지 않는 감가상각 방식입니다. (straight_line / declining_balance)"
+	}
+	if eq.PurchasePrice < 0 || eq.SalvageValue < 0 || eq.UsefulLifeYears < 0 {
+		return "취득가, 잔존가치, 내용연수는 0 이상이어야 합니다."
+	}
+	if eq.SalvageValue > eq.PurchasePrice {
+		return "잔존가치는 취득가를 초과할 수 없습니다."
+	}
+	if eq.WarrantyExpiry != "" {
+		if _, err := time.Parse(dateLayout, eq.WarrantyExpiry); err != nil {
+			return "보증 만료일 형식이 올바르지 않습니다. (YYYY-MM-DD)"
+		}
+	}
+	return ""
+}
+
 func (s *Server) HandleDeleteEquipment(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
 	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 	id, _ := strconv.ParseInt(parts[len(parts)-1], 10, 64)
-	if err := s.Repo.DeleteEquipment(id); err != nil {
-		s.errorJSON(w, "삭제 실패", 500)
+	if err := s.Repo.DeleteEquipment(scope, id); err != nil {
+		s.repoErrorJSON(w, err, "삭제 실패")
 		return
 	}
 	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
 }
 
+// HandleGetReservations: 지점 예약 현황 (지점 관리자/직원은 자기 지점만)
 func (s *Server) HandleGetReservations(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	idStr := r.URL.Query().Get("gym_id")
-	if idStr == "" {
-		idStr = r.URL.Query().Get("gymId")
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
 	}
-	id, _ := strconv.ParseInt(idStr, 10, 64)
+	id := scopedGymID(scope, queryGymID(r))
 
 	if id <= 0 {
 		s.errorJSON(w, "체육관 ID가 유효하지 않습니다.", http.StatusBadRequest)
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// HandleGetInventoryValuation: 지점별 기구 자산 평가 리포트 (format=csv 시 CSV 다운로드)
+func (s *Server) HandleGetInventoryValuation(w http.ResponseWriter, r *http.Request) {
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	gymID := scopedGymID(scope, queryGymID(r)) // 지점 관리자는 자기 지점만 조회
+	if gymID <= 0 {
+		s.errorJSON(w, "체육관 ID가 유효하지 않습니다.", http.StatusBadRequest)
+		return
+	}
+
+	asOf := time.Now()
+	if v := r.URL.Query().Get("as_of"); v != "" {
+		t, err := time.Parse(dateLayout, v)
+		if err != nil {
+			s.errorJSON(w, "기준일 형식이 올바르지 않습니다. (YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		asOf = t
+	}
+
+	list, err := s.Repo.GetEquipmentsByGymID(gymID)
+	if err != nil {
+		s.errorJSON(w, "기구 목록 조회 실패", http.StatusInternalServerError)
+		return
+	}
+
+	report := buildInventoryValuation(gymID, list, asOf)
+
+	if r.URL.Query().Get("format") == "csv" {
+		writeInventoryCSV(w, report)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// buildInventoryValuation: 기구별 감가상각을 계산해 지점 합계까지 집계
+func buildInventoryValuation(gymID int64, list []domain.Equipment, asOf time.Time) domain.InventoryValuation {
+	report := domain.InventoryValuation{
+		GymID: gymID,
+		AsOf:  asOf.Format(dateLayout),
+		Items: []domain.InventoryValuationItem{},
+	}
+
+	for _, eq := range list {
+		purchased, err := time.Parse(dateLayout, eq.PurchaseDate)
+		if err != nil {
+			// 취득일을 알 수 없으면 상각하지 않고 취득가 그대로 평가
+			purchased = asOf
+		}
+
+		dep := algo.Depreciate(eq.DepreciationMethod, eq.PurchasePrice, eq.SalvageValue, eq.UsefulLifeYears, purchased, asOf)
+		qty := int64(eq.Quantity)
+		item := domain.InventoryValuationItem{
+			Equipment:               eq,
+			UnitBookValue:           dep.BookValue,
+			TotalCost:               dep.Cost * qty,
+			AccumulatedDepreciation: dep.AccumulatedDepreciation * qty,
+			BookValue:               dep.BookValue * qty,
+			ElapsedYears:            dep.ElapsedYears,
+		}
+
+		report.Items = append(report.Items, item)
+		report.TotalCost += item.TotalCost
+		report.AccumulatedDepreciation += item.AccumulatedDepreciation
+		report.BookValue += item.BookValue
+	}
+	return report
+}
+
+func writeInventoryCSV(w http.ResponseWriter, report domain.InventoryValuation) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=inventory_%d_%s.csv", report.GymID, report.AsOf))
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"equip_id", "name", "category", "quantity", "supplier", "purchase_date", "warranty_expiry",
+		"depreciation_method", "useful_life_years", "unit_price", "total_cost", "accumulated_depreciation", "book_value"})
+	for _, it := range report.Items {
+		cw.Write([]string{
+			strconv.FormatInt(it.ID, 10), it.Name, it.Category, strconv.Itoa(it.Quantity), it.Supplier,
+			it.PurchaseDate, it.WarrantyExpiry, it.DepreciationMethod, strconv.Itoa(it.UsefulLifeYears),
+			strconv.FormatInt(it.PurchasePrice, 10), strconv.FormatInt(it.TotalCost, 10),
+			strconv.FormatInt(it.AccumulatedDepreciation, 10), strconv.FormatInt(it.BookValue, 10),
+		})
+	}
+	cw.Write([]string{"TOTAL", "", "", "", "", "", "", "", "", "",
+		strconv.FormatInt(report.TotalCost, 10), strconv.FormatInt(report.AccumulatedDepreciation, 10), strconv.FormatInt(report.BookValue, 10)})
+	cw.Flush()
+}
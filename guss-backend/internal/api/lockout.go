@@ -0,0 +1,164 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// proxyWarnOnce: TrustProxy 없이 X-Forwarded-For가 붙은 요청 경고는 한 번만 남긴다.
+var proxyWarnOnce sync.Once
+
+// clientIP: 요청자 IP (로드밸런서 뒤에서는 TrustProxy일 때만 X-Forwarded-For의 마지막 값 사용)
+// 마지막 값은 우리 로드밸런서가 붙인 것이라 클라이언트가 위조할 수 없다.
+// 로드밸런서 뒤인데 TrustProxy가 꺼져 있으면 모든 요청이 로드밸런서 IP 하나로 집계되어 IP 잠금이 전체 로그인을 막으므로 경고한다.
+func (s *Server) clientIP(r *http.Request) string {
+	xff := r.Header.Get("X-Forwarded-For")
+	if s.TrustProxy && xff != "" {
+		parts := strings.Split(xff, ",")
+		return strings.TrimSpace(parts[len(parts)-1])
+	}
+	if xff != "" {
+		proxyWarnOnce.Do(func() {
+			log.Printf("[WARN] X-Forwarded-For 헤더가 있는 요청을 받았지만 trust_proxy가 꺼져 있습니다. 로드밸런서 뒤라면 -trust_proxy로 실행하세요 (IP별 로그인 제한이 로드밸런서 IP로 집계됨)")
+		})
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// checkLoginThrottle: 비밀번호 확인 전 잠금/대기 여부 확인 후 이번 시도를 실패로 미리 집계 (거절했으면 false, 응답까지 처리)
+// 통과한 뒤에는 recordLoginFailure 또는 releaseLoginAttempt 중 하나를 호출해야 한다.
+func (s *Server) checkLoginThrottle(w http.ResponseWriter, accountKey, ipKey string) bool {
+	if s.LoginGuard == nil {
+		return true
+	}
+	throttle, err := s.LoginGuard.Begin(accountKey, ipKey, time.Now())
+	if err != nil {
+		// 집계 저장소 장애로 로그인 자체를 막지는 않는다.
+		log.Printf("[LOCKOUT ERROR] 실패 집계 조회 실패: %v", err)
+		return true
+	}
+	if throttle == nil {
+		return true
+	}
+
+	wait := int(throttle.RetryAfter.Round(time.Second).Seconds())
+	if wait < 1 {
+		wait = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(wait))
+	code := "LOGIN_THROTTLED"
+	if throttle.Locked {
+		code = "LOGIN_LOCKED"
+	}
+	s.errorCodeJSON(w, fmt.Sprintf("%s (%d초 후 재시도)", auth.ErrLoginLocked.Error(), wait), code, http.StatusTooManyRequests)
+	return false
+}
+
+// recordLoginFailure: 미리 집계한 실패로 잠금 판단 후 감사 로그 기록 (잠금이 걸리면 별도 이벤트)
+func (s *Server) recordLoginFailure(loginID, accountKey, ipKey, ip string) {
+	if s.LoginGuard == nil {
+		return
+	}
+	failures, locked, err := s.LoginGuard.Fail(accountKey, ipKey, time.Now())
+	if err != nil {
+		log.Printf("[LOCKOUT ERROR] 실패 기록 실패: %v", err)
+		return
+	}
+	s.audit(loginID, fmt.Sprintf("LOGIN_FAILED ip=%s failures=%d", ip, failures))
+	for _, key := range locked {
+		log.Printf("[SECURITY] 로그인 잠금: %s (%s)", key, ip)
+		s.audit(loginID, fmt.Sprintf("LOGIN_LOCKED key=%s ip=%s until=%s", key, ip,
+			time.Now().Add(s.LoginGuard.Policy.LockDuration).UTC().Format(time.RFC3339)))
+	}
+}
+
+// releaseLoginAttempt: 비밀번호/인증 코드가 맞았거나 서버 오류로 확인하지 못했을 때 미리 집계한 1회를 되돌림
+func (s *Server) releaseLoginAttempt(accountKey, ipKey string) {
+	if s.LoginGuard == nil {
+		return
+	}
+	if err := s.LoginGuard.Pass(accountKey, ipKey); err != nil {
+		log.Printf("[LOCKOUT ERROR] 시도 집계 취소 실패: %v", err)
+	}
+}
+
+func (s *Server) recordLoginSuccess(accountKey string) {
+	if s.LoginGuard == nil {
+		return
+	}
+	if err := s.LoginGuard.Succeed(accountKey); err != nil {
+		log.Printf("[LOCKOUT ERROR] 실패 집계 초기화 실패: %v", err)
+	}
+}
+
+// HandleUnlockLogin: 로그인 잠금 해제 (POST /admin/login-locks/unlock)
+// 회원 계정은 account:unlock 권한으로 해제할 수 있고, 관리자 계정과 IP 잠금은 gym:all 권한까지 필요하다.
+func (s *Server) HandleUnlockLogin(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.LoginGuard == nil {
+		s.errorJSON(w, "로그인 실패 제한이 비활성화되어 있습니다.", http.StatusServiceUnavailable)
+		return
+	}
+	claims := r.Context().Value(UserContextKey).(*auth.Claims)
+
+	var req struct {
+		PrincipalType string `json:"principal_type"` // USER(기본) / ADMIN
+		LoginID       string `json:"login_id"`
+		IP            string `json:"ip"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+	if req.PrincipalType == "" {
+		req.PrincipalType = domain.PrincipalUser
+	}
+	if req.PrincipalType != domain.PrincipalUser && req.PrincipalType != domain.PrincipalAdmin {
+		s.errorJSON(w, "principal_type은 USER 또는 ADMIN이어야 합니다.", http.StatusBadRequest)
+		return
+	}
+	if req.LoginID == "" && req.IP == "" {
+		s.errorJSON(w, "login_id 또는 ip가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+	if (req.IP != "" || (req.LoginID != "" && req.PrincipalType == domain.PrincipalAdmin)) && !claims.HasPermission(auth.PermAllGyms) {
+		s.errorJSON(w, "관리자 계정과 IP 잠금은 최고 관리자만 해제할 수 있습니다.", http.StatusForbidden)
+		return
+	}
+
+	var keys []string
+	if req.LoginID != "" {
+		keys = append(keys, auth.AccountAttemptKey(req.PrincipalType, req.LoginID))
+	}
+	if req.IP != "" {
+		keys = append(keys, auth.IPAttemptKey(req.IP))
+	}
+	for _, key := range keys {
+		if err := s.LoginGuard.Store.Reset(key); err != nil {
+			s.errorJSON(w, "잠금 해제 실패", http.StatusInternalServerError)
+			return
+		}
+		s.audit(claims.UserID, "LOGIN_UNLOCKED key="+key)
+	}
+
+	log.Printf("[LOCKOUT] %s 잠금 해제: %v", claims.UserID, keys)
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "unlocked": keys})
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/repository"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HandleMyPass: /api/me/passes/{id}[/freeze|/transfer] 라우팅
+func (s *Server) HandleMyPass(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/me/passes/"), "/")
+	parts := strings.Split(rest, "/")
+	passNum, _ := strconv.ParseInt(parts[0], 10, 64)
+
+	pass, err := s.Repo.GetPassByID(passNum)
+	if err != nil || pass.UserNumber != claims.UserNumber {
+		s.errorJSON(w, "이용권을 찾을 수 없습니다.", http.StatusNotFound)
+		return
+	}
+
+	action := ""
+	if len(parts) > 1 {
+		action = parts[1]
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		freezes, err := s.Repo.GetPassFreezes(pass.PassNumber)
+		if err != nil {
+			s.errorJSON(w, "이용권 조회 실패", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"pass":    pass,
+			"freezes": freezes,
+		})
+	case action == "freeze" && r.Method == http.MethodPost:
+		s.freezePass(w, r, claims, pass)
+	case action == "transfer" && r.Method == http.MethodPost:
+		s.transferPass(w, r, claims, pass)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// freezePass: 회원권 일시정지 (정지 일수만큼 만료일 연장, 정지 기간 중 예약 불가)
+func (s *Server) freezePass(w http.ResponseWriter, r *http.Request, claims *auth.Claims, pass *domain.Pass) {
+	var req struct {
+		StartDate string `json:"start_date"` // YYYY-MM-DD (한국 시간)
+		Days      int    `json:"days"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorJSON(w, "잘못된 요청 형식입니다.", http.StatusBadRequest)
+		return
+	}
+	start, err := time.ParseInLocation(dateLayout, req.StartDate, algo.KST)
+	if err != nil {
+		s.errorJSON(w, "시작일 형식이 올바르지 않습니다. (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+
+	// 연간 횟수/일수 한도는 저장소가 이용권 행을 잠근 뒤 읽은 이력으로 검사한다 (동시 요청으로 한도 초과 방지).
+	now := time.Now()
+	from, to := algo.FreezeWindow(start, req.Days)
+	freeze := &domain.PassFreeze{PassNumber: pass.PassNumber, StartAt: from, EndAt: to, Days: req.Days}
+	var policyErr error
+	err = s.Repo.FreezePass(claims.UserNumber, freeze, func(history []domain.PassFreeze) error {
+		policyErr = s.FreezePolicy.CheckFreeze(pass, start, req.Days, now, history)
+		return policyErr
+	})
+	if policyErr != nil {
+		s.errorJSON(w, policyErr.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.repoErrorJSON(w, err, "일시정지 처리 실패")
+		return
+	}
+
+	newExpiry := pass.ExpiresAt.AddDate(0, 0, req.Days)
+	s.audit(claims.UserID, fmt.Sprintf("PASS_FREEZE pass=%d %s~%s days=%d expires=%s",
+		pass.PassNumber, from.Format(dateLayout), to.AddDate(0, 0, -1).Format(dateLayout), req.Days,
+		newExpiry.In(algo.KST).Format(dateLayout)))
+	log.Printf("[SUCCESS] 유저 %s 이용권 %d번 %d일 일시정지", claims.UserID, pass.PassNumber, req.Days)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":     "success",
+		"freeze":     freeze,
+		"expires_at": newExpiry,
+	})
+}
+
+// transferPass: 다른 회원에게 이용권 양도 (입장 중/정지 중인 이용권 불가)
+func (s *Server) transferPass(w http.ResponseWriter, r *http.Request, claims *auth.Claims, pass *domain.Pass) {
+	var req struct {
+		ToUserID string `json:"to_user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorJSON(w, "잘못된 요청 형식입니다.", http.StatusBadRequest)
+		return
+	}
+
+	if !algo.IsFreezable(pass.ProductType) {
+		s.errorJSON(w, "일일권은 양도할 수 없습니다.", http.StatusBadRequest)
+		return
+	}
+	if pass.Status != domain.PassActive || !time.Now().Before(pass.ExpiresAt) {
+		s.errorJSON(w, "사용 가능한 이용권만 양도할 수 있습니다.", http.StatusBadRequest)
+		return
+	}
+
+	target, err := s.Repo.GetUserByID(req.ToUserID)
+	if err != nil {
+		s.errorJSON(w, "양도받을 회원을 찾을 수 없습니다.", http.StatusNotFound)
+		return
+	}
+	if target.UserNumber == claims.UserNumber {
+		s.errorJSON(w, "본인에게는 양도할 수 없습니다.", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.Repo.TransferPass(pass.PassNumber, claims.UserNumber, target.UserNumber); err != nil {
+		if errors.Is(err, repository.ErrPassInUse) {
+			s.errorJSON(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.repoErrorJSON(w, err, "양도 처리 실패")
+		return
+	}
+
+	s.audit(claims.UserID, fmt.Sprintf("PASS_TRANSFER_OUT pass=%d to=%s", pass.PassNumber, target.UserID))
+	s.audit(target.UserID, fmt.Sprintf("PASS_TRANSFER_IN pass=%d from=%s", pass.PassNumber, claims.UserID))
+	log.Printf("[SUCCESS] 이용권 %d번 양도: %s -> %s", pass.PassNumber, claims.UserID, target.UserID)
+
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
@@ -0,0 +1,381 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/repository"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	mfaIssuer         = "GUSS" // OTP 앱에 표시되는 서비스 이름
+	recoveryCodeCount = 10
+)
+
+var errInvalidMFACode = errors.New("인증 코드가 올바르지 않습니다")
+
+// beginAdminMFA: 비밀번호 확인 직후 2단계 인증이 필요하면 챌린지 토큰을 응답하고 true 반환
+//   - OTP 등록 완료: mfa_required (코드 입력 후 /api/admin/login/mfa)
+//   - 미등록인데 역할상 필수: mfa_setup_required (/api/admin/mfa/setup → /api/admin/mfa/activate)
+func (s *Server) beginAdminMFA(w http.ResponseWriter, p *principal) bool {
+	status, purpose := "", ""
+	mfa, err := s.Repo.GetAdminMFA(p.Number)
+	switch {
+	case err == nil && mfa.EnabledAt != nil:
+		status, purpose = "mfa_required", auth.ChallengeMFAVerify
+	case err != nil && !errors.Is(err, repository.ErrNotFound):
+		s.errorJSON(w, "2단계 인증 정보 조회 실패", http.StatusInternalServerError)
+		return true
+	default:
+		required, err := s.Repo.GetRoleMFARequired(p.Role)
+		if err != nil {
+			s.errorJSON(w, "2단계 인증 정보 조회 실패", http.StatusInternalServerError)
+			return true
+		}
+		if !required {
+			return false
+		}
+		status, purpose = "mfa_setup_required", auth.ChallengeMFASetup
+	}
+
+	challenge, err := auth.GenerateChallengeToken(p.Type, p.LoginID, purpose)
+	if err != nil {
+		s.errorJSON(w, "토큰 생성 실패", http.StatusInternalServerError)
+		return true
+	}
+	log.Printf("[LOGIN] %s %s 비밀번호 확인, 2단계 인증 대기 (%s)", p.Type, p.LoginID, status)
+	json.NewEncoder(w).Encode(mfaChallengeResponse{
+		Status:         status,
+		ChallengeToken: challenge,
+		ExpiresIn:      int(auth.ChallengeTTL.Seconds()),
+	})
+	return true
+}
+
+// checkSecondFactor: OTP 코드 또는 복구 코드 확인 (성공하면 사용한 수단과 남은 복구 코드 수 반환, OTP면 -1)
+func (s *Server) checkSecondFactor(mfa *domain.AdminMFA, code, recoveryCode string) (method string, remaining int, err error) {
+	if recoveryCode != "" {
+		remaining, err := s.Repo.UseRecoveryCode(mfa.AdminNumber, auth.HashRecoveryCode(recoveryCode))
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", 0, errInvalidMFACode
+		}
+		return "recovery_code", remaining, err
+	}
+
+	step, ok := auth.VerifyTOTP(mfa.Secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return "", 0, errInvalidMFACode
+	}
+	if err := s.Repo.UseAdminTOTPStep(mfa.AdminNumber, step); err != nil {
+		return "", 0, err
+	}
+	return "totp", -1, nil
+}
+
+// mfaPrincipal: OTP 등록 API의 호출자 확인
+// 로그인 중(필수 역할의 최초 등록)에는 mfa_setup 챌린지 토큰을, 로그인된 관리자는 Bearer 액세스 토큰을 사용한다.
+func (s *Server) mfaPrincipal(w http.ResponseWriter, r *http.Request, challengeToken string) (p *principal, viaChallenge bool, ok bool) {
+	var loginID string
+	var number int64
+	if challengeToken != "" {
+		claims, err := auth.ValidateChallengeToken(challengeToken, auth.ChallengeMFASetup)
+		if err != nil || claims.PrincipalType != domain.PrincipalAdmin {
+			s.errorCodeJSON(w, "2단계 인증 토큰이 유효하지 않습니다. 다시 로그인해 주세요.", auth.TokenErrorCode(err), http.StatusUnauthorized)
+			return nil, false, false
+		}
+		loginID, viaChallenge = claims.Subject, true
+	} else {
+		authHeader := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			s.errorCodeJSON(w, "인증 토큰이 없습니다.", "TOKEN_MISSING", http.StatusUnauthorized)
+			return nil, false, false
+		}
+		claims, err := auth.ValidateToken(strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
+			s.errorCodeJSON(w, err.Error(), auth.TokenErrorCode(err), http.StatusUnauthorized)
+			return nil, false, false
+		}
+		if claims.Role == auth.RoleUser {
+			s.errorJSON(w, "관리자 계정만 OTP 인증을 등록할 수 있습니다.", http.StatusForbidden)
+			return nil, false, false
+		}
+		loginID, number = claims.UserID, claims.UserNumber
+	}
+
+	p, err := s.loadPrincipal(domain.PrincipalAdmin, loginID)
+	if err != nil || (number != 0 && p.Number != number) {
+		s.errorJSON(w, "계정 정보를 찾을 수 없습니다. 다시 로그인해 주세요.", http.StatusUnauthorized)
+		return nil, false, false
+	}
+	return p, viaChallenge, true
+}
+
+// HandleAdminLoginMFA: 로그인 2단계 - OTP 또는 복구 코드 확인 후 토큰 발급 (POST /api/admin/login/mfa)
+// 실패는 비밀번호 실패와 같은 계정/IP 집계에 더해져 OTP 무차별 대입도 잠금 대상이 된다.
+func (s *Server) HandleAdminLoginMFA(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ChallengeToken string `json:"challenge_token"`
+		Code           string `json:"code"`
+		RecoveryCode   string `json:"recovery_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChallengeToken == "" || (req.Code == "" && req.RecoveryCode == "") {
+		s.errorJSON(w, "challenge_token과 code(또는 recovery_code)가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+
+	claims, err := auth.ValidateChallengeToken(req.ChallengeToken, auth.ChallengeMFAVerify)
+	if err != nil || claims.PrincipalType != domain.PrincipalAdmin {
+		s.errorCodeJSON(w, "2단계 인증 토큰이 유효하지 않습니다. 다시 로그인해 주세요.", auth.TokenErrorCode(err), http.StatusUnauthorized)
+		return
+	}
+
+	ip := s.clientIP(r)
+	accountKey, ipKey := auth.AccountAttemptKey(domain.PrincipalAdmin, claims.Subject), auth.IPAttemptKey(ip)
+	if !s.checkLoginThrottle(w, accountKey, ipKey) {
+		return
+	}
+
+	p, err := s.loadPrincipal(domain.PrincipalAdmin, claims.Subject)
+	if err != nil {
+		s.releaseLoginAttempt(accountKey, ipKey)
+		s.errorJSON(w, "계정 정보를 찾을 수 없습니다. 다시 로그인해 주세요.", http.StatusUnauthorized)
+		return
+	}
+	mfa, err := s.Repo.GetAdminMFA(p.Number)
+	if err != nil || mfa.EnabledAt == nil {
+		s.releaseLoginAttempt(accountKey, ipKey)
+		s.errorJSON(w, "OTP 인증이 등록되어 있지 않습니다. 다시 로그인해 주세요.", http.StatusUnauthorized)
+		return
+	}
+
+	method, remaining, err := s.checkSecondFactor(mfa, req.Code, req.RecoveryCode)
+	switch {
+	case errors.Is(err, errInvalidMFACode), errors.Is(err, repository.ErrMFACodeReused):
+		s.recordLoginFailure(p.LoginID, accountKey, ipKey, ip)
+		s.errorJSON(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		s.releaseLoginAttempt(accountKey, ipKey)
+		s.errorJSON(w, "인증 코드 확인 실패", http.StatusInternalServerError)
+		return
+	}
+	s.releaseLoginAttempt(accountKey, ipKey)
+	s.recordLoginSuccess(accountKey)
+	s.audit(p.LoginID, fmt.Sprintf("LOGIN_MFA method=%s ip=%s", method, ip))
+
+	session, err := s.issueSession(p)
+	if err != nil {
+		s.errorJSON(w, "토큰 생성 실패", http.StatusInternalServerError)
+		return
+	}
+	if method == "recovery_code" {
+		session.RecoveryCodesRemaining = &remaining
+	}
+	json.NewEncoder(w).Encode(session)
+}
+
+// HandleAdminMFASetup: OTP 등록 시작 - 비밀키와 otpauth URI 발급 (POST /api/admin/mfa/setup)
+// 첫 코드로 활성화하기 전까지는 로그인에 쓰이지 않으며, 다시 요청하면 새 비밀키로 바뀐다.
+func (s *Server) HandleAdminMFASetup(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ChallengeToken string `json:"challenge_token"`
+	}
+	// 로그인된 관리자는 본문 없이 호출할 수 있다.
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+	p, _, ok := s.mfaPrincipal(w, r, req.ChallengeToken)
+	if !ok {
+		return
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		s.errorJSON(w, "비밀키 생성 실패", http.StatusInternalServerError)
+		return
+	}
+	if err := s.Repo.SaveAdminMFASecret(p.Number, secret); err != nil {
+		if errors.Is(err, repository.ErrMFAAlreadyEnabled) {
+			s.errorJSON(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.errorJSON(w, "OTP 등록 실패", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("[MFA] %s OTP 등록 시작", p.LoginID)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "success",
+		"secret":      secret,
+		"otpauth_uri": auth.TOTPURI(mfaIssuer, p.LoginID, secret),
+	})
+}
+
+// HandleAdminMFAActivate: OTP 앱에 표시된 첫 코드로 활성화하고 복구 코드 발급 (POST /api/admin/mfa/activate)
+// 복구 코드 원문은 이 응답에서만 볼 수 있다. 로그인 중(mfa_setup 챌린지)이었다면 토큰도 함께 발급한다.
+func (s *Server) HandleAdminMFAActivate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ChallengeToken string `json:"challenge_token"`
+		Code           string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		s.errorJSON(w, "code가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+	p, viaChallenge, ok := s.mfaPrincipal(w, r, req.ChallengeToken)
+	if !ok {
+		return
+	}
+
+	mfa, err := s.Repo.GetAdminMFA(p.Number)
+	if errors.Is(err, repository.ErrNotFound) {
+		s.errorJSON(w, "먼저 OTP 등록을 시작해 주세요.", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.errorJSON(w, "2단계 인증 정보 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	if mfa.EnabledAt != nil {
+		s.errorJSON(w, repository.ErrMFAAlreadyEnabled.Error(), http.StatusConflict)
+		return
+	}
+
+	// 로그인과 같은 실패 집계 (탈취한 챌린지/액세스 토큰으로 코드를 대입하지 못하도록)
+	ip := s.clientIP(r)
+	accountKey, ipKey := auth.AccountAttemptKey(domain.PrincipalAdmin, p.LoginID), auth.IPAttemptKey(ip)
+	if !s.checkLoginThrottle(w, accountKey, ipKey) {
+		return
+	}
+	step, valid := auth.VerifyTOTP(mfa.Secret, strings.TrimSpace(req.Code), time.Now())
+	if !valid {
+		s.recordLoginFailure(p.LoginID, accountKey, ipKey, ip)
+		s.errorJSON(w, errInvalidMFACode.Error(), http.StatusBadRequest)
+		return
+	}
+	s.releaseLoginAttempt(accountKey, ipKey)
+	codes, hashes, err := auth.NewRecoveryCodes(recoveryCodeCount)
+	if err == nil {
+		err = s.Repo.ActivateAdminMFA(p.Number, step, hashes)
+	}
+	if errors.Is(err, repository.ErrMFAAlreadyEnabled) {
+		s.errorJSON(w, err.Error(), http.StatusConflict)
+		return
+	} else if err != nil {
+		s.errorJSON(w, "OTP 활성화 실패", http.StatusInternalServerError)
+		return
+	}
+	s.audit(p.LoginID, "MFA_ENABLED")
+	log.Printf("[MFA] %s OTP 인증 활성화", p.LoginID)
+
+	if !viaChallenge {
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "recovery_codes": codes})
+		return
+	}
+
+	s.recordLoginSuccess(accountKey)
+	session, err := s.issueSession(p)
+	if err != nil {
+		s.errorJSON(w, "토큰 생성 실패", http.StatusInternalServerError)
+		return
+	}
+	session.RecoveryCodes = codes
+	json.NewEncoder(w).Encode(session)
+}
+
+// HandleDisableAdminMFA: OTP 인증 해제 (DELETE /api/admin/mfa, 현재 코드 또는 복구 코드 필요)
+// 역할상 필수인 관리자는 해제할 수 없다 (기기 분실 시 복구 코드로 로그인 후 재등록).
+func (s *Server) HandleDisableAdminMFA(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	claims := r.Context().Value(UserContextKey).(*auth.Claims)
+	if claims.Role == auth.RoleUser {
+		s.errorJSON(w, "관리자 계정만 사용할 수 있습니다.", http.StatusForbidden)
+		return
+	}
+
+	var req struct {
+		Code         string `json:"code"`
+		RecoveryCode string `json:"recovery_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || (req.Code == "" && req.RecoveryCode == "") {
+		s.errorJSON(w, "code 또는 recovery_code가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+
+	required, err := s.Repo.GetRoleMFARequired(claims.Role)
+	if err != nil {
+		s.errorJSON(w, "2단계 인증 정보 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	if required {
+		s.errorJSON(w, "OTP 인증이 필수인 역할은 해제할 수 없습니다.", http.StatusForbidden)
+		return
+	}
+
+	mfa, err := s.Repo.GetAdminMFA(claims.UserNumber)
+	if err != nil || mfa.EnabledAt == nil {
+		s.errorJSON(w, "OTP 인증이 등록되어 있지 않습니다.", http.StatusNotFound)
+		return
+	}
+
+	// 로그인과 같은 실패 집계 (탈취한 액세스 토큰으로 코드를 대입해 OTP를 끄지 못하도록)
+	ip := s.clientIP(r)
+	accountKey, ipKey := auth.AccountAttemptKey(domain.PrincipalAdmin, claims.UserID), auth.IPAttemptKey(ip)
+	if !s.checkLoginThrottle(w, accountKey, ipKey) {
+		return
+	}
+	_, _, err = s.checkSecondFactor(mfa, req.Code, req.RecoveryCode)
+	switch {
+	case errors.Is(err, errInvalidMFACode), errors.Is(err, repository.ErrMFACodeReused):
+		s.recordLoginFailure(claims.UserID, accountKey, ipKey, ip)
+		s.errorJSON(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		s.releaseLoginAttempt(accountKey, ipKey)
+		s.errorJSON(w, "인증 코드 확인 실패", http.StatusInternalServerError)
+		return
+	}
+	s.releaseLoginAttempt(accountKey, ipKey)
+	s.recordLoginSuccess(accountKey)
+
+	if err := s.Repo.DisableAdminMFA(claims.UserNumber); err != nil {
+		s.errorJSON(w, "OTP 해제 실패", http.StatusInternalServerError)
+		return
+	}
+	s.audit(claims.UserID, "MFA_DISABLED")
+	log.Printf("[MFA] %s OTP 인증 해제", claims.UserID)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/repository"
+)
+
+// testMFARepo: admin_mfa_table.last_used_step 조건부 갱신(last_used_step < step)을 흉내 내는 저장소
+type testMFARepo struct {
+	repository.Repository
+	lastUsedStep int64
+}
+
+func (r *testMFARepo) UseAdminTOTPStep(adminNum, step int64) error {
+	if step <= r.lastUsedStep {
+		return repository.ErrMFACodeReused
+	}
+	r.lastUsedStep = step
+	return nil
+}
+
+// otpAt: OTP 앱과 같은 방식(RFC 6238, 30초, 6자리)으로 step 구간의 코드 생성
+func otpAt(t *testing.T, secret string, step int64) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[offset:offset+4])&0x7fffffff)%1000000)
+}
+
+func TestCheckSecondFactorRejectsReplay(t *testing.T) {
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &testMFARepo{Repository: repository.NewMockRepository()}
+	s := &Server{Repo: repo}
+	mfa := &domain.AdminMFA{AdminNumber: 1, Secret: secret}
+
+	// 테스트 도중 구간이 바뀌지 않도록 경계 직전이면 다음 구간까지 기다린다.
+	if rem := 30 - time.Now().Unix()%30; rem <= 2 {
+		time.Sleep(time.Duration(rem) * time.Second)
+	}
+	current := time.Now().Unix() / 30
+	prev := otpAt(t, secret, current-1)
+	if method, _, err := s.checkSecondFactor(mfa, prev, ""); err != nil || method != "totp" {
+		t.Fatalf("1구간 전 코드 = %q, %v; want totp", method, err)
+	}
+	if repo.lastUsedStep != current-1 {
+		t.Fatalf("last_used_step = %d, want %d", repo.lastUsedStep, current-1)
+	}
+
+	if _, _, err := s.checkSecondFactor(mfa, prev, ""); !errors.Is(err, repository.ErrMFACodeReused) {
+		t.Errorf("같은 코드 재사용 err = %v, want ErrMFACodeReused", err)
+	}
+	if _, _, err := s.checkSecondFactor(mfa, otpAt(t, secret, current-2), ""); !errors.Is(err, errInvalidMFACode) {
+		t.Errorf("2구간 전 코드 err = %v, want errInvalidMFACode", err)
+	}
+
+	next := otpAt(t, secret, current+1)
+	if _, _, err := s.checkSecondFactor(mfa, next, ""); err != nil {
+		t.Fatalf("다음 구간 코드 err = %v", err)
+	}
+	// 더 뒤의 구간을 쓴 뒤에는 아직 허용 범위 안인 이전 구간 코드도 거절한다.
+	if _, _, err := s.checkSecondFactor(mfa, otpAt(t, secret, current), ""); !errors.Is(err, repository.ErrMFACodeReused) {
+		t.Errorf("이전 구간 코드 err = %v, want ErrMFACodeReused", err)
+	}
+}
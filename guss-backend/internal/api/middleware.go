@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"guss-backend/internal/auth"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -12,16 +14,17 @@ func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			s.errorJSON(w, "인증 토큰이 없습니다.", http.StatusUnauthorized)
+			s.errorCodeJSON(w, "인증 토큰이 없습니다.", "TOKEN_MISSING", http.StatusUnauthorized)
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-		// 토큰 검증 및 Claims 추출
+		// 토큰 검증 및 Claims 추출 (만료는 TOKEN_EXPIRED로 구분되어 클라이언트가 리프레시를 시도할 수 있음)
 		claims, err := auth.ValidateToken(tokenString)
 		if err != nil {
-			s.errorJSON(w, "유효하지 않거나 만료된 토큰입니다.", http.StatusUnauthorized)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			s.errorCodeJSON(w, err.Error(), auth.TokenErrorCode(err), http.StatusUnauthorized)
 			return
 		}
 
@@ -32,16 +35,108 @@ func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// AdminMiddleware: ADMIN 권한이 있는 유저만 허용 (AuthMiddleware 뒤에 배치해야 함)
-func (s *Server) AdminMiddleware(next http.Handler) http.Handler {
+// RequirePermission: 나열한 권한을 모두 가진 유저만 허용 (AuthMiddleware 뒤에 배치해야 함)
+func (s *Server) RequirePermission(perms ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Context에서 주입된 Claims 확인
+			claims, ok := r.Context().Value(UserContextKey).(*auth.Claims)
+			if !ok {
+				s.errorJSON(w, "인증 정보가 없습니다.", http.StatusUnauthorized)
+				return
+			}
+			for _, perm := range perms {
+				if !claims.HasPermission(perm) {
+					log.Printf("[AUTH] %s(%s) 권한 부족: %s 필요 (%s)", claims.UserID, claims.Role, perm, r.URL.Path)
+					s.errorJSON(w, "해당 기능에 대한 권한이 없습니다.", http.StatusForbidden)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// adminGymScope: 관리자가 접근 가능한 지점 ID 반환 (gym:all 권한이면 0 = 전체)
+// 담당 지점이 없는 관리자는 어떤 지점도 수정할 수 없으므로 ok=false
+func adminGymScope(claims *auth.Claims) (gymID int64, ok bool) {
+	if claims.HasPermission(auth.PermAllGyms) {
+		return 0, true
+	}
+	if claims.Role == auth.RoleUser || claims.GymID <= 0 {
+		return 0, false
+	}
+	return claims.GymID, true
+}
+
+// GymScopeMiddleware: 호출자의 관리 지점 범위를 계산해 Context에 고정 (AuthMiddleware 뒤에 배치해야 함)
+// 담당 지점이 없는 관리자나 일반 회원은 핸들러에 도달하기 전에 403으로 차단된다.
+func (s *Server) GymScopeMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Context에서 주입된 Claims 확인
 		claims, ok := r.Context().Value(UserContextKey).(*auth.Claims)
-		if !ok || claims.Role != "ADMIN" {
-			s.errorJSON(w, "관리자 권한이 필요합니다.", http.StatusForbidden)
+		if !ok {
+			s.errorJSON(w, "인증 정보가 없습니다.", http.StatusUnauthorized)
+			return
+		}
+		scope, ok := adminGymScope(claims)
+		if !ok {
+			s.errorJSON(w, "해당 지점에 대한 관리 권한이 없습니다.", http.StatusForbidden)
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		ctx := context.WithValue(r.Context(), GymScopeContextKey, scope)
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// requireGymScope: 요청자의 관리 가능한 지점 범위를 꺼냄 (실패 시 에러 응답까지 처리)
+// GymScopeMiddleware를 거친 요청은 Context 값을 그대로 쓰고, 아니면 Claims에서 계산한다.
+func (s *Server) requireGymScope(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	if scope, ok := r.Context().Value(GymScopeContextKey).(int64); ok {
+		return scope, true
+	}
+	claims, ok := r.Context().Value(UserContextKey).(*auth.Claims)
+	if !ok {
+		s.errorJSON(w, "인증 정보가 없습니다.", http.StatusUnauthorized)
+		return 0, false
+	}
+	scope, ok := adminGymScope(claims)
+	if !ok {
+		s.errorJSON(w, "해당 지점에 대한 관리 권한이 없습니다.", http.StatusForbidden)
+		return 0, false
+	}
+	return scope, true
+}
+
+// memberClaims: 회원 본인 기능(/api/me/*, 예약, 결제 등) 공통 확인 - 관리자 토큰의 번호는 admin_number라 회원 번호로 쓰면 안 됨
+func (s *Server) memberClaims(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := r.Context().Value(UserContextKey).(*auth.Claims)
+	if !ok {
+		s.errorJSON(w, "인증 정보가 없습니다.", http.StatusUnauthorized)
+		return nil, false
+	}
+	if claims.Role != auth.RoleUser {
+		s.errorJSON(w, "회원 전용 기능입니다.", http.StatusForbidden)
+		return nil, false
+	}
+	return claims, true
+}
+
+// scopedGymID: 지점 관리자는 요청한 gym_id와 무관하게 자기 지점으로 고정 (scope 0 = 전 지점이면 요청값 사용)
+func scopedGymID(scope, requested int64) int64 {
+	if scope != 0 {
+		return scope
+	}
+	return requested
+}
+
+// queryGymID: gym_id 쿼리 파라미터 (기존 프론트엔드의 gymId 표기도 허용)
+func queryGymID(r *http.Request) int64 {
+	idStr := r.URL.Query().Get("gym_id")
+	if idStr == "" {
+		idStr = r.URL.Query().Get("gymId")
+	}
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+	return id
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/notify"
+	"guss-backend/internal/repository"
+	"log"
+	"net/http"
+	"time"
+)
+
+// codeResendInterval: 같은 대상에게 인증 코드를 다시 보내기까지의 최소 간격 (문자 폭탄 방지)
+const codeResendInterval = time.Minute
+
+// HandleRequestPasswordReset: 비밀번호 재설정 코드 발송 (POST /api/password-reset/request)
+// 가입된 아이디인지 드러나지 않도록 아이디가 없거나 발송을 건너뛰어도 같은 응답을 준다.
+func (s *Server) HandleRequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.Notifier == nil {
+		s.errorJSON(w, "인증 코드 발송이 비활성화되어 있습니다.", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" {
+		s.errorJSON(w, "user_id가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+
+	accepted := map[string]interface{}{
+		"status":     "success",
+		"message":    "가입된 아이디라면 등록된 휴대폰 번호로 인증 코드가 발송됩니다.",
+		"expires_in": int(auth.VerificationCodeTTL.Seconds()),
+	}
+
+	user, err := s.Repo.GetUserByID(req.UserID)
+	if err != nil {
+		json.NewEncoder(w).Encode(accepted)
+		return
+	}
+	if prev, err := s.Repo.GetActivePasswordReset(user.UserNumber); err == nil && time.Since(prev.CreatedAt) < codeResendInterval {
+		log.Printf("[RESET] %s 재발송 간격 미달로 건너뜀", user.UserID)
+		json.NewEncoder(w).Encode(accepted)
+		return
+	}
+
+	code, hash, err := auth.NewVerificationCode()
+	if err != nil {
+		s.errorJSON(w, "인증 코드 생성 실패", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	reset := &domain.PasswordReset{UserNumber: user.UserNumber, CodeHash: hash, ExpiresAt: now.Add(auth.VerificationCodeTTL), CreatedAt: now}
+	if err := s.Repo.CreatePasswordReset(reset); err != nil {
+		s.errorJSON(w, "인증 코드 생성 실패", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	err = s.Notifier.Send(ctx, notify.Message{
+		Channel: notify.ChannelSMS,
+		To:      user.UserPhone,
+		Body:    "[GUSS] 비밀번호 재설정 인증번호는 " + code + " 입니다. 10분 안에 입력해 주세요.",
+	})
+	if err != nil {
+		// 발송 실패도 응답으로는 구분하지 않는다 (회원은 잠시 후 다시 요청)
+		log.Printf("[NOTIFY ERROR] %s 재설정 코드 발송 실패: %v", user.UserID, err)
+	} else {
+		s.audit(user.UserID, "PASSWORD_RESET_REQUESTED")
+	}
+	json.NewEncoder(w).Encode(accepted)
+}
+
+// HandleConfirmPasswordReset: 코드 확인 후 새 비밀번호 설정 (POST /api/password-reset/confirm)
+// 성공하면 코드는 사용 처리되고 기존 로그인 세션(리프레시 토큰)은 모두 폐기된다.
+func (s *Server) HandleConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		UserID      string `json:"user_id"`
+		Code        string `json:"code"`
+		NewPassword string `json:"new_password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.Code == "" {
+		s.errorJSON(w, "user_id, code, new_password가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+	if err := auth.ValidatePassword(req.NewPassword, req.UserID); err != nil {
+		s.errorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	const invalidCode = "인증 코드가 올바르지 않거나 만료되었습니다."
+	user, err := s.Repo.GetUserByID(req.UserID)
+	if err != nil {
+		s.errorCodeJSON(w, invalidCode, "RESET_CODE_INVALID", http.StatusBadRequest)
+		return
+	}
+	reset, err := s.Repo.GetActivePasswordReset(user.UserNumber)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && time.Now().After(reset.ExpiresAt)) {
+		s.errorCodeJSON(w, invalidCode, "RESET_CODE_INVALID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.errorJSON(w, "인증 코드 확인 실패", http.StatusInternalServerError)
+		return
+	}
+
+	// 비교 전에 시도 횟수부터 차감 (동시 요청으로 한도를 넘겨 코드를 대입하지 못하도록)
+	if err := s.Repo.UsePasswordResetAttempt(reset.ResetNumber, auth.VerificationCodeMaxAttempts); errors.Is(err, repository.ErrNotFound) {
+		s.errorCodeJSON(w, invalidCode, "RESET_CODE_INVALID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.errorJSON(w, "인증 코드 확인 실패", http.StatusInternalServerError)
+		return
+	}
+	if !auth.CheckVerificationCode(req.Code, reset.CodeHash) {
+		s.audit(user.UserID, "PASSWORD_RESET_FAILED")
+		s.errorCodeJSON(w, invalidCode, "RESET_CODE_INVALID", http.StatusBadRequest)
+		return
+	}
+
+	hashedPW, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		s.errorJSON(w, "비밀번호 처리 중 오류 발생", http.StatusInternalServerError)
+		return
+	}
+	if err := s.Repo.CompletePasswordReset(reset.ResetNumber, user.UserNumber, hashedPW); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			s.errorCodeJSON(w, invalidCode, "RESET_CODE_INVALID", http.StatusBadRequest)
+			return
+		}
+		s.errorJSON(w, "비밀번호 변경 실패", http.StatusInternalServerError)
+		return
+	}
+
+	// 잊어버린 비밀번호로 실패해 잠긴 계정도 재설정으로 풀린다.
+	s.recordLoginSuccess(auth.AccountAttemptKey(domain.PrincipalUser, user.UserID))
+	s.audit(user.UserID, "PASSWORD_RESET")
+	log.Printf("[RESET] %s 비밀번호 재설정 완료, 기존 세션 폐기", user.UserID)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
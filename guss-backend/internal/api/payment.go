@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/payment"
+	"guss-backend/internal/repository"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxWebhookBody: 웹훅 본문 최대 크기 (서명 검증 전이므로 작게 제한)
+const maxWebhookBody = 64 << 10
+
+// HandleCheckout: 회원의 이용권 온라인 구매
+// PENDING 주문 생성 -> 결제 승인/매입 요청까지만 하고, 이용권 발급과 매출 기록은 결제사 웹훅 확인 후에 한다.
+func (s *Server) HandleCheckout(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		ProductNumber int64  `json:"product_number"`
+		CouponCode    string `json:"coupon_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+
+	product, err := s.Repo.GetProductByID(req.ProductNumber)
+	if err != nil {
+		s.repoErrorJSON(w, err, "상품 조회 실패")
+		return
+	}
+	if !product.IsActive {
+		s.errorJSON(w, "판매가 중지된 상품입니다.", http.StatusBadRequest)
+		return
+	}
+
+	order := &domain.Order{
+		UserNumber:    claims.UserNumber,
+		GymID:         product.GymID,
+		ProductNumber: product.ProductNumber,
+		ProductName:   product.Name,
+		ProductType:   product.Type,
+		VisitCount:    product.VisitCount,
+		ValidityDays:  product.ValidityDays,
+		ListPrice:     product.Price,
+		Amount:        product.Price,
+	}
+	if code := normalizeCouponCode(req.CouponCode); code != "" {
+		coupon, err := s.Repo.GetCouponByCode(code)
+		if err != nil {
+			s.errorJSON(w, "존재하지 않는 쿠폰입니다.", http.StatusNotFound)
+			return
+		}
+		discount, err := algo.CouponDiscount(coupon, product, time.Now())
+		if err != nil {
+			s.errorJSON(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		order.CouponNumber = coupon.CouponNumber
+		order.CouponCode = coupon.Code
+		order.Discount = discount
+		order.Amount = product.Price - discount
+	}
+	if err := s.Repo.CreateOrder(order); err != nil {
+		s.repoErrorJSON(w, err, "주문 생성 실패")
+		return
+	}
+
+	// 할인 후 결제 금액이 0원이면 결제사를 거치지 않고 바로 확정
+	if order.Amount == 0 {
+		now := time.Now()
+		pass := newPassFromProduct(order.UserNumber, product, now)
+		sale := &domain.Sale{GymID: order.GymID, SalesType: product.Type, SalesAmount: 0, SalesDate: now, UserNumber: order.UserNumber,
+			Items: s.orderReceiptLines(product, order)}
+		if err := s.Repo.ConfirmOrder(order.OrderNumber, pass, sale); err != nil {
+			s.Repo.FailOrder(order.OrderNumber)
+			s.errorJSON(w, "주문 확정 실패", http.StatusInternalServerError)
+			return
+		}
+		order.Status = domain.OrderPaid
+		order.PassNumber = pass.PassNumber
+
+		log.Printf("[SUCCESS] 주문 %d 무료 쿠폰 적용 확정 (유저 %s, Pass: %d)", order.OrderNumber, claims.UserID, pass.PassNumber)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"order":  order,
+			"pass":   pass,
+		})
+		return
+	}
+
+	if s.Payments == nil {
+		s.Repo.FailOrder(order.OrderNumber)
+		s.errorJSON(w, "결제 기능이 설정되지 않았습니다.", http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx := r.Context()
+	pay, err := s.Payments.Authorize(ctx, payment.AuthorizeRequest{
+		OrderID:     strconv.FormatInt(order.OrderNumber, 10),
+		Amount:      order.Amount,
+		Description: product.Name,
+	})
+	if err == nil {
+		order.PaymentID = pay.ID
+		if err = s.Repo.SetOrderPayment(order.OrderNumber, pay.ID); err == nil {
+			_, err = s.Payments.Capture(ctx, pay.ID, order.Amount)
+		}
+	}
+	if err != nil {
+		log.Printf("[PAYMENT] 주문 %d 결제 실패: %v", order.OrderNumber, err)
+		if order.PaymentID != "" {
+			s.voidAuthorization(ctx, order)
+		}
+		s.Repo.FailOrder(order.OrderNumber)
+		s.errorJSON(w, "결제에 실패했습니다.", http.StatusPaymentRequired)
+		return
+	}
+
+	log.Printf("[PAYMENT] 주문 %d 결제 요청 완료 (유저 %s, %d원)", order.OrderNumber, claims.UserID, order.Amount)
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"order":  order,
+	})
+}
+
+// orderProduct: 주문 시점에 보관한 상품 정보 (상품 정보가 없는 이전 주문은 현재 상품으로 대신)
+func (s *Server) orderProduct(order *domain.Order) (*domain.Product, error) {
+	if order.ProductName == "" {
+		return s.Repo.GetProductByID(order.ProductNumber)
+	}
+	return &domain.Product{
+		ProductNumber: order.ProductNumber,
+		GymID:         order.GymID,
+		Name:          order.ProductName,
+		Type:          order.ProductType,
+		Price:         order.ListPrice,
+		VisitCount:    order.VisitCount,
+		ValidityDays:  order.ValidityDays,
+		IsActive:      true,
+	}, nil
+}
+
+// voidAuthorization: 주문을 실패 처리하기 전에 남은 승인을 취소한다.
+// 매입 요청이 오류를 냈어도 결제사에서는 매입됐을 수 있으므로(ErrInvalidState), 그 경우는 매입 웹훅에서 환불한다.
+func (s *Server) voidAuthorization(ctx context.Context, order *domain.Order) {
+	_, err := s.Payments.Void(ctx, order.PaymentID)
+	switch {
+	case err == nil:
+		log.Printf("[PAYMENT] 주문 %d 결제 %s 승인 취소", order.OrderNumber, order.PaymentID)
+	case errors.Is(err, payment.ErrInvalidState):
+		log.Printf("[PAYMENT] 주문 %d 결제 %s 승인 취소 불가 (이미 매입됨, 매입 웹훅에서 환불)", order.OrderNumber, order.PaymentID)
+	default:
+		log.Printf("[PAYMENT ERROR] 주문 %d 결제 %s 승인 취소 실패 (수동 취소 필요): %v", order.OrderNumber, order.PaymentID, err)
+	}
+}
+
+// HandlePaymentWebhook: 결제사 웹훅 수신 (서명 검증 후 주문 확정/실패 처리)
+// 같은 이벤트가 여러 번 와도 결과가 같도록, 이미 처리된 주문은 200으로 응답만 한다.
+func (s *Server) HandlePaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.Payments == nil {
+		s.errorJSON(w, "결제 기능이 설정되지 않았습니다.", http.StatusServiceUnavailable)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+	ev, err := s.Payments.VerifyWebhook(payload, r.Header.Get(payment.SignatureHeader))
+	if err != nil {
+		s.errorJSON(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	order, err := s.Repo.GetOrderByPaymentID(ev.PaymentID)
+	if err != nil {
+		s.repoErrorJSON(w, err, "주문 조회 실패")
+		return
+	}
+
+	switch ev.Type {
+	case payment.EventCaptured:
+		if ev.Amount != order.Amount {
+			log.Printf("[PAYMENT] 주문 %d 금액 불일치: 주문 %d원, 매입 %d원", order.OrderNumber, order.Amount, ev.Amount)
+			s.refundOrphanCapture(w, r, order, ev, "금액 불일치")
+			return
+		}
+
+		// 결제 확인 전에 상품이 수정/삭제됐더라도 주문 시점 조건대로 발급한다.
+		product, err := s.orderProduct(order)
+		if err != nil {
+			s.repoErrorJSON(w, err, "상품 조회 실패")
+			return
+		}
+		now := time.Now()
+		pass := newPassFromProduct(order.UserNumber, product, now)
+		sale := &domain.Sale{GymID: order.GymID, SalesType: product.Type, SalesAmount: order.Amount, SalesDate: now, UserNumber: order.UserNumber,
+			Items: s.orderReceiptLines(product, order)}
+
+		err = s.Repo.ConfirmOrder(order.OrderNumber, pass, sale)
+		if errors.Is(err, repository.ErrOrderNotPending) {
+			// 결제 요청 중 오류로 이미 실패 처리된 주문에 매입이 뒤늦게 확인되면, 이용권 없이 돈만 빠져나간 상태이므로 환불한다.
+			current, err := s.Repo.GetOrderByPaymentID(ev.PaymentID)
+			if err != nil {
+				s.repoErrorJSON(w, err, "주문 조회 실패")
+				return
+			}
+			if current.Status == domain.OrderFailed {
+				log.Printf("[PAYMENT] 주문 %d 실패 처리 후 매입 확인, 환불 진행", current.OrderNumber)
+				s.refundOrphanCapture(w, r, current, ev, "실패 주문")
+				return
+			}
+			log.Printf("[PAYMENT] 주문 %d 중복 웹훅 무시", order.OrderNumber)
+			break
+		}
+		if err != nil {
+			s.errorJSON(w, "주문 확정 실패", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[SUCCESS] 주문 %d 결제 완료 (Pass: %d, Sale: %d)", order.OrderNumber, pass.PassNumber, sale.SalesNumber)
+
+	case payment.EventFailed:
+		if err := s.Repo.FailOrder(order.OrderNumber); err != nil {
+			s.errorJSON(w, "주문 상태 변경 실패", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[PAYMENT] 주문 %d 결제 실패 처리", order.OrderNumber)
+
+	case payment.EventRefunded:
+		// 환불은 요청 시점에 동기적으로 기록하므로 여기서는 확인 로그만 남긴다.
+		log.Printf("[PAYMENT] 주문 %d 환불 확인 (%d원)", order.OrderNumber, ev.Amount)
+
+	default:
+		log.Printf("[PAYMENT] 처리하지 않는 이벤트: %s (%s)", ev.Type, ev.PaymentID)
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// refundOrphanCapture: 주문을 확정할 수 없는 매입(금액 불일치, 이미 실패한 주문)은 주문을 실패 처리하고 매입 금액 전액을 결제사에 환불한다.
+// 환불이 실패하면 5xx로 응답해 결제사의 웹훅 재전송 때 다시 시도하고, 그동안은 수동 환불 대상으로 로그를 남긴다.
+func (s *Server) refundOrphanCapture(w http.ResponseWriter, r *http.Request, order *domain.Order, ev *payment.Event, reason string) {
+	if order.Status != domain.OrderPending && order.Status != domain.OrderFailed {
+		log.Printf("[PAYMENT] 주문 %d 이미 %s 상태, %s 웹훅 무시", order.OrderNumber, order.Status, reason)
+		json.NewEncoder(w).Encode(map[string]string{"status": "ignored"})
+		return
+	}
+	if err := s.Repo.FailOrder(order.OrderNumber); err != nil {
+		s.errorJSON(w, "주문 상태 변경 실패", http.StatusInternalServerError)
+		return
+	}
+
+	// 이미 전액 환불된 결제(재전송된 웹훅)는 결제사가 ErrInvalidState를 돌려준다.
+	if _, err := s.Payments.Refund(r.Context(), ev.PaymentID, ev.Amount); err != nil && !errors.Is(err, payment.ErrInvalidState) {
+		log.Printf("[PAYMENT ERROR] 주문 %d 결제 %s %s 자동 환불 실패 (수동 환불 필요, %d원): %v",
+			order.OrderNumber, ev.PaymentID, reason, ev.Amount, err)
+		s.errorJSON(w, "확정할 수 없는 결제의 환불에 실패했습니다.", http.StatusBadGateway)
+		return
+	}
+	log.Printf("[PAYMENT] 주문 %d %s 결제 %d원 환불 완료", order.OrderNumber, reason, ev.Amount)
+	json.NewEncoder(w).Encode(map[string]string{"status": "refunded"})
+}
+
+// HandleGetMyOrders: 로그인한 회원의 주문 내역
+func (s *Server) HandleGetMyOrders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := s.Repo.GetOrdersByUser(claims.UserNumber)
+	if err != nil {
+		s.errorJSON(w, "주문 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(list)
+}
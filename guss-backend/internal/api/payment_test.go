@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/payment"
+	"guss-backend/internal/repository"
+)
+
+// testOrderRepo: 주문 하나의 상태 전이를 흉내 내는 Mock 저장소 (PENDING 주문만 확정/실패 처리된다)
+type testOrderRepo struct {
+	repository.Repository
+	order      *domain.Order
+	paymentErr error // SetOrderPayment가 돌려줄 오류
+	pass       *domain.Pass
+	sale       *domain.Sale
+}
+
+func (r *testOrderRepo) CreateOrder(o *domain.Order) error {
+	o.OrderNumber = 1
+	o.Status = domain.OrderPending
+	r.order = o
+	return nil
+}
+
+func (r *testOrderRepo) SetOrderPayment(orderNum int64, paymentID string) error {
+	r.order.PaymentID = paymentID
+	return r.paymentErr
+}
+
+func (r *testOrderRepo) GetOrderByPaymentID(paymentID string) (*domain.Order, error) {
+	if paymentID != r.order.PaymentID {
+		return nil, repository.ErrNotFound
+	}
+	o := *r.order
+	return &o, nil
+}
+
+func (r *testOrderRepo) ConfirmOrder(orderNum int64, pass *domain.Pass, sale *domain.Sale) error {
+	if r.order.Status != domain.OrderPending {
+		return repository.ErrOrderNotPending
+	}
+	r.order.Status = domain.OrderPaid
+	r.pass, r.sale = pass, sale
+	return nil
+}
+
+func (r *testOrderRepo) FailOrder(orderNum int64) error {
+	if r.order.Status == domain.OrderPending {
+		r.order.Status = domain.OrderFailed
+	}
+	return nil
+}
+
+// capturedPayment: 가짜 결제사에 매입까지 끝난 결제를 만들고 그 매입 웹훅 요청을 돌려준다.
+func capturedPayment(t *testing.T, pg *payment.FakeProvider, amount int64) (*payment.Payment, *http.Request) {
+	t.Helper()
+	ctx := context.Background()
+	pay, err := pg.Authorize(ctx, payment.AuthorizeRequest{OrderID: "1", Amount: amount})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pg.Capture(ctx, pay.ID, amount); err != nil {
+		t.Fatal(err)
+	}
+
+	payload, _ := json.Marshal(payment.Event{Type: payment.EventCaptured, PaymentID: pay.ID, OrderID: "1", Amount: amount, OccurredAt: time.Now()})
+	req := httptest.NewRequest(http.MethodPost, "/api/payments/webhook", strings.NewReader(string(payload)))
+	req.Header.Set(payment.SignatureHeader, pg.Sign(payload, time.Now()))
+	return pay, req
+}
+
+func TestPaymentWebhookRefundsCaptureOfFailedOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		wantStatus string
+		refunded   bool
+	}{
+		{"결제 요청 오류로 실패 처리된 주문", domain.OrderFailed, "refunded", true},
+		{"이미 확정된 주문 (중복 웹훅)", domain.OrderPaid, "success", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := payment.NewFakeProvider("test-secret", "")
+			pay, req := capturedPayment(t, pg, 10000)
+			repo := &testOrderRepo{
+				Repository: repository.NewMockRepository(),
+				order:      &domain.Order{OrderNumber: 1, UserNumber: 1, GymID: 1, ProductNumber: 1, Amount: 10000, Status: tt.status, PaymentID: pay.ID},
+			}
+			s := &Server{Repo: repo, Payments: pg}
+
+			rec := httptest.NewRecorder()
+			s.HandlePaymentWebhook(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("상태 코드 %d: %s", rec.Code, rec.Body.String())
+			}
+			var body map[string]string
+			json.NewDecoder(rec.Body).Decode(&body)
+			if body["status"] != tt.wantStatus {
+				t.Errorf("status = %q, want %q", body["status"], tt.wantStatus)
+			}
+
+			// 전액 환불된 결제는 다시 환불할 수 없다.
+			_, err := pg.Refund(context.Background(), pay.ID, 1)
+			if got := errors.Is(err, payment.ErrInvalidState); got != tt.refunded {
+				t.Errorf("환불 여부 = %v, want %v (err=%v)", got, tt.refunded, err)
+			}
+			if repo.order.Status != tt.status {
+				t.Errorf("주문 상태 = %s, want %s", repo.order.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestCheckoutVoidsAuthorizationWhenPaymentFails(t *testing.T) {
+	pg := payment.NewFakeProvider("test-secret", "")
+	repo := &testOrderRepo{Repository: repository.NewMockRepository(), paymentErr: errors.New("db down")}
+	s := &Server{Repo: repo, Payments: pg}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/checkout", strings.NewReader(`{"product_number": 1}`))
+	req = req.WithContext(context.WithValue(req.Context(), UserContextKey, &auth.Claims{UserNumber: 1, UserID: "member01", Role: auth.RoleUser}))
+	rec := httptest.NewRecorder()
+	s.HandleCheckout(rec, req)
+
+	if rec.Code != http.StatusPaymentRequired {
+		t.Fatalf("상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	if repo.order.Status != domain.OrderFailed {
+		t.Errorf("주문 상태 = %s, want %s", repo.order.Status, domain.OrderFailed)
+	}
+	// 취소된 승인은 더 이상 매입할 수 없다.
+	if _, err := pg.Capture(context.Background(), repo.order.PaymentID, repo.order.Amount); !errors.Is(err, payment.ErrInvalidState) {
+		t.Errorf("승인 취소 후 매입 err = %v, want ErrInvalidState", err)
+	}
+}
+
+func TestPaymentWebhookIssuesPassFromOrderSnapshot(t *testing.T) {
+	pg := payment.NewFakeProvider("test-secret", "")
+	pay, req := capturedPayment(t, pg, 90000)
+	// 주문 후 상품이 삭제된 경우 (Mock 저장소에 없는 상품 번호)
+	repo := &testOrderRepo{
+		Repository: repository.NewMockRepository(),
+		order: &domain.Order{OrderNumber: 1, UserNumber: 1, GymID: 1, ProductNumber: 999,
+			ProductName: "10회권", ProductType: "VISIT_PASS", VisitCount: 10, ValidityDays: 60,
+			ListPrice: 100000, Discount: 10000, Amount: 90000, CouponCode: "WELCOME10",
+			Status: domain.OrderPending, PaymentID: pay.ID},
+	}
+	s := &Server{Repo: repo, Payments: pg}
+
+	rec := httptest.NewRecorder()
+	s.HandlePaymentWebhook(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("상태 코드 %d: %s", rec.Code, rec.Body.String())
+	}
+	if repo.pass == nil {
+		t.Fatal("이용권이 발급되지 않음")
+	}
+	if repo.pass.RemainingVisits == nil || *repo.pass.RemainingVisits != 10 || repo.pass.ProductName != "10회권" {
+		t.Errorf("이용권 = %+v, want 주문 시점 10회권", repo.pass)
+	}
+	if days := repo.pass.ExpiresAt.Sub(repo.pass.StartsAt).Hours() / 24; days != 60 {
+		t.Errorf("유효 기간 %v일, want 60", days)
+	}
+	want := []domain.ReceiptLine{
+		{Description: "10회권", Quantity: 1, UnitPrice: 100000, Amount: 100000},
+		{Description: "쿠폰 할인 (WELCOME10)", Quantity: 1, UnitPrice: -10000, Amount: -10000},
+	}
+	if repo.sale.SalesType != "VISIT_PASS" || len(repo.sale.Items) != len(want) {
+		t.Fatalf("매출 = %+v", repo.sale)
+	}
+	for i, line := range want {
+		if repo.sale.Items[i] != line {
+			t.Errorf("영수증 %d번 항목 = %+v, want %+v", i, repo.sale.Items[i], line)
+		}
+	}
+}
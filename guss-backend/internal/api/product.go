@@ -0,0 +1,241 @@
+package api
+
+import (
+	"encoding/json"
+	"guss-backend/internal/domain"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HandleGetProducts: 지점의 판매 중인 이용권 상품 목록 (비로그인 조회 가능)
+func (s *Server) HandleGetProducts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	gymID, _ := strconv.ParseInt(r.URL.Query().Get("gym_id"), 10, 64)
+	if gymID <= 0 {
+		s.errorJSON(w, "체육관 ID가 유효하지 않습니다.", http.StatusBadRequest)
+		return
+	}
+
+	list, err := s.Repo.GetProducts(gymID, true)
+	if err != nil {
+		s.errorJSON(w, "상품 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// HandleAdminProducts: 관리자용 상품 목록(판매 중지 포함) 조회 및 등록
+func (s *Server) HandleAdminProducts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		gymID := scopedGymID(scope, queryGymID(r))
+		if gymID <= 0 {
+			s.errorJSON(w, "체육관 ID가 유효하지 않습니다.", http.StatusBadRequest)
+			return
+		}
+		list, err := s.Repo.GetProducts(gymID, false)
+		if err != nil {
+			s.errorJSON(w, "상품 조회 실패", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(list)
+
+	case http.MethodPost:
+		p := domain.Product{IsActive: true}
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+			return
+		}
+		p.GymID = scopedGymID(scope, p.GymID)
+		if msg := validateProduct(&p); msg != "" {
+			s.errorJSON(w, msg, http.StatusBadRequest)
+			return
+		}
+		if err := s.Repo.CreateProduct(&p); err != nil {
+			s.errorJSON(w, "상품 등록 실패", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[SUCCESS] 체육관 %d번 상품 등록: %s (%d원)", p.GymID, p.Name, p.Price)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":         "success",
+			"product_number": p.ProductNumber,
+		})
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleUpdateProduct: 상품 수정 (PUT /admin/products/{id}, 요청에 없는 필드는 기존 값 유지)
+func (s *Server) HandleUpdateProduct(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	id, _ := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+
+	p, err := s.Repo.GetProductByID(id)
+	if err != nil {
+		s.repoErrorJSON(w, err, "상품 조회 실패")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil || json.Unmarshal(body, p) != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+	p.ProductNumber = id
+
+	if msg := validateProduct(p); msg != "" {
+		s.errorJSON(w, msg, http.StatusBadRequest)
+		return
+	}
+	if err := s.Repo.UpdateProduct(scope, p); err != nil {
+		s.repoErrorJSON(w, err, "상품 수정 실패")
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// HandleIssuePass: 현장 판매 - 관리자가 회원에게 이용권을 발급하고 매출을 기록
+func (s *Server) HandleIssuePass(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		UserID        string `json:"user_id"`
+		ProductNumber int64  `json:"product_number"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+
+	product, err := s.Repo.GetProductByID(req.ProductNumber)
+	if err != nil {
+		s.repoErrorJSON(w, err, "상품 조회 실패")
+		return
+	}
+	if scope != 0 && product.GymID != scope {
+		s.errorJSON(w, "다른 지점의 상품은 판매할 수 없습니다.", http.StatusForbidden)
+		return
+	}
+	if !product.IsActive {
+		s.errorJSON(w, "판매가 중지된 상품입니다.", http.StatusBadRequest)
+		return
+	}
+
+	user, err := s.Repo.GetUserByID(req.UserID)
+	if err != nil {
+		s.errorJSON(w, "회원을 찾을 수 없습니다.", http.StatusNotFound)
+		return
+	}
+
+	now := time.Now()
+	pass := newPassFromProduct(user.UserNumber, product, now)
+	sale := &domain.Sale{GymID: product.GymID, SalesType: product.Type, SalesAmount: product.Price, SalesDate: now, UserNumber: user.UserNumber,
+		Items: []domain.ReceiptLine{{Description: product.Name, Quantity: 1, UnitPrice: product.Price, Amount: product.Price}}}
+	if err := s.Repo.IssuePass(pass, sale); err != nil {
+		s.errorJSON(w, "이용권 발급 실패", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("[SUCCESS] 유저 %s -> 이용권 %s 발급 (Pass: %d)", user.UserID, product.Name, pass.PassNumber)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"pass":   pass,
+	})
+}
+
+// HandleGetMyPasses: 로그인한 회원의 보유 이용권 목록
+func (s *Server) HandleGetMyPasses(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := s.Repo.GetPassesByUser(claims.UserNumber)
+	if err != nil {
+		s.errorJSON(w, "이용권 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// newPassFromProduct: 상품 정의대로 지금부터 유효한 이용권 생성
+func newPassFromProduct(userNum int64, p *domain.Product, now time.Time) *domain.Pass {
+	pass := &domain.Pass{
+		UserNumber:    userNum,
+		ProductNumber: p.ProductNumber,
+		GymID:         p.GymID,
+		StartsAt:      now,
+		ExpiresAt:     now.AddDate(0, 0, p.ValidityDays),
+		Status:        domain.PassActive,
+		ProductName:   p.Name,
+		ProductType:   p.Type,
+	}
+	if p.VisitCount > 0 {
+		visits := p.VisitCount
+		pass.RemainingVisits = &visits
+	}
+	return pass
+}
+
+// validateProduct: 상품 유형별 필수값 확인 및 보정 (문제 없으면 빈 문자열)
+func validateProduct(p *domain.Product) string {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.GymID <= 0 {
+		return "체육관 ID가 유효하지 않습니다."
+	}
+	if p.Name == "" {
+		return "상품명을 입력해 주세요."
+	}
+	if p.Price < 0 {
+		return "가격은 0 이상이어야 합니다."
+	}
+	if p.ValidityDays <= 0 {
+		return "유효 기간은 1일 이상이어야 합니다."
+	}
+
+	switch p.Type {
+	case domain.ProductDayPass:
+		p.VisitCount = 1
+	case domain.ProductVisitPass:
+		if p.VisitCount <= 0 {
+			return "횟수권은 이용 횟수가 1회 이상이어야 합니다."
+		}
+	case domain.ProductMembership:
+		p.VisitCount = 0 // 기간 내 무제한
+	default:
+		return "상품 유형은 DAY_PASS, VISIT_PASS, MEMBERSHIP 중 하나여야 합니다."
+	}
+	return ""
+}
@@ -0,0 +1,354 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/notify"
+	"guss-backend/internal/repository"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// currentMember: 토큰의 회원을 DB에서 다시 읽음 (탈퇴 후 남은 액세스 토큰이면 401)
+func (s *Server) currentMember(w http.ResponseWriter, claims *auth.Claims) (*domain.User, bool) {
+	user, err := s.Repo.GetUserByNumber(claims.UserNumber)
+	if errors.Is(err, repository.ErrNotFound) {
+		s.errorJSON(w, "계정 정보를 찾을 수 없습니다. 다시 로그인해 주세요.", http.StatusUnauthorized)
+		return nil, false
+	} else if err != nil {
+		s.errorJSON(w, "회원 정보 조회 실패", http.StatusInternalServerError)
+		return nil, false
+	}
+	return user, true
+}
+
+// checkCurrentPassword: 비밀번호 변경/탈퇴 전 본인 확인 (로그인과 같은 실패 집계를 써서 탈취된 토큰으로 대입하지 못하게 함)
+func (s *Server) checkCurrentPassword(w http.ResponseWriter, r *http.Request, user *domain.User, password string) bool {
+	ip := s.clientIP(r)
+	accountKey, ipKey := auth.AccountAttemptKey(domain.PrincipalUser, user.UserID), auth.IPAttemptKey(ip)
+	if !s.checkLoginThrottle(w, accountKey, ipKey) {
+		return false
+	}
+	if !auth.CheckPasswordHash(password, user.UserPW) {
+		s.recordLoginFailure(user.UserID, accountKey, ipKey, ip)
+		s.errorCodeJSON(w, "현재 비밀번호가 일치하지 않습니다.", "CURRENT_PASSWORD_INVALID", http.StatusBadRequest)
+		return false
+	}
+	s.releaseLoginAttempt(accountKey, ipKey)
+	s.recordLoginSuccess(accountKey)
+	return true
+}
+
+// HandleMe: 내 정보 조회/수정/탈퇴 (GET, PATCH, DELETE /api/me)
+func (s *Server) HandleMe(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+	user, ok := s.currentMember(w, claims)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp := newUserResponse(user)
+		if pc, err := s.Repo.GetActivePhoneChange(user.UserNumber); err == nil && time.Now().Before(pc.ExpiresAt) {
+			resp.PendingPhone = pc.NewPhone
+		}
+		json.NewEncoder(w).Encode(resp)
+	case http.MethodPatch:
+		s.updateMe(w, r, user)
+	case http.MethodDelete:
+		s.deleteMe(w, r, user)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// updateMe: 이름은 바로 반영하고, 휴대폰 번호는 새 번호로 보낸 코드를 /api/me/phone/verify에서 확인해야 바뀐다.
+func (s *Server) updateMe(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	var req struct {
+		UserName  *string `json:"user_name"`
+		UserPhone *string `json:"user_phone"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+
+	fields := map[string]string{}
+	var newName, newPhone string
+	if req.UserName != nil {
+		newName = strings.TrimSpace(*req.UserName)
+		if msg := validateUserName(newName); msg != "" {
+			fields["user_name"] = msg
+		} else if newName == user.UserName {
+			newName = ""
+		}
+	}
+	if req.UserPhone != nil {
+		newPhone = normalizePhone(*req.UserPhone)
+		if msg := validateUserPhone(newPhone); msg != "" {
+			fields["user_phone"] = msg
+		} else if newPhone == user.UserPhone {
+			newPhone = ""
+		}
+	}
+	if len(fields) > 0 {
+		s.fieldErrorsJSON(w, "입력값을 확인해 주세요.", "VALIDATION_FAILED", fields, http.StatusBadRequest)
+		return
+	}
+
+	// 번호 변경은 발송 조건을 모두 확인한 뒤에 이름과 함께 반영한다 (일부만 바뀐 채 실패하지 않도록).
+	if newPhone != "" {
+		if s.Notifier == nil {
+			s.errorJSON(w, "인증 문자 발송이 비활성화되어 번호를 변경할 수 없습니다.", http.StatusServiceUnavailable)
+			return
+		}
+		_, phoneTaken, err := s.Repo.CheckUserDuplicate("", newPhone)
+		if err != nil {
+			s.errorJSON(w, "회원 정보 수정 중 오류 발생", http.StatusInternalServerError)
+			return
+		}
+		if phoneTaken {
+			s.fieldErrorsJSON(w, "이미 가입된 정보가 있습니다.", "DUPLICATE_ACCOUNT",
+				map[string]string{"user_phone": repository.ErrDuplicatePhone.Error()}, http.StatusConflict)
+			return
+		}
+		if prev, err := s.Repo.GetActivePhoneChange(user.UserNumber); err == nil && time.Since(prev.CreatedAt) < codeResendInterval {
+			wait := int((codeResendInterval - time.Since(prev.CreatedAt)).Seconds()) + 1
+			w.Header().Set("Retry-After", strconv.Itoa(wait))
+			s.errorCodeJSON(w, "인증 코드를 방금 보냈습니다. 잠시 후 다시 요청해 주세요.", "CODE_RESEND_TOO_SOON", http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	if newName != "" {
+		if err := s.Repo.UpdateUserName(user.UserNumber, newName); err != nil {
+			s.errorJSON(w, "회원 정보 수정 실패", http.StatusInternalServerError)
+			return
+		}
+		user.UserName = newName
+		s.audit(user.UserID, "PROFILE_UPDATED")
+	}
+
+	if newPhone == "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "user": newUserResponse(user)})
+		return
+	}
+
+	code, codeHash, err := auth.NewVerificationCode()
+	if err != nil {
+		s.errorJSON(w, "인증 코드 생성 실패", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	change := &domain.PhoneChange{UserNumber: user.UserNumber, NewPhone: newPhone, CodeHash: codeHash,
+		ExpiresAt: now.Add(auth.VerificationCodeTTL), CreatedAt: now}
+	if err := s.Repo.CreatePhoneChange(change); err != nil {
+		s.errorJSON(w, "회원 정보 수정 중 오류 발생", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	err = s.Notifier.Send(ctx, notify.Message{
+		Channel: notify.ChannelSMS,
+		To:      newPhone,
+		Body:    "[GUSS] 휴대폰 번호 변경 인증번호는 " + code + " 입니다. 10분 안에 입력해 주세요.",
+	})
+	if err != nil {
+		log.Printf("[NOTIFY ERROR] %s 번호 변경 코드 발송 실패: %v", user.UserID, err)
+		s.errorJSON(w, "인증 문자 발송에 실패했습니다. 잠시 후 다시 시도해 주세요.", http.StatusBadGateway)
+		return
+	}
+
+	resp := newUserResponse(user)
+	resp.PendingPhone = newPhone
+	log.Printf("[PROFILE] %s 번호 변경 대기 (인증 코드 발송)", user.UserID)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":     "verification_required",
+		"user":       resp,
+		"expires_in": int(auth.VerificationCodeTTL.Seconds()),
+	})
+}
+
+// deleteMe: 회원 탈퇴 - 비밀번호 확인 후 개인정보를 익명화한다 (예약/매출 이력은 통계용으로 유지)
+func (s *Server) deleteMe(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	var req struct {
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password == "" {
+		s.errorJSON(w, "탈퇴하려면 비밀번호(password)를 입력해 주세요.", http.StatusBadRequest)
+		return
+	}
+	if !s.checkCurrentPassword(w, r, user, req.Password) {
+		return
+	}
+
+	if err := s.Repo.AnonymizeUser(user.UserNumber); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAccountInUse):
+			s.errorCodeJSON(w, err.Error(), "ACCOUNT_IN_USE", http.StatusConflict)
+		case errors.Is(err, repository.ErrNotFound):
+			s.errorJSON(w, "계정 정보를 찾을 수 없습니다.", http.StatusNotFound)
+		default:
+			s.errorJSON(w, "회원 탈퇴 처리 실패", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 활동 로그는 원래 아이디로 쌓여 있으므로 함께 지우고, 탈퇴 기록은 익명화된 아이디로 남긴다.
+	if s.LogRepo != nil {
+		if _, err := s.LogRepo.DeleteUserLogs(user.UserID); err != nil {
+			// 남은 로그는 보존 기간 정리에서 지워진다.
+			log.Printf("[PRIVACY ERROR] %s 활동 로그 삭제 실패: %v", user.UserID, err)
+		}
+	}
+	s.audit(domain.DeletedUserID(user.UserNumber), "ACCOUNT_DELETED")
+	log.Printf("[PROFILE] 회원 탈퇴: No %d (개인정보 익명화, 세션/활동 로그 삭제)", user.UserNumber)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// HandleVerifyPhoneChange: 새 번호로 받은 코드 확인 후 번호 변경 (POST /api/me/phone/verify)
+func (s *Server) HandleVerifyPhoneChange(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+	user, ok := s.currentMember(w, claims)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		s.errorJSON(w, "code가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+
+	const invalidCode = "인증 코드가 올바르지 않거나 만료되었습니다. 다시 요청해 주세요."
+	change, err := s.Repo.GetActivePhoneChange(user.UserNumber)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && time.Now().After(change.ExpiresAt)) {
+		s.errorCodeJSON(w, invalidCode, "PHONE_CODE_INVALID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.errorJSON(w, "인증 코드 확인 실패", http.StatusInternalServerError)
+		return
+	}
+	// 비교 전에 시도 횟수부터 차감 (동시 요청으로 한도를 넘겨 코드를 대입하지 못하도록)
+	if err := s.Repo.UsePhoneChangeAttempt(change.ChangeNumber, auth.VerificationCodeMaxAttempts); errors.Is(err, repository.ErrNotFound) {
+		s.errorCodeJSON(w, invalidCode, "PHONE_CODE_INVALID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.errorJSON(w, "인증 코드 확인 실패", http.StatusInternalServerError)
+		return
+	}
+	if !auth.CheckVerificationCode(req.Code, change.CodeHash) {
+		s.errorCodeJSON(w, invalidCode, "PHONE_CODE_INVALID", http.StatusBadRequest)
+		return
+	}
+
+	phone, err := s.Repo.CompletePhoneChange(change.ChangeNumber, user.UserNumber)
+	switch {
+	case errors.Is(err, repository.ErrDuplicatePhone):
+		s.fieldErrorsJSON(w, "이미 가입된 정보가 있습니다.", "DUPLICATE_ACCOUNT", map[string]string{"user_phone": err.Error()}, http.StatusConflict)
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		s.errorCodeJSON(w, invalidCode, "PHONE_CODE_INVALID", http.StatusBadRequest)
+		return
+	case err != nil:
+		s.errorJSON(w, "휴대폰 번호 변경 실패", http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	user.UserPhone, user.PhoneVerifiedAt = phone, &now
+	s.audit(user.UserID, "PHONE_CHANGED")
+	log.Printf("[PROFILE] %s 휴대폰 번호 변경 완료", user.UserID)
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "user": newUserResponse(user)})
+}
+
+// HandleChangePassword: 현재 비밀번호 확인 후 변경 (POST /api/me/password)
+// 다른 기기의 세션은 모두 폐기하고, 요청한 기기에는 새 토큰을 발급해 로그인 상태를 유지한다.
+func (s *Server) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+	user, ok := s.currentMember(w, claims)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.CurrentPassword == "" {
+		s.errorJSON(w, "current_password와 new_password가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+	if !s.checkCurrentPassword(w, r, user, req.CurrentPassword) {
+		return
+	}
+	if err := auth.ValidatePassword(req.NewPassword, user.UserID); err != nil {
+		s.errorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.NewPassword == req.CurrentPassword {
+		s.errorJSON(w, "현재 비밀번호와 다른 비밀번호를 입력해 주세요.", http.StatusBadRequest)
+		return
+	}
+
+	hashedPW, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		s.errorJSON(w, "비밀번호 처리 중 오류 발생", http.StatusInternalServerError)
+		return
+	}
+	if err := s.Repo.ChangePassword(user.UserNumber, user.UserPW, hashedPW); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			s.errorJSON(w, "비밀번호가 이미 변경되었습니다. 다시 시도해 주세요.", http.StatusConflict)
+			return
+		}
+		s.errorJSON(w, "비밀번호 변경 실패", http.StatusInternalServerError)
+		return
+	}
+	s.audit(user.UserID, "PASSWORD_CHANGED")
+	log.Printf("[PROFILE] %s 비밀번호 변경, 기존 세션 폐기", user.UserID)
+
+	p, err := s.loadPrincipal(domain.PrincipalUser, user.UserID)
+	if err == nil {
+		var session *sessionResponse
+		if session, err = s.issueSession(p); err == nil {
+			json.NewEncoder(w).Encode(session)
+			return
+		}
+	}
+	// 비밀번호는 이미 바뀌었으므로 성공으로 응답하고 다시 로그인하도록 안내
+	log.Printf("[PROFILE ERROR] %s 새 세션 발급 실패: %v", user.UserID, err)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success", "message": "비밀번호가 변경되었습니다. 다시 로그인해 주세요."})
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/domain"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HandleGetMyReceipts: 로그인한 회원의 영수증 목록
+func (s *Server) HandleGetMyReceipts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := s.Repo.GetReceiptsByUser(claims.UserNumber)
+	if err != nil {
+		s.errorJSON(w, "영수증 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// HandleGetMyReceipt: 영수증 상세 (GET /api/me/receipts/{id})
+// 기본은 JSON, ?format=html 또는 Accept: text/html 이면 인쇄용 HTML (브라우저 인쇄로 PDF 저장)
+func (s *Server) HandleGetMyReceipt(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	id, _ := strconv.ParseInt(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/me/receipts/"), "/"), 10, 64)
+	rc, err := s.Repo.GetReceiptByID(id)
+	if err != nil || rc.UserNumber != claims.UserNumber {
+		s.errorJSON(w, "영수증을 찾을 수 없습니다.", http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "html" || strings.Contains(r.Header.Get("Accept"), "text/html") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := receiptTemplate.Execute(w, rc); err != nil {
+			log.Printf("[ERROR] 영수증 %d HTML 렌더링 실패: %v", rc.ReceiptNumber, err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rc)
+}
+
+// orderReceiptLines: 주문 결제 매출의 영수증 항목 (주문 시점 상품 정가 + 쿠폰 할인)
+func (s *Server) orderReceiptLines(product *domain.Product, order *domain.Order) []domain.ReceiptLine {
+	listPrice := order.ListPrice
+	if listPrice == 0 {
+		listPrice = order.Amount + order.Discount
+	}
+	lines := []domain.ReceiptLine{{Description: product.Name, Quantity: 1, UnitPrice: listPrice, Amount: listPrice}}
+
+	if order.Discount > 0 {
+		desc := "쿠폰 할인"
+		if order.CouponCode != "" {
+			desc = fmt.Sprintf("쿠폰 할인 (%s)", order.CouponCode)
+		} else if c, err := s.Repo.GetCouponByID(order.CouponNumber); err == nil {
+			desc = fmt.Sprintf("쿠폰 할인 (%s)", c.Code)
+		}
+		lines = append(lines, domain.ReceiptLine{Description: desc, Quantity: 1, UnitPrice: -order.Discount, Amount: -order.Discount})
+	}
+	return lines
+}
+
+// formatWon: 금액을 천 단위 구분 기호가 있는 원 표기로 (음수 지원)
+func formatWon(v int64) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	s := strconv.FormatInt(v, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s + "원"
+}
+
+var receiptTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"won": formatWon,
+	"kst": func(rc *domain.Receipt) string { return rc.IssuedAt.In(algo.KST).Format("2006-01-02 15:04") },
+}).Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head>
+<meta charset="utf-8">
+<title>영수증 {{.ReceiptNo}}</title>
+<style>
+  body { font-family: "Malgun Gothic", "Apple SD Gothic Neo", sans-serif; max-width: 420px; margin: 24px auto; color: #222; }
+  h1 { font-size: 20px; text-align: center; margin-bottom: 4px; }
+  .meta, .gym { font-size: 13px; color: #555; }
+  table { width: 100%; border-collapse: collapse; margin-top: 16px; font-size: 14px; }
+  th, td { padding: 6px 4px; border-bottom: 1px solid #ddd; }
+  td.num, th.num { text-align: right; }
+  tfoot td { border-bottom: none; }
+  .total td { font-weight: bold; border-top: 2px solid #222; }
+  @media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>{{if lt .Total 0}}환불 영수증{{else}}영수증{{end}}</h1>
+<div class="gym">
+  <div><strong>{{.GymName}}</strong></div>
+  <div>{{.GymAddress}}</div>
+  <div>{{.GymPhone}}</div>
+</div>
+<div class="meta">
+  <div>영수증 번호: {{.ReceiptNo}}</div>
+  <div>발행 일시: {{kst .}}</div>
+  {{if .OrderNumber}}<div>주문 번호: {{.OrderNumber}}</div>{{end}}
+</div>
+<table>
+  <thead><tr><th>항목</th><th class="num">수량</th><th class="num">단가</th><th class="num">금액</th></tr></thead>
+  <tbody>
+  {{range .Lines}}<tr><td>{{.Description}}</td><td class="num">{{.Quantity}}</td><td class="num">{{won .UnitPrice}}</td><td class="num">{{won .Amount}}</td></tr>
+  {{end}}
+  </tbody>
+  <tfoot>
+    <tr><td colspan="3">공급가액</td><td class="num">{{won .Supply}}</td></tr>
+    <tr><td colspan="3">부가세 (10%)</td><td class="num">{{won .VAT}}</td></tr>
+    <tr class="total"><td colspan="3">합계</td><td class="num">{{won .Total}}</td></tr>
+  </tfoot>
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,441 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/repository"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HandleMyOrder: /api/me/orders/{id}/refund 처리
+// GET은 현재 시점 환불 예상 금액 조회, POST는 환불 요청 (승인 기준 이하면 즉시 환불)
+func (s *Server) HandleMyOrder(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := s.memberClaims(w, r)
+	if !ok {
+		return
+	}
+
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/me/orders/"), "/")
+	idStr, action, _ := strings.Cut(rest, "/")
+	orderNum, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || action != "refund" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	order, err := s.Repo.GetOrderByID(orderNum)
+	if err != nil || order.UserNumber != claims.UserNumber {
+		s.errorJSON(w, "주문을 찾을 수 없습니다.", http.StatusNotFound)
+		return
+	}
+	if order.Status != domain.OrderPaid {
+		s.errorJSON(w, "결제 완료된 주문만 환불할 수 있습니다.", http.StatusBadRequest)
+		return
+	}
+
+	pass, quote, err := s.quoteRefund(order)
+	if err != nil {
+		s.repoErrorJSON(w, err, "환불 금액 계산 실패")
+		return
+	}
+	// 양도한 이용권은 현재 소유자가 사용 중이므로 원 구매자도 환불할 수 없다.
+	if pass.UserNumber != claims.UserNumber {
+		s.errorJSON(w, "양도한 이용권은 환불할 수 없습니다.", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"order":          order,
+			"quote":          quote,
+			"needs_approval": s.RefundPolicy.NeedsApproval(quote.Amount),
+		})
+	case http.MethodPost:
+		s.requestRefund(w, r, claims, order, quote)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) requestRefund(w http.ResponseWriter, r *http.Request, claims *auth.Claims, order *domain.Order, quote algo.RefundQuote) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	json.NewDecoder(r.Body).Decode(&req) // 사유는 선택 입력
+
+	if quote.Amount <= 0 {
+		s.errorJSON(w, quote.Reason, http.StatusBadRequest)
+		return
+	}
+
+	rf := &domain.Refund{
+		OrderNumber: order.OrderNumber,
+		UserNumber:  order.UserNumber,
+		GymID:       order.GymID,
+		PaidAmount:  order.Amount,
+		Amount:      quote.Amount,
+		Reason:      strings.TrimSpace(req.Reason),
+	}
+	if err := s.Repo.CreateRefund(rf); err != nil {
+		s.repoErrorJSON(w, err, "환불 요청 실패")
+		return
+	}
+	s.audit(claims.UserID, fmt.Sprintf("REFUND_REQUEST refund=%d order=%d amount=%d", rf.RefundNumber, order.OrderNumber, rf.Amount))
+
+	if s.RefundPolicy.NeedsApproval(rf.Amount) {
+		log.Printf("[REFUND] 환불 %d번 관리자 승인 대기 (주문 %d, %d원)", rf.RefundNumber, order.OrderNumber, rf.Amount)
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"refund": rf,
+			"quote":  quote,
+		})
+		return
+	}
+
+	if err := s.processRefund(r.Context(), rf, order, rf.Amount, ""); err != nil {
+		s.refundErrorJSON(w, err)
+		return
+	}
+
+	log.Printf("[SUCCESS] 주문 %d 자동 환불 완료 (%d원)", order.OrderNumber, rf.Amount)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"refund": rf,
+		"quote":  quote,
+	})
+}
+
+// HandleAdminRefunds: 관리자용 환불 목록 (?status=REQUESTED 등으로 필터)
+func (s *Server) HandleAdminRefunds(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := s.Repo.GetRefunds(scope, strings.ToUpper(r.URL.Query().Get("status")))
+	if err != nil {
+		s.errorJSON(w, "환불 목록 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// HandleAdminRefundAction: /admin/refunds/{id}/approve, /admin/refunds/{id}/reject
+func (s *Server) HandleAdminRefundAction(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+	claims := r.Context().Value(UserContextKey).(*auth.Claims)
+
+	idStr, action, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/refunds/"), "/"), "/")
+	refundNum, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || (action != "approve" && action != "reject") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	rf, err := s.Repo.GetRefundByID(refundNum)
+	if err != nil {
+		s.repoErrorJSON(w, err, "환불 조회 실패")
+		return
+	}
+	if scope != 0 && rf.GymID != scope {
+		s.errorJSON(w, repository.ErrGymScopeDenied.Error(), http.StatusForbidden)
+		return
+	}
+	// 결제사 환불 후 기록이 실패한 환불(PROVIDER_DONE)은 재승인으로 기록만 마무리할 수 있다.
+	retry := rf.Status == domain.RefundProviderDone && action == "approve"
+	if rf.Status != domain.RefundRequested && !retry {
+		s.errorJSON(w, repository.ErrRefundNotPending.Error(), http.StatusConflict)
+		return
+	}
+
+	switch action {
+	case "approve":
+		order, err := s.Repo.GetOrderByID(rf.OrderNumber)
+		if err != nil {
+			s.repoErrorJSON(w, err, "주문 조회 실패")
+			return
+		}
+		amount := rf.Amount // 재시도는 이미 결제사에서 환불된 금액 그대로 기록
+		if !retry {
+			// 승인 대기 중에도 이용권을 쓸 수 있으므로 승인 시점 기준으로 다시 계산한다.
+			_, quote, err := s.quoteRefund(order)
+			if err != nil {
+				s.repoErrorJSON(w, err, "환불 금액 계산 실패")
+				return
+			}
+			if quote.Amount <= 0 {
+				s.errorJSON(w, quote.Reason, http.StatusConflict)
+				return
+			}
+			amount = quote.Amount
+		}
+
+		if err := s.processRefund(r.Context(), rf, order, amount, claims.UserID); err != nil {
+			s.refundErrorJSON(w, err)
+			return
+		}
+		s.audit(claims.UserID, fmt.Sprintf("REFUND_APPROVE refund=%d order=%d amount=%d", rf.RefundNumber, rf.OrderNumber, rf.Amount))
+		log.Printf("[SUCCESS] 환불 %d번 승인 완료 (%d원, 관리자 %s)", rf.RefundNumber, rf.Amount, claims.UserID)
+
+	case "reject":
+		var req struct {
+			Note string `json:"note"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if err := s.Repo.RejectRefund(rf.RefundNumber, claims.UserID, strings.TrimSpace(req.Note)); err != nil {
+			s.repoErrorJSON(w, err, "환불 거절 실패")
+			return
+		}
+		rf.Status = domain.RefundRejected
+		rf.AdminNote = strings.TrimSpace(req.Note)
+		s.audit(claims.UserID, fmt.Sprintf("REFUND_REJECT refund=%d order=%d", rf.RefundNumber, rf.OrderNumber))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"refund": rf,
+	})
+}
+
+// HandleAdminPassRefund: /admin/passes/{id}/refund - 현장 발급 이용권 환불 (GET 예상 금액, POST 환불 처리)
+// 현장 결제는 결제사를 거치지 않으므로 금액은 카운터에서 돌려주고, 여기서는 정책 금액 계산과 음수 매출/이용권 취소만 기록한다.
+// 온라인 주문으로 구매한 이용권은 회원의 주문 환불(/api/me/orders/{id}/refund)로만 처리한다.
+func (s *Server) HandleAdminPassRefund(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+	claims := r.Context().Value(UserContextKey).(*auth.Claims)
+
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/passes/"), "/")
+	idStr, action, _ := strings.Cut(rest, "/")
+	passNum, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || action != "refund" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pass, err := s.Repo.GetPassByID(passNum)
+	if err != nil {
+		s.repoErrorJSON(w, err, "이용권 조회 실패")
+		return
+	}
+	if scope != 0 && pass.GymID != scope {
+		s.errorJSON(w, repository.ErrGymScopeDenied.Error(), http.StatusForbidden)
+		return
+	}
+	product, err := s.Repo.GetProductByID(pass.ProductNumber)
+	if err != nil {
+		s.repoErrorJSON(w, err, "상품 조회 실패")
+		return
+	}
+	visits, noShows, err := s.Repo.GetPassUsage(pass.PassNumber)
+	if err != nil {
+		s.errorJSON(w, "환불 금액 계산 실패", http.StatusInternalServerError)
+		return
+	}
+	// 현장 발급은 발급 시점의 상품 가격을 받고 발급 즉시 이용이 시작되므로, 그 금액과 시작 시각을 결제 정보로 본다.
+	usage := algo.RefundUsage{Visits: visits, NoShows: noShows}
+	quote := s.RefundPolicy.Quote(product.Price, pass.StartsAt, product, pass, usage, time.Now())
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"pass":  pass,
+			"quote": quote,
+		})
+		return
+	case http.MethodPost:
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if quote.Amount <= 0 {
+		s.errorJSON(w, quote.Reason, http.StatusBadRequest)
+		return
+	}
+	sale := &domain.Sale{
+		GymID:       pass.GymID,
+		SalesType:   domain.SalesTypeRefund,
+		SalesAmount: -quote.Amount,
+		SalesDate:   time.Now(),
+		UserNumber:  pass.UserNumber,
+		Items:       []domain.ReceiptLine{{Description: "환불: " + product.Name, Quantity: 1, UnitPrice: -quote.Amount, Amount: -quote.Amount}},
+	}
+	if err := s.Repo.RefundCounterPass(pass.PassNumber, sale); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPassHasOrder), errors.Is(err, repository.ErrRefundNotPending):
+			s.errorJSON(w, err.Error(), http.StatusConflict)
+		default:
+			s.repoErrorJSON(w, err, "환불 처리 실패")
+		}
+		return
+	}
+
+	s.audit(claims.UserID, fmt.Sprintf("REFUND_COUNTER pass=%d user=%d amount=%d", pass.PassNumber, pass.UserNumber, quote.Amount))
+	log.Printf("[SUCCESS] 현장 이용권 %d번 환불 완료 (%d원, 관리자 %s)", pass.PassNumber, quote.Amount, claims.UserID)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"quote":  quote,
+		"sale":   sale,
+	})
+}
+
+// HandleMarkNoShow: /admin/reservations/{id}/no-show - 예약 미방문 처리 (해당 이용권은 환불 불가)
+func (s *Server) HandleMarkNoShow(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+	claims := r.Context().Value(UserContextKey).(*auth.Claims)
+
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/reservations/"), "/")
+	idStr, action, _ := strings.Cut(rest, "/")
+	revsNum, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || action != "no-show" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err := s.Repo.MarkNoShow(scope, revsNum); err != nil {
+		s.repoErrorJSON(w, err, "미방문 처리 실패")
+		return
+	}
+
+	s.audit(claims.UserID, fmt.Sprintf("RESERVATION_NO_SHOW revs=%d", revsNum))
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// quoteRefund: 주문으로 발급된 이용권의 사용 이력을 모아 환불 정책 적용
+func (s *Server) quoteRefund(order *domain.Order) (*domain.Pass, algo.RefundQuote, error) {
+	pass, err := s.Repo.GetPassByID(order.PassNumber)
+	if err != nil {
+		return nil, algo.RefundQuote{}, err
+	}
+	product, err := s.orderProduct(order)
+	if err != nil {
+		return nil, algo.RefundQuote{}, err
+	}
+	visits, noShows, err := s.Repo.GetPassUsage(pass.PassNumber)
+	if err != nil {
+		return nil, algo.RefundQuote{}, err
+	}
+
+	paidAt := order.CreatedAt
+	if order.PaidAt != nil {
+		paidAt = *order.PaidAt
+	}
+	usage := algo.RefundUsage{Visits: visits, NoShows: noShows}
+	return pass, s.RefundPolicy.Quote(order.Amount, paidAt, product, pass, usage, time.Now()), nil
+}
+
+// processRefund: 환불 선점 -> 결제사 환불 -> 결제사 환불 완료 표시 -> 음수 매출/주문/이용권 반영
+// 결제사 환불이 실패하면 REQUESTED로 되돌려 관리자가 다시 승인할 수 있게 한다.
+// 이미 결제사 환불을 마친 환불(PROVIDER_DONE)은 결제사를 다시 부르지 않고 장부 기록만 다시 시도한다.
+func (s *Server) processRefund(ctx context.Context, rf *domain.Refund, order *domain.Order, amount int64, adminID string) error {
+	if rf.Status != domain.RefundProviderDone {
+		if s.Payments == nil {
+			return errPaymentsDisabled
+		}
+		if err := s.Repo.ClaimRefund(rf.RefundNumber, amount); err != nil {
+			return err
+		}
+		rf.Amount = amount
+		rf.Status = domain.RefundProcessing
+
+		if _, err := s.Payments.Refund(ctx, order.PaymentID, amount); err != nil {
+			log.Printf("[REFUND] 환불 %d번 결제사 환불 실패: %v", rf.RefundNumber, err)
+			s.Repo.ReleaseRefund(rf.RefundNumber)
+			rf.Status = domain.RefundRequested
+			return fmt.Errorf("%w: %v", errProviderRefund, err)
+		}
+		if err := s.Repo.MarkRefundProviderDone(rf.RefundNumber); err != nil {
+			// PROCESSING으로 남으므로 자동 재시도 대상이 아니다.
+			log.Printf("[REFUND ERROR] 환불 %d번 결제사 환불 완료 표시 실패 (수동 확인 필요, %d원): %v", rf.RefundNumber, amount, err)
+			return err
+		}
+		rf.Status = domain.RefundProviderDone
+	}
+
+	rf.ProcessedBy = adminID
+	desc := fmt.Sprintf("주문 %d번 환불", order.OrderNumber)
+	if product, err := s.orderProduct(order); err == nil {
+		desc = "환불: " + product.Name
+	}
+	sale := &domain.Sale{
+		GymID:       order.GymID,
+		SalesType:   domain.SalesTypeRefund,
+		SalesAmount: -amount,
+		SalesDate:   time.Now(),
+		UserNumber:  order.UserNumber,
+		OrderNumber: order.OrderNumber,
+		Items:       []domain.ReceiptLine{{Description: desc, Quantity: 1, UnitPrice: -amount, Amount: -amount}},
+	}
+	if err := s.Repo.CompleteRefund(rf, order.PassNumber, sale); err != nil {
+		// PROVIDER_DONE으로 남아 있으므로 관리자가 다시 승인하면 기록만 마무리된다.
+		log.Printf("[REFUND ERROR] 환불 %d번 결제사 환불 완료 후 기록 실패 (재승인 필요): %v", rf.RefundNumber, err)
+		return err
+	}
+	return nil
+}
+
+var (
+	errPaymentsDisabled = errors.New("결제 기능이 설정되지 않았습니다")
+	errProviderRefund   = errors.New("결제사 환불 처리에 실패했습니다")
+)
+
+func (s *Server) refundErrorJSON(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errPaymentsDisabled):
+		s.errorJSON(w, err.Error(), http.StatusServiceUnavailable)
+	case errors.Is(err, errProviderRefund):
+		s.errorJSON(w, errProviderRefund.Error(), http.StatusBadGateway)
+	default:
+		s.repoErrorJSON(w, err, "환불 처리 실패")
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/payment"
+	"guss-backend/internal/repository"
+)
+
+// testRefundRepo: 환불 상태 전이를 흉내 내고, completeErr가 있으면 장부 기록을 한 번 실패시키는 저장소
+type testRefundRepo struct {
+	repository.Repository
+	status      string
+	completeErr error
+	sales       []*domain.Sale
+}
+
+func (r *testRefundRepo) ClaimRefund(refundNum, amount int64) error {
+	if r.status != domain.RefundRequested {
+		return repository.ErrRefundNotPending
+	}
+	r.status = domain.RefundProcessing
+	return nil
+}
+
+func (r *testRefundRepo) MarkRefundProviderDone(refundNum int64) error {
+	if r.status != domain.RefundProcessing {
+		return repository.ErrRefundNotPending
+	}
+	r.status = domain.RefundProviderDone
+	return nil
+}
+
+func (r *testRefundRepo) CompleteRefund(rf *domain.Refund, passNum int64, sale *domain.Sale) error {
+	if err := r.completeErr; err != nil {
+		r.completeErr = nil
+		return err
+	}
+	if r.status != domain.RefundProcessing && r.status != domain.RefundProviderDone {
+		return repository.ErrRefundNotPending
+	}
+	r.status = domain.RefundCompleted
+	r.sales = append(r.sales, sale)
+	rf.Status = domain.RefundCompleted
+	return nil
+}
+
+func TestProcessRefundRetryDoesNotRefundTwice(t *testing.T) {
+	ctx := context.Background()
+	pg := payment.NewFakeProvider("test-secret", "")
+	pay, err := pg.Authorize(ctx, payment.AuthorizeRequest{OrderID: "1", Amount: 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pg.Capture(ctx, pay.ID, 10000); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &testRefundRepo{Repository: repository.NewMockRepository(), status: domain.RefundRequested, completeErr: errors.New("db down")}
+	s := &Server{Repo: repo, Payments: pg}
+	order := &domain.Order{OrderNumber: 1, UserNumber: 1, GymID: 1, ProductNumber: 1, ProductName: "1일권", Amount: 10000, PaymentID: pay.ID, PassNumber: 1}
+	rf := &domain.Refund{RefundNumber: 1, OrderNumber: 1, Amount: 10000, Status: domain.RefundRequested}
+
+	if err := s.processRefund(ctx, rf, order, 10000, "manager01"); err == nil {
+		t.Fatal("장부 기록 실패가 전달되지 않음")
+	}
+	if rf.Status != domain.RefundProviderDone || repo.status != domain.RefundProviderDone {
+		t.Fatalf("기록 실패 후 상태 = %s/%s, want %s", rf.Status, repo.status, domain.RefundProviderDone)
+	}
+
+	// 재시도는 결제사 환불 없이 기록만 마무리한다 (다시 환불을 요청했다면 전액 환불된 결제라 실패한다).
+	if err := s.processRefund(ctx, rf, order, 10000, "manager01"); err != nil {
+		t.Fatalf("재시도 실패: %v", err)
+	}
+	if rf.Status != domain.RefundCompleted || len(repo.sales) != 1 || repo.sales[0].SalesAmount != -10000 {
+		t.Errorf("재시도 후 상태 %s, 기록된 매출 %+v", rf.Status, repo.sales)
+	}
+	if _, err := pg.Refund(ctx, pay.ID, 1); !errors.Is(err, payment.ErrInvalidState) {
+		t.Errorf("결제 상태가 전액 환불이 아님: %v", err)
+	}
+}
+
+func TestHandleMyOrderRejectsMalformedPath(t *testing.T) {
+	s := &Server{Repo: repository.NewMockRepository()}
+	for _, path := range []string{
+		"/api/me/orders/",
+		"/api/me/orders/abc/refund",
+		"/api/me/orders/1",
+		"/api/me/orders/1/cancel",
+		"/api/me/orders/1/refund/extra",
+	} {
+		t.Run(path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), UserContextKey, &auth.Claims{UserNumber: 1, UserID: "member01", Role: auth.RoleUser}))
+			rec := httptest.NewRecorder()
+			s.HandleMyOrder(rec, req)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("상태 코드 %d, want 404", rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/notify"
+	"guss-backend/internal/repository"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	userIDPattern    = regexp.MustCompile(`^[a-z][a-z0-9_]{3,19}$`)
+	userPhonePattern = regexp.MustCompile(`^01[016789][0-9]{7,8}$`) // 휴대폰 번호 (숫자만)
+)
+
+// normalizePhone: 하이픈/공백을 뺀 숫자만 저장 (같은 번호가 형식만 달라 중복 가입되지 않도록)
+func normalizePhone(phone string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(phone))
+}
+
+// validateUserName: 이름 검사 (가입/정보 수정 공통, 문제가 없으면 빈 문자열)
+func validateUserName(userName string) string {
+	name := []rune(userName)
+	switch {
+	case len(name) == 0:
+		return "이름을 입력해 주세요."
+	case len(name) > 50:
+		return "이름은 50자 이하로 입력해 주세요."
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return "이름에 사용할 수 없는 문자가 있습니다."
+		}
+	}
+	return ""
+}
+
+// validateUserPhone: normalizePhone을 거친 번호 검사 (문제가 없으면 빈 문자열)
+func validateUserPhone(phone string) string {
+	if !userPhonePattern.MatchString(phone) {
+		return "휴대폰 번호 형식이 올바르지 않습니다. (예: 010-1234-5678)"
+	}
+	return ""
+}
+
+// validateSignup: 가입 입력값 검사 (문제가 있는 필드 -> 사유, 비어 있으면 통과)
+func validateSignup(userID, userName, userPhone, password string) map[string]string {
+	fields := map[string]string{}
+
+	if !userIDPattern.MatchString(userID) {
+		fields["user_id"] = "아이디는 영문 소문자로 시작하는 4~20자의 영문 소문자/숫자/밑줄이어야 합니다."
+	}
+
+	if msg := validateUserName(userName); msg != "" {
+		fields["user_name"] = msg
+	}
+	if msg := validateUserPhone(userPhone); msg != "" {
+		fields["user_phone"] = msg
+	}
+
+	if err := auth.ValidatePassword(password, userID); err != nil {
+		fields["user_pw"] = err.Error()
+	}
+	return fields
+}
+
+// HandleRegister: 회원가입 1단계 - 입력값 검사 후 휴대폰으로 인증 코드 발송 (POST /api/register)
+// 계정은 /api/register/verify에서 코드를 확인해야 만들어지며, 그 전에는 로그인할 수 없다.
+func (s *Server) HandleRegister(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.Notifier == nil {
+		s.errorJSON(w, "인증 문자 발송이 비활성화되어 가입할 수 없습니다.", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req signupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+	req.UserID = strings.TrimSpace(req.UserID)
+	req.UserName = strings.TrimSpace(req.UserName)
+	req.UserPhone = normalizePhone(req.UserPhone)
+
+	if fields := validateSignup(req.UserID, req.UserName, req.UserPhone, req.UserPW); len(fields) > 0 {
+		s.fieldErrorsJSON(w, "입력값을 확인해 주세요.", "VALIDATION_FAILED", fields, http.StatusBadRequest)
+		return
+	}
+
+	idTaken, phoneTaken, err := s.Repo.CheckUserDuplicate(req.UserID, req.UserPhone)
+	if err != nil {
+		s.errorJSON(w, "회원가입 처리 중 오류 발생", http.StatusInternalServerError)
+		return
+	}
+	if idTaken || phoneTaken {
+		fields := map[string]string{}
+		if idTaken {
+			fields["user_id"] = repository.ErrDuplicateUserID.Error()
+		}
+		if phoneTaken {
+			fields["user_phone"] = repository.ErrDuplicatePhone.Error()
+		}
+		s.fieldErrorsJSON(w, "이미 가입된 정보가 있습니다.", "DUPLICATE_ACCOUNT", fields, http.StatusConflict)
+		return
+	}
+
+	if prev, err := s.Repo.GetPendingSignup(req.UserPhone); err == nil && time.Since(prev.CreatedAt) < codeResendInterval {
+		wait := int((codeResendInterval - time.Since(prev.CreatedAt)).Seconds()) + 1
+		w.Header().Set("Retry-After", strconv.Itoa(wait))
+		s.errorCodeJSON(w, "인증 코드를 방금 보냈습니다. 잠시 후 다시 요청해 주세요.", "CODE_RESEND_TOO_SOON", http.StatusTooManyRequests)
+		return
+	}
+
+	hashedPW, err := auth.HashPassword(req.UserPW)
+	if err != nil {
+		s.errorJSON(w, "비밀번호 처리 중 오류 발생", http.StatusInternalServerError)
+		return
+	}
+	code, codeHash, err := auth.NewVerificationCode()
+	if err != nil {
+		s.errorJSON(w, "인증 코드 생성 실패", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	pending := &domain.PendingSignup{
+		UserID:       req.UserID,
+		UserName:     req.UserName,
+		UserPhone:    req.UserPhone,
+		PasswordHash: hashedPW,
+		CodeHash:     codeHash,
+		ExpiresAt:    now.Add(auth.VerificationCodeTTL),
+		CreatedAt:    now,
+	}
+	if err := s.Repo.CreatePendingSignup(pending); err != nil {
+		s.errorJSON(w, "회원가입 처리 중 오류 발생", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	err = s.Notifier.Send(ctx, notify.Message{
+		Channel: notify.ChannelSMS,
+		To:      req.UserPhone,
+		Body:    "[GUSS] 회원가입 인증번호는 " + code + " 입니다. 10분 안에 입력해 주세요.",
+	})
+	if err != nil {
+		log.Printf("[NOTIFY ERROR] %s 가입 인증 코드 발송 실패: %v", req.UserID, err)
+		s.errorJSON(w, "인증 문자 발송에 실패했습니다. 잠시 후 다시 시도해 주세요.", http.StatusBadGateway)
+		return
+	}
+
+	log.Printf("[SIGNUP] %s 가입 대기 (휴대폰 인증 코드 발송)", req.UserID)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":     "verification_required",
+		"user_phone": req.UserPhone,
+		"expires_in": int(auth.VerificationCodeTTL.Seconds()),
+	})
+}
+
+// HandleVerifyRegistration: 회원가입 2단계 - 문자로 받은 코드 확인 후 계정 생성 (POST /api/register/verify)
+func (s *Server) HandleVerifyRegistration(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		UserPhone string `json:"user_phone"`
+		Code      string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserPhone == "" || req.Code == "" {
+		s.errorJSON(w, "user_phone과 code가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+
+	const invalidCode = "인증 코드가 올바르지 않거나 만료되었습니다. 다시 요청해 주세요."
+	pending, err := s.Repo.GetPendingSignup(normalizePhone(req.UserPhone))
+	if errors.Is(err, repository.ErrNotFound) {
+		s.errorCodeJSON(w, invalidCode, "SIGNUP_CODE_INVALID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.errorJSON(w, "인증 코드 확인 실패", http.StatusInternalServerError)
+		return
+	}
+	// 비교 전에 시도 횟수부터 차감 (동시 요청으로 한도를 넘겨 코드를 대입하지 못하도록)
+	if err := s.Repo.UsePendingSignupAttempt(pending.SignupNumber, auth.VerificationCodeMaxAttempts); errors.Is(err, repository.ErrNotFound) {
+		s.errorCodeJSON(w, invalidCode, "SIGNUP_CODE_INVALID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.errorJSON(w, "인증 코드 확인 실패", http.StatusInternalServerError)
+		return
+	}
+	if !auth.CheckVerificationCode(req.Code, pending.CodeHash) {
+		s.errorCodeJSON(w, invalidCode, "SIGNUP_CODE_INVALID", http.StatusBadRequest)
+		return
+	}
+
+	u, err := s.Repo.CompleteSignup(pending.SignupNumber)
+	switch {
+	case errors.Is(err, repository.ErrDuplicateUserID):
+		s.fieldErrorsJSON(w, "이미 가입된 정보가 있습니다.", "DUPLICATE_ACCOUNT", map[string]string{"user_id": err.Error()}, http.StatusConflict)
+		return
+	case errors.Is(err, repository.ErrDuplicatePhone):
+		s.fieldErrorsJSON(w, "이미 가입된 정보가 있습니다.", "DUPLICATE_ACCOUNT", map[string]string{"user_phone": err.Error()}, http.StatusConflict)
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		s.errorCodeJSON(w, invalidCode, "SIGNUP_CODE_INVALID", http.StatusBadRequest)
+		return
+	case err != nil:
+		s.errorJSON(w, "회원가입 실패", http.StatusInternalServerError)
+		return
+	}
+
+	s.audit(u.UserID, "SIGNUP")
+	log.Printf("[SUCCESS] 신규 유저 가입: %s (No: %d)", u.UserName, u.UserNumber)
+	json.NewEncoder(w).Encode(signupResponse{Status: "success", UserNumber: u.UserNumber})
+}
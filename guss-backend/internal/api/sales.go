@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/domain"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxSalesTypeLen = 50 // sales_table.sales_type VARCHAR(50)
+
+// HandleGetSales: 매출 조회 (gym_id, from/to(YYYY-MM-DD, 한국 시간 기준, to 포함), type 필터)
+func (s *Server) HandleGetSales(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	filter, msg := parseSalesFilter(r)
+	if msg != "" {
+		s.errorJSON(w, msg, http.StatusBadRequest)
+		return
+	}
+	filter.GymID = scopedGymID(scope, filter.GymID) // 지점 관리자는 자기 지점 매출만 조회
+
+	list, err := s.Repo.GetSales(filter)
+	if err != nil {
+		s.errorJSON(w, "매출 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// HandleCreateSale: 현장 판매 등 매출 수동 기록
+func (s *Server) HandleCreateSale(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	var sale domain.Sale
+	if err := json.NewDecoder(r.Body).Decode(&sale); err != nil {
+		s.errorJSON(w, "데이터 형식 오류", http.StatusBadRequest)
+		return
+	}
+	sale.GymID = scopedGymID(scope, sale.GymID)
+	sale.OrderNumber = 0 // 주문 연결은 결제 흐름에서만
+
+	sale.SalesType = strings.TrimSpace(sale.SalesType)
+	switch {
+	case sale.GymID <= 0:
+		s.errorJSON(w, "체육관 ID가 유효하지 않습니다.", http.StatusBadRequest)
+		return
+	case sale.SalesType == "" || len(sale.SalesType) > maxSalesTypeLen:
+		s.errorJSON(w, "매출 유형이 올바르지 않습니다.", http.StatusBadRequest)
+		return
+	case sale.SalesAmount <= 0:
+		s.errorJSON(w, "매출 금액은 0보다 커야 합니다.", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.Repo.CreateSale(&sale); err != nil {
+		s.errorJSON(w, "매출 기록 실패", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("[SUCCESS] 체육관 %d번 매출 기록: %s %d원", sale.GymID, sale.SalesType, sale.SalesAmount)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":       "success",
+		"sales_number": sale.SalesNumber,
+	})
+}
+
+// parseSalesFilter: 쿼리 파라미터를 매출 조회 조건으로 변환 (형식 오류 시 에러 메시지 반환)
+func parseSalesFilter(r *http.Request) (domain.SalesFilter, string) {
+	q := r.URL.Query()
+	var f domain.SalesFilter
+
+	idStr := q.Get("gym_id")
+	if idStr == "" {
+		idStr = q.Get("gymId")
+	}
+	if idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil || id < 0 {
+			return f, "체육관 ID가 유효하지 않습니다."
+		}
+		f.GymID = id
+	}
+
+	if v := q.Get("from"); v != "" {
+		t, err := time.ParseInLocation(dateLayout, v, algo.KST)
+		if err != nil {
+			return f, "시작일 형식이 올바르지 않습니다. (YYYY-MM-DD)"
+		}
+		f.From = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.ParseInLocation(dateLayout, v, algo.KST)
+		if err != nil {
+			return f, "종료일 형식이 올바르지 않습니다. (YYYY-MM-DD)"
+		}
+		f.To = t.AddDate(0, 0, 1) // 종료일 당일까지 포함
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return f, "시작일이 종료일보다 늦을 수 없습니다."
+	}
+
+	f.SalesType = strings.TrimSpace(q.Get("type"))
+	return f, ""
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultReportDays = 90 // from 미지정 시 최근 90일
+
+// HandleGetSalesReport: 일/주/월 단위 매출 집계 리포트 (한국 시간 기준, format=csv 지원)
+func (s *Server) HandleGetSalesReport(w http.ResponseWriter, r *http.Request) {
+	scope, ok := s.requireGymScope(w, r)
+	if !ok {
+		return
+	}
+
+	filter, msg := parseSalesFilter(r)
+	if msg != "" {
+		s.errorJSON(w, msg, http.StatusBadRequest)
+		return
+	}
+	filter.GymID = scopedGymID(scope, filter.GymID) // 지점 관리자는 자기 지점 매출만 집계
+
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = algo.PeriodMonthly
+	}
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy == "" {
+		groupBy = algo.GroupByGym
+	}
+	if !algo.IsValidPeriod(period) || !algo.IsValidGroupBy(groupBy) {
+		s.errorJSON(w, "period는 daily/weekly/monthly, group_by는 gym/type/gym_type 중 하나여야 합니다.", http.StatusBadRequest)
+		return
+	}
+
+	since := filter.From
+	if since.IsZero() {
+		since = time.Now().In(algo.KST).AddDate(0, 0, -defaultReportDays)
+	}
+	// 첫 기간의 증감률 계산을 위해 직전 기간부터 조회
+	filter.From = algo.PrevPeriodStart(algo.PeriodStart(since, period), period)
+
+	sales, err := s.Repo.GetSales(filter)
+	if err != nil {
+		s.errorJSON(w, "매출 조회 실패", http.StatusInternalServerError)
+		return
+	}
+
+	until := time.Now()
+	if !filter.To.IsZero() {
+		until = filter.To.Add(-time.Nanosecond)
+	}
+	buckets := algo.AggregateRevenue(sales, period, groupBy, since, until)
+
+	var total, refundAmount int64
+	var count, refundCount int
+	for _, b := range buckets {
+		total += b.Total
+		count += b.Count
+		refundCount += b.RefundCount
+		refundAmount += b.RefundAmount
+	}
+	avg := algo.AverageTicket(total+refundAmount, count)
+
+	to := ""
+	if !filter.To.IsZero() {
+		to = filter.To.AddDate(0, 0, -1).In(algo.KST).Format(dateLayout)
+	}
+	from := algo.PeriodStart(since, period).Format(dateLayout)
+
+	if r.URL.Query().Get("format") == "csv" {
+		writeRevenueCSV(w, period, from, buckets)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"period":         period,
+		"group_by":       groupBy,
+		"timezone":       "Asia/Seoul",
+		"from":           from,
+		"to":             to,
+		"total":          total,
+		"count":          count,
+		"average_ticket": avg,
+		"refund_count":   refundCount,
+		"refund_amount":  refundAmount,
+		"buckets":        buckets,
+	})
+}
+
+func writeRevenueCSV(w http.ResponseWriter, period, from string, buckets []domain.RevenueBucket) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=revenue_%s_%s.csv", period, from))
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"period_start", "gym_id", "type", "total", "count", "average_ticket", "refund_count", "refund_amount", "prev_total", "change_rate"})
+	for _, b := range buckets {
+		rate := ""
+		if b.ChangeRate != nil {
+			rate = strconv.FormatFloat(*b.ChangeRate, 'f', 2, 64)
+		}
+		gym := ""
+		if b.GymID != 0 {
+			gym = strconv.FormatInt(b.GymID, 10)
+		}
+		cw.Write([]string{
+			b.PeriodStart, gym, b.SalesType,
+			strconv.FormatInt(b.Total, 10), strconv.Itoa(b.Count), strconv.FormatInt(b.AverageTicket, 10),
+			strconv.Itoa(b.RefundCount), strconv.FormatInt(b.RefundAmount, 10),
+			strconv.FormatInt(b.PrevTotal, 10), rate,
+		})
+	}
+	cw.Flush()
+}
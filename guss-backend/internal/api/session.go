@@ -0,0 +1,240 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"guss-backend/internal/auth"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/repository"
+	"log"
+	"net/http"
+	"time"
+)
+
+// principal: 토큰을 발급받는 로그인 주체 (일반 회원 또는 admin_table 관리자)
+type principal struct {
+	Type         string // domain.PrincipalUser / domain.PrincipalAdmin
+	Number       int64
+	LoginID      string
+	Name         string
+	Role         string
+	Permissions  []string
+	GymID        int64 // 관리자의 담당 지점 ID (0은 지점 없음 또는 전체)
+	PasswordHash string
+}
+
+// loadPrincipal: 주체 유형별 조회 (회원/관리자 아이디 공간은 분리되어 있으며, 리프레시 시 최신 역할/권한을 다시 읽을 때도 사용)
+func (s *Server) loadPrincipal(principalType, loginID string) (*principal, error) {
+	var p *principal
+	switch principalType {
+	case domain.PrincipalUser:
+		user, err := s.Repo.GetUserByID(loginID)
+		if err != nil {
+			return nil, err
+		}
+		p = &principal{Type: principalType, Number: user.UserNumber, LoginID: user.UserID, Name: user.UserName,
+			Role: auth.RoleUser, PasswordHash: user.UserPW}
+	case domain.PrincipalAdmin:
+		admin, err := s.Repo.GetAdminByID(loginID)
+		if err != nil {
+			return nil, err
+		}
+		p = &principal{Type: principalType, Number: admin.AdminNumber, LoginID: admin.AdminID, Name: "관리자(" + admin.AdminID + ")",
+			Role: admin.AdminRole, PasswordHash: admin.AdminPW}
+		// [중요] sql.NullInt64 안전하게 처리 (SUPER_ADMIN은 NULL이므로 Valid가 false)
+		if admin.FKGussID.Valid {
+			p.GymID = admin.FKGussID.Int64
+		}
+	default:
+		return nil, repository.ErrNotFound
+	}
+
+	perms, err := s.Repo.GetRolePermissions(p.Role)
+	if err != nil {
+		log.Printf("[AUTH] %s의 역할 %q 권한 조회 실패: %v", p.LoginID, p.Role, err)
+		return nil, err
+	}
+	p.Permissions = perms
+	return p, nil
+}
+
+type issuedRefreshToken struct {
+	raw   string
+	token *domain.RefreshToken
+}
+
+func (s *Server) newRefreshToken(p *principal, familyID string) (*issuedRefreshToken, error) {
+	raw, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &issuedRefreshToken{raw: raw, token: &domain.RefreshToken{
+		TokenHash:       hash,
+		FamilyID:        familyID,
+		PrincipalType:   p.Type,
+		PrincipalNumber: p.Number,
+		PrincipalID:     p.LoginID,
+		ExpiresAt:       now.Add(auth.RefreshTokenTTL),
+		CreatedAt:       now,
+	}}, nil
+}
+
+// sessionPayload: 액세스 토큰을 만들어 로그인/재발급 공통 응답 구성
+func (s *Server) sessionPayload(p *principal, refreshToken string) (*sessionResponse, error) {
+	token, err := auth.GenerateToken(p.Number, p.LoginID, p.Role, p.GymID, p.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionResponse{
+		Status:       "success",
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(auth.AccessTokenTTL.Seconds()),
+		UserName:     p.Name,
+		UserRole:     p.Role,
+		Permissions:  p.Permissions,
+		GymID:        p.GymID,
+	}, nil
+}
+
+// writeSession: 로그인/재발급 공통 응답 작성
+func (s *Server) writeSession(w http.ResponseWriter, p *principal, refreshToken string) {
+	session, err := s.sessionPayload(p, refreshToken)
+	if err != nil {
+		s.errorJSON(w, "토큰 생성 실패", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(session)
+}
+
+// issueSession: 인증을 모두 마친 주체에게 새 리프레시 토큰 묶음을 만들고 응답 본문 반환
+func (s *Server) issueSession(p *principal) (*sessionResponse, error) {
+	familyID, err := auth.NewTokenFamilyID()
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := s.newRefreshToken(p, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.SaveRefreshToken(refresh.token); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[LOGIN] %s %s 접속 (Role: %s, GymID: %d)", p.Type, p.LoginID, p.Role, p.GymID)
+	return s.sessionPayload(p, refresh.raw)
+}
+
+// HandleRefreshToken: 리프레시 토큰으로 액세스 토큰 재발급 (POST /api/token/refresh)
+// 리프레시 토큰은 한 번 쓰면 새 토큰으로 교체되며, 권한(역할/담당 지점)은 매번 DB에서 다시 읽는다.
+func (s *Server) HandleRefreshToken(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		s.errorJSON(w, "리프레시 토큰이 없습니다.", http.StatusBadRequest)
+		return
+	}
+
+	raw, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		s.errorJSON(w, "토큰 생성 실패", http.StatusInternalServerError)
+		return
+	}
+	next := &domain.RefreshToken{TokenHash: hash, ExpiresAt: time.Now().Add(auth.RefreshTokenTTL), CreatedAt: time.Now()}
+
+	old, err := s.Repo.RotateRefreshToken(auth.HashRefreshToken(req.RefreshToken), next)
+	switch {
+	case errors.Is(err, repository.ErrRefreshTokenReused):
+		s.audit(old.PrincipalID, "REFRESH_TOKEN_REUSE family="+old.FamilyID)
+		s.errorJSON(w, err.Error(), http.StatusUnauthorized)
+		return
+	case errors.Is(err, repository.ErrRefreshTokenExpired):
+		s.errorJSON(w, err.Error(), http.StatusUnauthorized)
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		s.errorJSON(w, "유효하지 않은 리프레시 토큰입니다.", http.StatusUnauthorized)
+		return
+	case err != nil:
+		s.errorJSON(w, "토큰 재발급 실패", http.StatusInternalServerError)
+		return
+	}
+
+	// 탈퇴/삭제 등으로 주체가 사라졌으면 세션도 끝낸다.
+	p, err := s.loadPrincipal(old.PrincipalType, old.PrincipalID)
+	if err != nil || p.Number != old.PrincipalNumber {
+		s.Repo.RevokeTokenFamily(old.FamilyID)
+		s.errorJSON(w, "계정 정보를 찾을 수 없습니다. 다시 로그인해 주세요.", http.StatusUnauthorized)
+		return
+	}
+
+	s.writeSession(w, p, raw)
+}
+
+// HandleLogout: 리프레시 토큰 폐기 (POST /api/logout, all=true면 모든 기기에서 로그아웃)
+// 이미 발급된 액세스 토큰은 짧은 유효 시간이 지나면 자연히 만료된다.
+func (s *Server) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+		All          bool   `json:"all"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		s.errorJSON(w, "리프레시 토큰이 없습니다.", http.StatusBadRequest)
+		return
+	}
+
+	t, err := s.Repo.GetRefreshToken(auth.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		// 이미 없는 토큰이어도 로그아웃은 성공으로 처리
+		json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+		return
+	}
+
+	if req.All {
+		err = s.Repo.RevokeAllRefreshTokens(t.PrincipalType, t.PrincipalNumber)
+	} else {
+		err = s.Repo.RevokeTokenFamily(t.FamilyID)
+	}
+	if err != nil {
+		s.errorJSON(w, "로그아웃 처리 실패", http.StatusInternalServerError)
+		return
+	}
+
+	action := "LOGOUT"
+	if req.All {
+		action = "LOGOUT_ALL"
+	}
+	s.audit(t.PrincipalID, action)
+	log.Printf("[LOGOUT] %s (%s)", t.PrincipalID, action)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// HandleJWKS: 토큰 검증용 공개키 목록 (GET /.well-known/jwks.json)
+// 비대칭(RS256/EdDSA) 키만 노출되며, HS256만 쓰는 경우 빈 목록이 반환된다.
+func (s *Server) HandleJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	ks := auth.CurrentKeySet()
+	if ks == nil {
+		s.errorJSON(w, "서명 키가 설정되지 않았습니다.", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(ks.JWKS())
+}
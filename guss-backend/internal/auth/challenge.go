@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ChallengeTTL: 비밀번호 확인 후 2단계 인증을 마칠 때까지 허용하는 시간
+const ChallengeTTL = 5 * time.Minute
+
+// 2단계 인증 토큰 용도
+const (
+	ChallengeMFAVerify = "mfa_verify" // OTP 또는 복구 코드 입력 대기
+	ChallengeMFASetup  = "mfa_setup"  // 필수 역할인데 아직 OTP를 등록하지 않음
+)
+
+// ChallengeClaims: 비밀번호만 확인된 상태를 나타내는 단기 토큰
+// audience가 액세스 토큰과 달라서 API 인증에는 쓸 수 없다.
+type ChallengeClaims struct {
+	PrincipalType string `json:"ptype"`
+	Purpose       string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+func challengeAudience() string {
+	return currentTokenConfig().Audience + ":mfa"
+}
+
+// GenerateChallengeToken: 비밀번호 확인 직후 발급 (subject는 로그인 ID)
+func GenerateChallengeToken(principalType, loginID, purpose string) (string, error) {
+	ks := CurrentKeySet()
+	if ks == nil {
+		return "", ErrNoSigningKey
+	}
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	cfg := currentTokenConfig()
+	now := time.Now()
+	claims := &ChallengeClaims{
+		PrincipalType: principalType,
+		Purpose:       purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    cfg.Issuer,
+			Subject:   loginID,
+			Audience:  jwt.ClaimStrings{challengeAudience()},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ChallengeTTL)),
+			ID:        jti,
+		},
+	}
+	return ks.Sign(claims)
+}
+
+// ValidateChallengeToken: 2단계 인증 토큰 검증 (용도가 다르면 ErrTokenInvalid)
+func ValidateChallengeToken(tokenString, purpose string) (*ChallengeClaims, error) {
+	claims := &ChallengeClaims{}
+	if err := parseToken(tokenString, challengeAudience(), claims); err != nil {
+		return nil, err
+	}
+	if claims.ID == "" || claims.Purpose != purpose {
+		return nil, ErrTokenInvalid
+	}
+	return claims, nil
+}
@@ -2,47 +2,177 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"sync"
 	"time"
 	"github.com/golang-jwt/jwt/v5" // JWT 라이브러리 (표준에 가장 가까움)
 )
 
-var secretKey = []byte("GUSS_SECRET_KEY_2026") // 실제 운영 시 환경변수 처리
+// AccessTokenTTL: 액세스 토큰 유효 시간 (만료 후에는 리프레시 토큰으로 재발급)
+const AccessTokenTTL = 15 * time.Minute
+
+// 토큰 검증 실패 사유 (클라이언트는 error_code로 구분해 재발급/재로그인을 결정)
+var (
+	ErrTokenExpired     = errors.New("토큰이 만료되었습니다")
+	ErrTokenNotYetValid = errors.New("아직 사용할 수 없는 토큰입니다")
+	ErrTokenMalformed   = errors.New("토큰 형식이 올바르지 않습니다")
+	ErrTokenAudience    = errors.New("이 서비스용으로 발급된 토큰이 아닙니다")
+	ErrTokenIssuer      = errors.New("토큰 발급자가 올바르지 않습니다")
+	ErrTokenInvalid     = errors.New("유효하지 않은 토큰입니다")
+)
+
+// TokenErrorCode: 검증 에러를 응답용 에러 코드로 변환
+func TokenErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrTokenExpired):
+		return "TOKEN_EXPIRED"
+	case errors.Is(err, ErrTokenNotYetValid):
+		return "TOKEN_NOT_YET_VALID"
+	case errors.Is(err, ErrTokenMalformed):
+		return "TOKEN_MALFORMED"
+	case errors.Is(err, ErrTokenAudience):
+		return "TOKEN_WRONG_AUDIENCE"
+	case errors.Is(err, ErrTokenIssuer):
+		return "TOKEN_WRONG_ISSUER"
+	default:
+		return "TOKEN_INVALID"
+	}
+}
+
+// TokenConfig: 발급자/대상 서비스와 시계 오차 허용 범위
+type TokenConfig struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration // 서버 간 시계 차이 허용 (exp/nbf/iat 판정에 적용)
+}
+
+var DefaultTokenConfig = TokenConfig{Issuer: "guss-backend", Audience: "guss-api", Leeway: 30 * time.Second}
+
+var (
+	tokenConfigMu sync.RWMutex
+	tokenConfig   = DefaultTokenConfig
+)
+
+// SetTokenConfig: 서버 시작 시 발급/검증 규칙 지정
+func SetTokenConfig(cfg TokenConfig) {
+	tokenConfigMu.Lock()
+	tokenConfig = cfg
+	tokenConfigMu.Unlock()
+}
+
+func currentTokenConfig() TokenConfig {
+	tokenConfigMu.RLock()
+	defer tokenConfigMu.RUnlock()
+	return tokenConfig
+}
 
 type Claims struct {
-	UserNumber int64  `json:"user_number"`
-	UserID     string `json:"user_id"`
-	Role       string `json:"role"`
+	UserNumber  int64    `json:"user_number"`
+	UserID      string   `json:"user_id"`
+	Role        string   `json:"role"`
+	GymID       int64    `json:"gym_id"`          // 지점 관리자의 담당 지점 (0은 지점 없음)
+	Permissions []string `json:"perms,omitempty"` // 역할에 부여된 세부 권한 (로그인/재발급 시 DB에서 조회)
 	jwt.RegisteredClaims
 }
 
 // GenerateToken: 로그인 성공 시 토큰 생성
-func GenerateToken(userNumber int64, userID, role string) (string, error) {
+func GenerateToken(userNumber int64, userID, role string, gymID int64, perms []string) (string, error) {
+	ks := CurrentKeySet()
+	if ks == nil {
+		return "", ErrNoSigningKey
+	}
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	cfg := currentTokenConfig()
+	now := time.Now()
 	claims := &Claims{
-		UserNumber: userNumber,
-		UserID:     userID,
-		Role:       role,
+		UserNumber:  userNumber,
+		UserID:      userID,
+		Role:        role,
+		GymID:       gymID,
+		Permissions: perms,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			Issuer:    cfg.Issuer,
+			Subject:   userID,
+			Audience:  jwt.ClaimStrings{cfg.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+			ID:        jti,
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secretKey)
+	return ks.Sign(claims)
+}
+
+// newTokenID: 토큰마다 고유한 jti (추후 개별 토큰 차단/추적용)
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
 }
 
 // ValidateToken: 미들웨어에서 토큰 검증 시 사용
+// 서명 알고리즘은 설정된 키의 알고리즘으로 고정하고, iss/aud/exp/nbf/iat를 모두 확인한다.
 func ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
-	})
+	claims := &Claims{}
+	if err := parseToken(tokenString, currentTokenConfig().Audience, claims); err != nil {
+		return nil, err
+	}
+	if claims.ID == "" {
+		return nil, ErrTokenInvalid
+	}
+	return claims, nil
+}
+
+// parseToken: 서명/등록 클레임 공통 검증 (audience로 액세스 토큰과 2단계 인증 토큰을 구분)
+func parseToken(tokenString, audience string, claims jwt.Claims) error {
+	ks := CurrentKeySet()
+	if ks == nil {
+		return ErrNoSigningKey
+	}
+
+	cfg := currentTokenConfig()
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(ks.Algorithms()),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(audience),
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
 
+	token, err := parser.ParseWithClaims(tokenString, claims, ks.Keyfunc)
 	if err != nil {
-		return nil, err
+		return classifyTokenError(err)
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
+	if id, _ := claims.GetSubject(); !token.Valid || id == "" {
+		return ErrTokenInvalid
 	}
+	return nil
+}
 
-	return nil, errors.New("invalid token")
-}
\ No newline at end of file
+// classifyTokenError: 라이브러리 에러를 검증 실패 사유로 정리 (서명/키 관련 상세는 노출하지 않음)
+func classifyTokenError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return ErrTokenMalformed
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return ErrTokenNotYetValid
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrTokenAudience
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ErrTokenIssuer
+	default:
+		return ErrTokenInvalid
+	}
+}
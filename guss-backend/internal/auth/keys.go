@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoSigningKey = errors.New("JWT 서명 키가 설정되지 않았습니다")
+	ErrUnknownKID   = errors.New("알 수 없는 서명 키(kid)입니다")
+)
+
+// SigningKey: kid로 식별되는 JWT 서명/검증 키 한 개
+// 개인키가 없는(공개키만 있는) 키는 교체 후 기존 토큰 검증용으로만 쓰인다.
+type SigningKey struct {
+	KID       string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+func (k *SigningKey) CanSign() bool { return k.signKey != nil }
+
+// KeySet: 현재 활성 키로 서명하고, 등록된 모든 키로 검증한다.
+// 새 키를 추가해 활성 키를 바꾸고 이전 키를 남겨 두면 기존 토큰을 끊지 않고 키를 교체할 수 있다.
+type KeySet struct {
+	mu     sync.RWMutex
+	keys   map[string]*SigningKey
+	order  []string
+	active string
+}
+
+// ParseKeySet: 키 설정 문자열 파싱
+// 형식: "kid=ALG:값;kid2=ALG:값" (ALG는 HS256, RS256, EdDSA)
+//   - HS256: 값이 공유 비밀키 자체 (JWKS로 공개되지 않음)
+//   - RS256/EdDSA: 값이 PEM 파일 경로 (개인키면 서명+검증, 공개키면 검증 전용)
+//
+// activeKID가 비어 있으면 서명 가능한 첫 번째 키가 활성 키가 된다.
+func ParseKeySet(spec, activeKID string) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*SigningKey)}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, rest, ok := strings.Cut(entry, "=")
+		alg, value, ok2 := strings.Cut(rest, ":")
+		if !ok || !ok2 || kid == "" || value == "" {
+			return nil, fmt.Errorf("잘못된 키 설정: %q (kid=ALG:값 형식)", entry)
+		}
+		key, err := loadSigningKey(kid, alg, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := ks.Add(key); err != nil {
+			return nil, err
+		}
+	}
+	if len(ks.keys) == 0 {
+		return nil, ErrNoSigningKey
+	}
+
+	if activeKID == "" {
+		for _, kid := range ks.order {
+			if ks.keys[kid].CanSign() {
+				activeKID = kid
+				break
+			}
+		}
+	}
+	if err := ks.SetActive(activeKID); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+func loadSigningKey(kid, alg, value string) (*SigningKey, error) {
+	key := &SigningKey{KID: kid}
+	switch alg {
+	case "HS256":
+		key.Method = jwt.SigningMethodHS256
+		key.signKey, key.verifyKey = []byte(value), []byte(value)
+		return key, nil
+	case "RS256", "EdDSA":
+	default:
+		return nil, fmt.Errorf("키 %s: 지원하지 않는 알고리즘 %q", kid, alg)
+	}
+
+	pem, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("키 %s: PEM 파일 읽기 실패: %w", kid, err)
+	}
+
+	if alg == "RS256" {
+		key.Method = jwt.SigningMethodRS256
+		if priv, err := jwt.ParseRSAPrivateKeyFromPEM(pem); err == nil {
+			key.signKey, key.verifyKey = priv, &priv.PublicKey
+		} else if pub, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+			key.verifyKey = pub
+		} else {
+			return nil, fmt.Errorf("키 %s: RSA 키를 해석할 수 없습니다", kid)
+		}
+		return key, nil
+	}
+
+	key.Method = jwt.SigningMethodEdDSA
+	if priv, err := jwt.ParseEdPrivateKeyFromPEM(pem); err == nil {
+		edPriv := priv.(ed25519.PrivateKey)
+		key.signKey, key.verifyKey = edPriv, edPriv.Public()
+	} else if pub, err := jwt.ParseEdPublicKeyFromPEM(pem); err == nil {
+		key.verifyKey = pub
+	} else {
+		return nil, fmt.Errorf("키 %s: Ed25519 키를 해석할 수 없습니다", kid)
+	}
+	return key, nil
+}
+
+// NewDevKeySet: 키 설정이 없을 때 쓰는 임시 HS256 키 (재시작하면 모든 토큰이 무효화됨)
+func NewDevKeySet() (*KeySet, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	kid := "dev-" + hex.EncodeToString(secret[:4])
+	ks := &KeySet{keys: make(map[string]*SigningKey)}
+	ks.Add(&SigningKey{KID: kid, Method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret})
+	ks.active = kid
+	log.Printf("--- [WARN] JWT 서명 키 미설정: 임시 키(%s)를 생성했습니다. 재시작 시 모든 로그인이 만료됩니다 ---", kid)
+	return ks, nil
+}
+
+// Add: 검증용 키 추가 (kid 중복 불가)
+func (ks *KeySet) Add(key *SigningKey) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if _, dup := ks.keys[key.KID]; dup {
+		return fmt.Errorf("중복된 kid: %s", key.KID)
+	}
+	ks.keys[key.KID] = key
+	ks.order = append(ks.order, key.KID)
+	return nil
+}
+
+// SetActive: 새 토큰 서명에 쓸 키 지정 (개인키가 있어야 함)
+func (ks *KeySet) SetActive(kid string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	key, ok := ks.keys[kid]
+	if !ok {
+		return fmt.Errorf("활성 키 %q: %w", kid, ErrUnknownKID)
+	}
+	if !key.CanSign() {
+		return fmt.Errorf("활성 키 %q에 개인키가 없습니다", kid)
+	}
+	ks.active = kid
+	return nil
+}
+
+func (ks *KeySet) ActiveKID() string {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.active
+}
+
+// Algorithms: 등록된 키들의 서명 알고리즘 목록 (검증 시 이 외의 alg는 거부)
+func (ks *KeySet) Algorithms() []string {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	algs := []string{}
+	seen := make(map[string]bool)
+	for _, kid := range ks.order {
+		alg := ks.keys[kid].Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
+// Sign: 활성 키로 서명하고 헤더에 kid 기록
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	ks.mu.RLock()
+	key := ks.keys[ks.active]
+	ks.mu.RUnlock()
+	if key == nil {
+		return "", ErrNoSigningKey
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.signKey)
+}
+
+// Keyfunc: 토큰 헤더의 kid로 검증 키를 고른다. 키에 등록된 알고리즘과 다르면 거부 (알고리즘 혼동 방지)
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	ks.mu.RLock()
+	key := ks.keys[kid]
+	ks.mu.RUnlock()
+	if key == nil {
+		return nil, ErrUnknownKID
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("kid %s: 알고리즘 불일치 (%s)", kid, token.Method.Alg())
+	}
+	return key.verifyKey, nil
+}
+
+// JWK: 공개키 한 개의 JSON Web Key 표현 (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS: 다른 서비스가 토큰을 검증할 수 있도록 비대칭 키의 공개키만 내보낸다 (HS256 비밀키는 제외)
+func (ks *KeySet) JWKS() map[string][]JWK {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	list := []JWK{}
+	for _, kid := range ks.order {
+		key := ks.keys[kid]
+		switch pub := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			list = append(list, JWK{Kty: "RSA", Kid: kid, Alg: key.Method.Alg(), Use: "sig",
+				N: base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())})
+		case ed25519.PublicKey:
+			list = append(list, JWK{Kty: "OKP", Kid: kid, Alg: key.Method.Alg(), Use: "sig",
+				Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)})
+		}
+	}
+	return map[string][]JWK{"keys": list}
+}
+
+var (
+	keysMu sync.RWMutex
+	keys   *KeySet
+)
+
+// SetKeySet: 서버 시작 시 토큰 발급/검증에 사용할 키 묶음 지정
+func SetKeySet(ks *KeySet) {
+	keysMu.Lock()
+	keys = ks
+	keysMu.Unlock()
+}
+
+// CurrentKeySet: 현재 설정된 키 묶음 (미설정이면 nil)
+func CurrentKeySet() *KeySet {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	return keys
+}
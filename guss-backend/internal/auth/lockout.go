@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrLoginLocked = errors.New("로그인 시도가 너무 많습니다. 잠시 후 다시 시도해 주세요")
+
+// LockoutPolicy: 로그인 실패 제한 규칙 (계정별/IP별로 따로 집계)
+type LockoutPolicy struct {
+	FreeAttempts  int           // 이 횟수까지는 대기 없이 재시도 가능 (오타 허용)
+	BaseDelay     time.Duration // 그 이후 실패마다 2배씩 늘어나는 대기 시간의 시작값
+	MaxDelay      time.Duration
+	AccountLockAt int // 계정 연속 실패가 이 횟수에 도달하면 LockDuration 동안 잠금
+	IPLockAt      int // IP는 여러 회원이 공유하므로(NAT, 공용 와이파이) 더 높은 기준까지 대기 없이 허용한 뒤 잠금
+	LockDuration  time.Duration
+	FailureWindow time.Duration // 마지막 실패 후 이 시간이 지나면 실패 횟수 초기화
+}
+
+var DefaultLockoutPolicy = LockoutPolicy{
+	FreeAttempts:  2,
+	BaseDelay:     time.Second,
+	MaxDelay:      time.Minute,
+	AccountLockAt: 5,
+	IPLockAt:      20,
+	LockDuration:  15 * time.Minute,
+	FailureWindow: 30 * time.Minute,
+}
+
+// AttemptState: 키(계정 또는 IP) 하나의 실패 집계
+type AttemptState struct {
+	Failures    int       `json:"failures"`
+	LastFailure time.Time `json:"last_failure"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// AttemptStore: 실패 집계 저장소 (단일 인스턴스는 메모리, 다중 인스턴스는 공유 저장소 사용)
+type AttemptStore interface {
+	Get(key string) (AttemptState, error) // 기록이 없으면 빈 상태
+	// Acquire: blocked가 false일 때만 시도 1회를 실패로 미리 집계 (확인과 증가를 원자적으로 처리)
+	// 마지막 실패가 window보다 오래됐으면 1부터 다시 센다. 거절했으면 증가 전 상태와 false 반환
+	Acquire(key string, now time.Time, window time.Duration, blocked func(AttemptState) bool) (AttemptState, bool, error)
+	Release(key string) error // Acquire로 미리 집계한 1회 취소 (비밀번호가 맞았을 때)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+}
+
+// LoginGuard: 로그인 시도 예약(대기/잠금 확인)과 실패/성공 기록
+// 시도는 비밀번호 확인 전에 실패로 먼저 집계해 두므로, 동시에 보낸 요청들이 같은 실패 횟수를 보고 한꺼번에 통과하지 못한다.
+type LoginGuard struct {
+	Store  AttemptStore
+	Policy LockoutPolicy
+}
+
+// LoginThrottle: 로그인을 거절한 이유와 재시도 가능 시점
+type LoginThrottle struct {
+	Locked     bool // 임계치 초과로 잠김 (false면 지수 대기 중)
+	RetryAfter time.Duration
+	Key        string
+}
+
+func AccountAttemptKey(principalType, loginID string) string {
+	return "acct:" + principalType + ":" + loginID
+}
+
+const ipKeyPrefix = "ip:"
+
+func IPAttemptKey(ip string) string {
+	return ipKeyPrefix + ip
+}
+
+// delay: 실패 n회 뒤 다음 시도까지 기다려야 하는 시간
+func (p LockoutPolicy) delay(failures int) time.Duration {
+	n := failures - p.FreeAttempts
+	if n <= 0 {
+		return 0
+	}
+	d := p.BaseDelay
+	for i := 1; i < n && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// blockedUntil: 키의 다음 시도 가능 시점 (잠금이면 true) - IP 키는 잠금만 적용하고 실패마다의 대기는 계정 키에만 둔다
+func (p LockoutPolicy) blockedUntil(key string, st AttemptState, now time.Time) (time.Time, bool) {
+	if now.Before(st.LockedUntil) {
+		return st.LockedUntil, true
+	}
+	if strings.HasPrefix(key, ipKeyPrefix) || st.Failures == 0 || now.Sub(st.LastFailure) > p.FailureWindow {
+		return time.Time{}, false
+	}
+	return st.LastFailure.Add(p.delay(st.Failures)), false
+}
+
+// Begin: 비밀번호 확인 전에 호출 - 잠금 또는 대기 중이면 거절 사유 반환, 아니면 이번 시도를 실패로 미리 집계 (nil이면 시도 가능)
+func (g *LoginGuard) Begin(accountKey, ipKey string, now time.Time) (*LoginThrottle, error) {
+	var acquired []string
+	for _, key := range []string{ipKey, accountKey} {
+		blocked := func(st AttemptState) bool {
+			until, _ := g.Policy.blockedUntil(key, st, now)
+			return now.Before(until)
+		}
+		st, ok, err := g.Store.Acquire(key, now, g.Policy.FailureWindow, blocked)
+		if err == nil && !ok {
+			// 앞에서 예약한 키는 되돌린다 (거절된 시도는 집계하지 않음)
+			g.release(acquired)
+			until, locked := g.Policy.blockedUntil(key, st, now)
+			return &LoginThrottle{Locked: locked, RetryAfter: until.Sub(now), Key: key}, nil
+		}
+		if err != nil {
+			g.release(acquired)
+			return nil, err
+		}
+		acquired = append(acquired, key)
+	}
+	return nil, nil
+}
+
+// Fail: 비밀번호/인증 코드가 틀렸을 때 호출 - Begin에서 집계한 횟수로 잠금 여부 판단, 새로 잠긴 키가 있으면 반환
+func (g *LoginGuard) Fail(accountKey, ipKey string, now time.Time) (accountFailures int, lockedKeys []string, err error) {
+	limits := map[string]int{accountKey: g.Policy.AccountLockAt, ipKey: g.Policy.IPLockAt}
+	for _, key := range []string{accountKey, ipKey} {
+		st, err := g.Store.Get(key)
+		if err != nil {
+			return 0, nil, err
+		}
+		if key == accountKey {
+			accountFailures = st.Failures
+		}
+		if limit := limits[key]; limit > 0 && st.Failures >= limit && !now.Before(st.LockedUntil) {
+			if err := g.Store.Lock(key, now.Add(g.Policy.LockDuration)); err != nil {
+				return 0, nil, err
+			}
+			lockedKeys = append(lockedKeys, key)
+		}
+	}
+	return accountFailures, lockedKeys, nil
+}
+
+// Pass: 비밀번호가 맞았을 때 Begin에서 미리 집계한 1회를 되돌림 (계정 초기화는 로그인이 끝난 뒤 Succeed에서)
+func (g *LoginGuard) Pass(accountKey, ipKey string) error {
+	return g.release([]string{accountKey, ipKey})
+}
+
+func (g *LoginGuard) release(keys []string) error {
+	var firstErr error
+	for _, key := range keys {
+		if err := g.Store.Release(key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Succeed: 로그인 성공 시 계정 집계 초기화 (IP 집계는 다른 계정 시도와 공유하므로 유지)
+func (g *LoginGuard) Succeed(accountKey string) error {
+	return g.Store.Reset(accountKey)
+}
+
+// MemoryAttemptStore: 단일 서버용 인메모리 저장소 (재시작 시 초기화)
+type MemoryAttemptStore struct {
+	mu        sync.Mutex
+	entries   map[string]*AttemptState
+	lastSweep time.Time
+}
+
+func NewMemoryAttemptStore() *MemoryAttemptStore {
+	return &MemoryAttemptStore{entries: make(map[string]*AttemptState)}
+}
+
+func (m *MemoryAttemptStore) Get(key string) (AttemptState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if st, ok := m.entries[key]; ok {
+		return *st, nil
+	}
+	return AttemptState{}, nil
+}
+
+func (m *MemoryAttemptStore) Acquire(key string, now time.Time, window time.Duration, blocked func(AttemptState) bool) (AttemptState, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now, window)
+	st, ok := m.entries[key]
+	if !ok {
+		st = &AttemptState{}
+		m.entries[key] = st
+	}
+	if blocked(*st) {
+		return *st, false, nil
+	}
+	if now.Sub(st.LastFailure) > window {
+		st.Failures = 0
+	}
+	st.Failures++
+	st.LastFailure = now
+	return *st, true, nil
+}
+
+func (m *MemoryAttemptStore) Release(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if st, ok := m.entries[key]; ok && st.Failures > 0 {
+		st.Failures--
+	}
+	return nil
+}
+
+// sweep: 오래된 기록 정리 (무작위 아이디로 시도해도 메모리가 계속 늘지 않도록)
+func (m *MemoryAttemptStore) sweep(now time.Time, window time.Duration) {
+	if now.Sub(m.lastSweep) < time.Minute {
+		return
+	}
+	m.lastSweep = now
+	for key, st := range m.entries {
+		if now.Sub(st.LastFailure) > window && !now.Before(st.LockedUntil) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+func (m *MemoryAttemptStore) Lock(key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st, ok := m.entries[key]
+	if !ok {
+		st = &AttemptState{}
+		m.entries[key] = st
+	}
+	st.LockedUntil = until
+	return nil
+}
+
+func (m *MemoryAttemptStore) Reset(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// failN: 계정을 바꿔 가며 같은 IP에서 n번 실패 (계정 대기에 걸리지 않도록 매번 다른 계정)
+func failN(t *testing.T, g *LoginGuard, ipKey string, n int, now time.Time) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		accountKey := AccountAttemptKey("USER", "member"+string(rune('a'+i)))
+		throttle, err := g.Begin(accountKey, ipKey, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if throttle != nil {
+			t.Fatalf("%d번째 시도 거절: %+v", i+1, throttle)
+		}
+		if _, _, err := g.Fail(accountKey, ipKey, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoginGuardIPHasNoDelayBelowLock(t *testing.T) {
+	policy := LockoutPolicy{FreeAttempts: 2, BaseDelay: time.Second, MaxDelay: time.Minute,
+		AccountLockAt: 5, IPLockAt: 20, LockDuration: 15 * time.Minute, FailureWindow: 30 * time.Minute}
+	g := &LoginGuard{Store: NewMemoryAttemptStore(), Policy: policy}
+	ipKey := IPAttemptKey("192.0.2.1")
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	// 같은 시각에 잠금 기준 직전까지 실패해도 IP 때문에 기다리지 않는다.
+	failN(t, g, ipKey, policy.IPLockAt-1, now)
+
+	// 기준에 도달하면 IP 전체가 잠긴다.
+	accountKey := AccountAttemptKey("USER", "lastone")
+	if throttle, err := g.Begin(accountKey, ipKey, now); err != nil || throttle != nil {
+		t.Fatalf("기준 도달 전 시도 거절: %+v, %v", throttle, err)
+	}
+	_, locked, err := g.Fail(accountKey, ipKey, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locked) != 1 || locked[0] != ipKey {
+		t.Fatalf("잠긴 키 = %v, want [%s]", locked, ipKey)
+	}
+
+	throttle, err := g.Begin(AccountAttemptKey("USER", "another"), ipKey, now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if throttle == nil || !throttle.Locked || throttle.Key != ipKey || throttle.RetryAfter != 14*time.Minute {
+		t.Errorf("잠금 후 시도 = %+v, want IP 잠금 14분", throttle)
+	}
+}
+
+func TestLoginGuardAccountCycle(t *testing.T) {
+	policy := LockoutPolicy{FreeAttempts: 2, BaseDelay: time.Second, MaxDelay: 4 * time.Second,
+		AccountLockAt: 5, IPLockAt: 20, LockDuration: 15 * time.Minute, FailureWindow: 30 * time.Minute}
+	g := &LoginGuard{Store: NewMemoryAttemptStore(), Policy: policy}
+	accountKey := AccountAttemptKey("USER", "member01")
+	ipKey := IPAttemptKey("192.0.2.1")
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	// 실패 n회 뒤 대기 시간: 무료 2회를 넘긴 3회째부터 1초, 이후 2배씩 (최대 4초)
+	wantDelays := []time.Duration{0, 0, 0, time.Second}
+	for i, delay := range wantDelays {
+		if delay > 0 {
+			throttle, err := g.Begin(accountKey, ipKey, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if throttle == nil || throttle.Locked || throttle.Key != accountKey || throttle.RetryAfter != delay {
+				t.Fatalf("%d회 실패 직후 = %+v, want 계정 대기 %v", i, throttle, delay)
+			}
+			now = now.Add(delay)
+		}
+		if throttle, err := g.Begin(accountKey, ipKey, now); err != nil || throttle != nil {
+			t.Fatalf("%d번째 시도 거절: %+v, %v", i+1, throttle, err)
+		}
+		failures, locked, err := g.Fail(accountKey, ipKey, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if failures != i+1 || len(locked) != 0 {
+			t.Fatalf("%d번째 실패: failures=%d locked=%v", i+1, failures, locked)
+		}
+	}
+
+	// 대기 중 동시에 들어온 요청은 거절되고 집계되지 않는다.
+	if throttle, _ := g.Begin(accountKey, ipKey, now); throttle == nil {
+		t.Fatal("대기 중 시도가 통과함")
+	}
+
+	// 5번째 실패에서 계정 잠금
+	now = now.Add(2 * time.Second)
+	if throttle, err := g.Begin(accountKey, ipKey, now); err != nil || throttle != nil {
+		t.Fatalf("5번째 시도 거절: %+v, %v", throttle, err)
+	}
+	failures, locked, err := g.Fail(accountKey, ipKey, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failures != 5 || len(locked) != 1 || locked[0] != accountKey {
+		t.Fatalf("5번째 실패: failures=%d locked=%v, want 계정 잠금", failures, locked)
+	}
+	throttle, err := g.Begin(accountKey, ipKey, now.Add(10*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if throttle == nil || !throttle.Locked || throttle.RetryAfter != 5*time.Minute {
+		t.Fatalf("잠금 중 시도 = %+v, want 잠금 5분 남음", throttle)
+	}
+
+	// 잠금이 끝나면 비밀번호가 맞는 시도는 예약을 되돌리고 계정 집계를 초기화한다.
+	now = now.Add(policy.LockDuration)
+	if throttle, err := g.Begin(accountKey, ipKey, now); err != nil || throttle != nil {
+		t.Fatalf("잠금 해제 후 시도 거절: %+v, %v", throttle, err)
+	}
+	if err := g.Pass(accountKey, ipKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Succeed(accountKey); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := g.Store.Get(accountKey); st.Failures != 0 {
+		t.Errorf("성공 후 계정 실패 %d회, want 0", st.Failures)
+	}
+	if st, _ := g.Store.Get(ipKey); st.Failures != 5 {
+		t.Errorf("성공 후 IP 실패 %d회, want 5 (IP 집계는 유지)", st.Failures)
+	}
+}
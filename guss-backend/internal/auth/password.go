@@ -1,12 +1,142 @@
 package auth
 
 import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword: 평문 비밀번호를 bcrypt로 해싱합니다.
+var (
+	ErrPasswordTooShort   = errors.New("비밀번호가 너무 짧습니다")
+	ErrPasswordTooLong    = errors.New("비밀번호가 너무 깁니다")
+	ErrPasswordTooSimple  = errors.New("비밀번호에 영문 대/소문자, 숫자, 특수문자를 섞어 주세요")
+	ErrPasswordContainsID = errors.New("비밀번호에 아이디를 포함할 수 없습니다")
+	ErrPasswordBreached   = errors.New("유출된 이력이 있는 비밀번호입니다. 다른 비밀번호를 사용해 주세요")
+)
+
+// PasswordPolicy: 비밀번호 규칙과 bcrypt 비용
+type PasswordPolicy struct {
+	MinLength  int
+	MaxLength  int                 // bcrypt는 72바이트까지만 반영하므로 그 이상은 거절
+	MinClasses int                 // 영문 소문자/대문자/숫자/특수문자 중 최소 포함 종류 수
+	BcryptCost int                 // 올리면 기존 해시는 다음 로그인 때 새 비용으로 다시 저장됨
+	Breached   map[string]struct{} // 유출 비밀번호 SHA-1 (대문자 hex), nil이면 검사 안 함
+}
+
+var DefaultPasswordPolicy = PasswordPolicy{MinLength: 8, MaxLength: 72, MinClasses: 2, BcryptCost: 12}
+
+var (
+	passwordPolicyMu sync.RWMutex
+	passwordPolicy   = DefaultPasswordPolicy
+)
+
+// SetPasswordPolicy: 서버 시작 시 비밀번호 규칙 지정
+func SetPasswordPolicy(p PasswordPolicy) {
+	passwordPolicyMu.Lock()
+	passwordPolicy = p
+	passwordPolicyMu.Unlock()
+}
+
+func currentPasswordPolicy() PasswordPolicy {
+	passwordPolicyMu.RLock()
+	defer passwordPolicyMu.RUnlock()
+	return passwordPolicy
+}
+
+// LoadBreachedPasswords: 유출 비밀번호 목록 파일 읽기 (한 줄에 하나, '#'으로 시작하면 주석)
+// 평문 비밀번호 또는 HIBP 형식의 SHA-1 해시("HASH" 또는 "HASH:건수")를 섞어 써도 된다.
+func LoadBreachedPasswords(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	set := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if hash, _, _ := strings.Cut(line, ":"); isSHA1Hex(hash) {
+			set[strings.ToUpper(hash)] = struct{}{}
+			continue
+		}
+		set[passwordSHA1(line)] = struct{}{}
+	}
+	return set, scanner.Err()
+}
+
+func isSHA1Hex(s string) bool {
+	if len(s) != sha1.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func passwordSHA1(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// ValidatePassword: 가입/재설정/변경 시 새 비밀번호 규칙 확인 (loginID가 있으면 아이디 포함 여부도 검사)
+func ValidatePassword(password, loginID string) error {
+	p := currentPasswordPolicy()
+
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("%w (최소 %d자)", ErrPasswordTooShort, p.MinLength)
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		return fmt.Errorf("%w (최대 %d바이트)", ErrPasswordTooLong, p.MaxLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, has := range []bool{lower, upper, digit, symbol} {
+		if has {
+			classes++
+		}
+	}
+	if classes < p.MinClasses {
+		return fmt.Errorf("%w (%d종류 이상)", ErrPasswordTooSimple, p.MinClasses)
+	}
+
+	if len(loginID) >= 3 && strings.Contains(strings.ToLower(password), strings.ToLower(loginID)) {
+		return ErrPasswordContainsID
+	}
+	if p.Breached != nil {
+		if _, found := p.Breached[passwordSHA1(password)]; found {
+			return ErrPasswordBreached
+		}
+	}
+	return nil
+}
+
+// HashPassword: 평문 비밀번호를 설정된 비용의 bcrypt로 해싱합니다.
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), currentPasswordPolicy().BcryptCost)
 	return string(bytes), err
 }
 
@@ -14,4 +144,34 @@ func HashPassword(password string) (string, error) {
 func CheckPasswordHash(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
-}
\ No newline at end of file
+}
+
+var dummyHash struct {
+	mu   sync.Mutex
+	cost int
+	hash []byte
+}
+
+// CheckDummyPassword: 없는 계정으로 로그인할 때도 실제 비교와 같은 비용의 bcrypt 비교를 수행
+// 응답 시간 차이로 아이디 존재 여부가 드러나지 않도록 한다. 결과는 항상 실패로 취급한다.
+func CheckDummyPassword(password string) {
+	cost := currentPasswordPolicy().BcryptCost
+	dummyHash.mu.Lock()
+	if dummyHash.hash == nil || dummyHash.cost != cost {
+		hash, err := bcrypt.GenerateFromPassword([]byte("guss-dummy-password"), cost)
+		if err != nil {
+			dummyHash.mu.Unlock()
+			return
+		}
+		dummyHash.hash, dummyHash.cost = hash, cost
+	}
+	hash := dummyHash.hash
+	dummyHash.mu.Unlock()
+	bcrypt.CompareHashAndPassword(hash, []byte(password))
+}
+
+// NeedsRehash: 저장된 해시의 비용이 현재 설정보다 낮으면 true (로그인 성공 직후 새 해시로 교체)
+func NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost < currentPasswordPolicy().BcryptCost
+}
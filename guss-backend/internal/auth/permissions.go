@@ -0,0 +1,57 @@
+package auth
+
+// 역할 (role_table의 role_code와 동일)
+const (
+	RoleUser       = "USER"        // 일반 회원
+	RoleGymStaff   = "GYM_STAFF"   // 지점 직원 (현장 업무)
+	RoleGymAdmin   = "GYM_ADMIN"   // 지점 관리자 (자기 지점 운영 전반)
+	RoleSuperAdmin = "SUPER_ADMIN" // 본사 관리자 (전 지점)
+)
+
+// 세부 권한 (role_permission_table의 permission과 동일)
+const (
+	PermDashboardView     = "dashboard:view"
+	PermReservationManage = "reservation:manage" // 예약 현황 조회, 노쇼 처리
+	PermEquipmentManage   = "equipment:manage"   // 기구 등록/수정/삭제
+	PermInventoryView     = "inventory:view"     // 자산(감가상각) 현황
+	PermProductManage     = "product:manage"
+	PermPassIssue         = "pass:issue" // 현장 이용권 발급
+	PermSalesView         = "sales:view"
+	PermSalesWrite        = "sales:write" // 수동 매출 기록
+	PermCouponManage      = "coupon:manage"
+	PermRefundManage      = "refund:manage"
+	PermAccountUnlock     = "account:unlock" // 로그인 잠금 해제 (관리자 계정/IP 해제는 gym:all 필요)
+	PermAllGyms           = "gym:all"        // 담당 지점과 무관하게 전 지점 접근
+)
+
+// DefaultRolePermissions: schema.sql의 기본 역할/권한 데이터와 같은 내용 (Mock 저장소에서 사용)
+var DefaultRolePermissions = map[string][]string{
+	RoleUser: {},
+	RoleGymStaff: {
+		PermDashboardView, PermReservationManage, PermPassIssue, PermInventoryView,
+	},
+	RoleGymAdmin: {
+		PermDashboardView, PermReservationManage, PermEquipmentManage, PermInventoryView, PermProductManage,
+		PermPassIssue, PermSalesView, PermSalesWrite, PermCouponManage, PermRefundManage, PermAccountUnlock,
+	},
+	RoleSuperAdmin: {
+		PermDashboardView, PermReservationManage, PermEquipmentManage, PermInventoryView, PermProductManage,
+		PermPassIssue, PermSalesView, PermSalesWrite, PermCouponManage, PermRefundManage, PermAccountUnlock, PermAllGyms,
+	},
+}
+
+// DefaultMFARequiredRoles: schema.sql의 role_table.mfa_required와 같은 내용 (Mock 저장소에서 사용)
+var DefaultMFARequiredRoles = map[string]bool{
+	RoleGymAdmin:   true,
+	RoleSuperAdmin: true,
+}
+
+// HasPermission: 토큰에 해당 권한이 포함되어 있는지 확인
+func (c *Claims) HasPermission(perm string) bool {
+	for _, p := range c.Permissions {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
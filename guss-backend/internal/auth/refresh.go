@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshTokenTTL: 리프레시 토큰 유효 기간 (사용할 때마다 새 토큰으로 교체됨)
+const RefreshTokenTTL = 14 * 24 * time.Hour
+
+// NewRefreshToken: 클라이언트에 전달할 무작위 리프레시 토큰과 서버 저장용 해시 생성
+func NewRefreshToken() (raw, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	raw = base64.RawURLEncoding.EncodeToString(buf)
+	return raw, HashRefreshToken(raw), nil
+}
+
+// HashRefreshToken: 리프레시 토큰은 원문 대신 SHA-256 해시로만 저장/조회
+func HashRefreshToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewTokenFamilyID: 로그인 한 번에서 이어지는 리프레시 토큰 묶음 ID
+func NewTokenFamilyID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
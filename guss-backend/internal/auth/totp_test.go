@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// RFC 6238 부록 B의 SHA1 비밀키 ("12345678901234567890")
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestVerifyTOTPRFCVector(t *testing.T) {
+	// T=59 -> 8자리 94287082, 6자리 287082 (step 1)
+	step, ok := VerifyTOTP(rfcSecret, "287082", time.Unix(59, 0))
+	if !ok || step != 1 {
+		t.Errorf("VerifyTOTP = %d, %v; want 1, true", step, ok)
+	}
+}
+
+func TestVerifyTOTPSkew(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfcSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 19, 9, 0, 10, 0, time.UTC)
+	current := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name   string
+		offset int64
+		ok     bool
+	}{
+		{"현재 구간", 0, true},
+		{"1구간 전 (30초 지연)", -1, true},
+		{"1구간 후 (기기 시계 빠름)", 1, true},
+		{"2구간 전", -2, false},
+		{"2구간 후", 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := totpCode(key, current+tt.offset)
+			step, ok := VerifyTOTP(rfcSecret, code, now)
+			if ok != tt.ok {
+				t.Fatalf("VerifyTOTP ok = %v, want %v", ok, tt.ok)
+			}
+			// 일치한 구간을 돌려줘야 호출자가 재사용을 막을 수 있다.
+			if ok && step != current+tt.offset {
+				t.Errorf("step = %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestVerifyTOTPRejectsMalformedInput(t *testing.T) {
+	now := time.Unix(59, 0)
+	for _, tt := range []struct{ name, secret, code string }{
+		{"자릿수 부족", rfcSecret, "28708"},
+		{"8자리 코드", rfcSecret, "94287082"},
+		{"비밀키 형식 오류", "not-base32!", "287082"},
+		{"다른 코드", rfcSecret, "000000"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := VerifyTOTP(tt.secret, tt.code, now); ok {
+				t.Error("잘못된 입력이 통과함")
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// 문자/이메일로 보내는 1회용 인증 코드 (비밀번호 재설정 등)
+const (
+	VerificationCodeTTL         = 10 * time.Minute
+	VerificationCodeMaxAttempts = 5 // 한 코드에 허용하는 오입력 횟수 (초과 시 코드 폐기)
+	verificationCodeDigits      = 6
+)
+
+// NewVerificationCode: 6자리 숫자 코드와 저장용 해시 생성
+func NewVerificationCode() (code, hash string, err error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", "", err
+	}
+	code = fmt.Sprintf("%0*d", verificationCodeDigits, n.Int64())
+	return code, HashVerificationCode(code), nil
+}
+
+func HashVerificationCode(code string) string {
+	return HashRefreshToken(strings.TrimSpace(code))
+}
+
+// CheckVerificationCode: 입력 코드가 저장된 해시와 일치하는지 (비교 시간 일정)
+func CheckVerificationCode(code, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashVerificationCode(code)), []byte(hash)) == 1
+}
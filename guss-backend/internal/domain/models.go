@@ -2,18 +2,88 @@ package domain
 
 import (
 	"database/sql"
+	"strconv"
 	"time"
 )
 
 // 1. 사용자 정보 (user_table)
+// API 입출력에는 api 패키지의 요청/응답 타입을 쓰며, 실수로 인코딩되더라도 비밀번호 해시는 빠진다.
 type User struct {
 	UserNumber int64  `json:"user_number" db:"user_number"`
 	UserName   string `json:"user_name"   db:"user_name"`
 	UserPhone  string `json:"user_phone"  db:"user_phone"`
 	UserID     string `json:"user_id"     db:"user_id"`
-	UserPW     string `json:"user_pw"     db:"user_pw"`
+	UserPW     string `json:"-"           db:"user_pw"`
+
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at" db:"phone_verified_at"`
 }
 
+// 탈퇴 회원 표시 이름 (탈퇴 시 이름/아이디/휴대폰 번호를 익명화하고 행은 이력 보존용으로 남긴다)
+const DeletedUserName = "탈퇴회원"
+
+// DeletedUserID: 탈퇴 회원에게 남기는 아이디 (UNIQUE 유지를 위해 회원 번호 기반, 탈퇴 감사 로그에도 사용)
+func DeletedUserID(userNum int64) string {
+	return "deleted_" + strconv.FormatInt(userNum, 10)
+}
+
+// 1-2. 비밀번호 재설정 코드 (password_reset_table), 코드 원문은 문자로만 보내고 해시만 보관
+// 새 코드를 요청하면 이전 코드는 폐기되며, 사용했거나 오입력 한도를 넘긴 코드는 UsedAt이 채워진다.
+type PasswordReset struct {
+	ResetNumber int64      `json:"reset_number" db:"reset_number"`
+	UserNumber  int64      `json:"user_number"  db:"fk_user_number"`
+	CodeHash    string     `json:"-"            db:"code_hash"`
+	Attempts    int        `json:"attempts"     db:"attempts"` // 오입력 횟수
+	ExpiresAt   time.Time  `json:"expires_at"   db:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"   db:"created_at"`
+	UsedAt      *time.Time `json:"used_at"      db:"used_at"`
+}
+
+// 1-3. 가입 대기 (signup_verification_table), 휴대폰 인증을 마치면 user_table로 옮겨지고 삭제된다.
+type PendingSignup struct {
+	SignupNumber int64     `json:"signup_number" db:"signup_number"`
+	UserID       string    `json:"user_id"       db:"user_id"`
+	UserName     string    `json:"user_name"     db:"user_name"`
+	UserPhone    string    `json:"user_phone"    db:"user_phone"` // 숫자만 (01012345678)
+	PasswordHash string    `json:"-"             db:"user_pw"`
+	CodeHash     string    `json:"-"             db:"code_hash"`
+	Attempts     int       `json:"attempts"      db:"attempts"`
+	ExpiresAt    time.Time `json:"expires_at"    db:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"    db:"created_at"`
+}
+
+// 1-4. 휴대폰 번호 변경 대기 (phone_change_table), 새 번호로 보낸 코드를 확인해야 반영된다.
+type PhoneChange struct {
+	ChangeNumber int64      `json:"change_number" db:"change_number"`
+	UserNumber   int64      `json:"user_number"   db:"fk_user_number"`
+	NewPhone     string     `json:"new_phone"     db:"new_phone"` // 숫자만 (01012345678)
+	CodeHash     string     `json:"-"             db:"code_hash"`
+	Attempts     int        `json:"attempts"      db:"attempts"`
+	ExpiresAt    time.Time  `json:"expires_at"    db:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"    db:"created_at"`
+	UsedAt       *time.Time `json:"used_at"       db:"used_at"`
+}
+
+// 1-1. 리프레시 토큰 (refresh_token_table), 원문은 저장하지 않고 해시만 보관
+// 같은 로그인에서 교체되며 이어진 토큰은 FamilyID를 공유하고, 이미 쓴 토큰이 다시 오면 묶음 전체를 폐기한다.
+type RefreshToken struct {
+	TokenNumber     int64      `json:"token_number"     db:"token_number"`
+	TokenHash       string     `json:"-"                db:"token_hash"`
+	FamilyID        string     `json:"family_id"        db:"family_id"`
+	PrincipalType   string     `json:"principal_type"   db:"principal_type"` // USER / ADMIN
+	PrincipalNumber int64      `json:"principal_number" db:"principal_number"`
+	PrincipalID     string     `json:"principal_id"     db:"principal_id"` // 로그인 ID
+	ExpiresAt       time.Time  `json:"expires_at"       db:"expires_at"`
+	CreatedAt       time.Time  `json:"created_at"       db:"created_at"`
+	UsedAt          *time.Time `json:"used_at"          db:"used_at"`    // 새 토큰으로 교체된 시각
+	RevokedAt       *time.Time `json:"revoked_at"       db:"revoked_at"` // 로그아웃/재사용 감지로 폐기된 시각
+}
+
+// 토큰 주체 유형 (user_table / admin_table)
+const (
+	PrincipalUser  = "USER"
+	PrincipalAdmin = "ADMIN"
+)
+
 // 2. 체육관 정보 (guss_table)
 type Gym struct {
 	GussNumber    int64  `json:"guss_number"    db:"guss_number"`
@@ -36,6 +106,14 @@ type Equipment struct {
 	Quantity     int    `json:"quantity"       db:"equip_quantity"` // equip_quantity -> quantity
 	Status       string `json:"status"         db:"equip_status"`   // equip_status -> status
 	PurchaseDate string `json:"purchaseDate"   db:"purchase_date"`  // purchase_date -> purchaseDate
+
+	// 회계용 자산 정보 (단가 기준, 원 단위)
+	PurchasePrice      int64  `json:"purchasePrice"      db:"purchase_price"`
+	Supplier           string `json:"supplier"           db:"supplier"`
+	WarrantyExpiry     string `json:"warrantyExpiry"     db:"warranty_expiry"`
+	DepreciationMethod string `json:"depreciationMethod" db:"depreciation_method"` // straight_line / declining_balance
+	UsefulLifeYears    int    `json:"usefulLifeYears"    db:"useful_life_years"`   // 내용연수
+	SalvageValue       int64  `json:"salvageValue"       db:"salvage_value"`       // 잔존가치
 }
 
 // 4. 예약 정보 (revs_table)
@@ -48,10 +126,289 @@ type Reservation struct {
 	UserName   string    `json:"user_name,omitempty"`
 }
 
+// 예약 상태 (revs_table.revs_status)
+const (
+	RevsConfirmed = "CONFIRMED"
+	RevsCompleted = "COMPLETED" // 퇴실
+	RevsNoShow    = "NO_SHOW"   // 관리자가 미방문 처리 (환불 불가 사유)
+)
+
+// 기구 예약 상태 (equip_booking_table.booking_status)
+const (
+	BookingBooked    = "BOOKED"
+	BookingCancelled = "CANCELLED"
+	BookingReleased  = "RELEASED" // 퇴실 시 자동 반납
+)
+
+// 4-1. 기구 예약 정보 (equip_booking_table)
+type EquipmentBooking struct {
+	BookingNumber int64     `json:"booking_number" db:"booking_number"`
+	EquipID       int64     `json:"equip_id"       db:"fk_equip_id"`
+	UserNumber    int64     `json:"user_number"    db:"fk_user_number"`
+	RevsNumber    int64     `json:"revs_number"    db:"fk_revs_number"`
+	UnitNo        int       `json:"unit_no"        db:"unit_no"`
+	StartTime     time.Time `json:"start_time"     db:"start_time"`
+	EndTime       time.Time `json:"end_time"       db:"end_time"`
+	Status        string    `json:"status"         db:"booking_status"`
+}
+
+// 상품 유형 (product_table.product_type)
+const (
+	ProductDayPass    = "DAY_PASS"   // 일일권 (1회)
+	ProductVisitPass  = "VISIT_PASS" // N회권
+	ProductMembership = "MEMBERSHIP" // 기간제 회원권 (무제한)
+)
+
+// 이용권 상태 (pass_table.pass_status)
+const (
+	PassActive    = "ACTIVE"
+	PassUsedUp    = "USED_UP"
+	PassCancelled = "CANCELLED"
+)
+
+// 4-2. 상품 정보 (product_table)
+type Product struct {
+	ProductNumber int64  `json:"product_number" db:"product_number"`
+	GymID         int64  `json:"gym_id"         db:"fk_guss_number"`
+	Name          string `json:"name"           db:"product_name"`
+	Type          string `json:"type"           db:"product_type"`
+	Price         int64  `json:"price"          db:"product_price"`
+	VisitCount    int    `json:"visit_count"    db:"visit_count"` // 0이면 기간 내 무제한
+	ValidityDays  int    `json:"validity_days"  db:"validity_days"`
+	IsActive      bool   `json:"is_active"      db:"is_active"`
+}
+
+// 4-3. 회원 이용권 (pass_table)
+type Pass struct {
+	PassNumber      int64     `json:"pass_number"      db:"pass_number"`
+	UserNumber      int64     `json:"user_number"      db:"fk_user_number"`
+	ProductNumber   int64     `json:"product_number"   db:"fk_product_number"`
+	GymID           int64     `json:"gym_id"           db:"fk_guss_number"`
+	RemainingVisits *int      `json:"remaining_visits" db:"remaining_visits"` // nil이면 무제한
+	StartsAt        time.Time `json:"starts_at"        db:"starts_at"`
+	ExpiresAt       time.Time `json:"expires_at"       db:"expires_at"`
+	Status          string    `json:"status"           db:"pass_status"`
+	ProductName     string    `json:"product_name,omitempty"`
+	ProductType     string    `json:"product_type,omitempty"`
+}
+
+// 4-4. 이용권 일시정지 이력 (pass_freeze_table), 구간은 [StartAt, EndAt)
+type PassFreeze struct {
+	FreezeNumber int64     `json:"freeze_number" db:"freeze_number"`
+	PassNumber   int64     `json:"pass_number"   db:"fk_pass_number"`
+	StartAt      time.Time `json:"start_at"      db:"start_at"`
+	EndAt        time.Time `json:"end_at"        db:"end_at"`
+	Days         int       `json:"days"          db:"freeze_days"`
+	CreatedAt    time.Time `json:"created_at"    db:"created_at"`
+}
+
+// 주문 상태 (order_table.order_status)
+const (
+	OrderPending  = "PENDING"
+	OrderPaid     = "PAID"
+	OrderFailed   = "FAILED"
+	OrderRefunded = "REFUNDED" // 전액/부분 환불 완료 (이용권은 취소됨)
+)
+
+// 4-5. 주문 정보 (order_table)
+type Order struct {
+	OrderNumber   int64      `json:"order_number"   db:"order_number"`
+	UserNumber    int64      `json:"user_number"    db:"fk_user_number"`
+	GymID         int64      `json:"gym_id"         db:"fk_guss_number"`
+	ProductNumber int64      `json:"product_number" db:"fk_product_number"`
+	ProductName   string     `json:"product_name"   db:"product_name"` // 이하 4개는 주문 시점 상품 정보 (빈 값이면 마이그레이션 이전 주문)
+	ProductType   string     `json:"product_type"   db:"product_type"`
+	VisitCount    int        `json:"visit_count"    db:"visit_count"`
+	ValidityDays  int        `json:"validity_days"  db:"validity_days"`
+	Amount        int64      `json:"amount"         db:"order_amount"` // 실제 결제 금액 (할인 후)
+	ListPrice     int64      `json:"list_price"     db:"list_price"`   // 할인 전 상품 가격
+	Discount      int64      `json:"discount"       db:"discount_amount"`
+	CouponNumber  int64      `json:"coupon_number,omitempty" db:"fk_coupon_number"`
+	CouponCode    string     `json:"coupon_code,omitempty"   db:"coupon_code"`
+	Status        string     `json:"status"         db:"order_status"`
+	PaymentID     string     `json:"payment_id"     db:"payment_id"`
+	PassNumber    int64      `json:"pass_number,omitempty"  db:"fk_pass_number"`
+	SalesNumber   int64      `json:"sales_number,omitempty" db:"fk_sales_number"`
+	CreatedAt     time.Time  `json:"created_at"     db:"created_at"`
+	PaidAt        *time.Time `json:"paid_at"        db:"paid_at"`
+}
+
+// 쿠폰 할인 방식 (coupon_table.discount_type)
+const (
+	DiscountPercent = "PERCENT" // 정률 할인 (DiscountValue %)
+	DiscountFixed   = "FIXED"   // 정액 할인 (DiscountValue 원)
+	DiscountFree    = "FREE"    // 전액 무료
+)
+
+// 쿠폰 사용 상태 (coupon_redemption_table.redemption_status)
+const (
+	RedemptionReserved = "RESERVED" // 결제 대기 주문에 적용됨 (한도에 포함)
+	RedemptionRedeemed = "REDEEMED" // 결제 완료
+	RedemptionReleased = "RELEASED" // 결제 실패로 반환
+)
+
+// 4-6. 쿠폰 정보 (coupon_table + coupon_target_table)
+// GymIDs/ProductNumbers가 비어 있으면 전체 지점/상품에 적용, ProductType이 있으면 해당 유형 상품만
+type Coupon struct {
+	CouponNumber      int64     `json:"coupon_number"       db:"coupon_number"`
+	Code              string    `json:"code"                db:"coupon_code"`
+	Name              string    `json:"name"                db:"coupon_name"`
+	DiscountType      string    `json:"discount_type"       db:"discount_type"`
+	DiscountValue     int64     `json:"discount_value"      db:"discount_value"`
+	MaxDiscount       int64     `json:"max_discount"        db:"max_discount"` // 정률 할인 최대 금액 (0이면 제한 없음)
+	ProductType       string    `json:"product_type"        db:"product_type"`
+	StartsAt          time.Time `json:"starts_at"           db:"starts_at"`
+	EndsAt            time.Time `json:"ends_at"             db:"ends_at"`
+	TotalLimit        int       `json:"total_limit"         db:"total_limit"`    // 전체 사용 한도 (0이면 무제한)
+	PerUserLimit      int       `json:"per_user_limit"      db:"per_user_limit"` // 회원당 사용 한도 (0이면 무제한)
+	FirstPurchaseOnly bool      `json:"first_purchase_only" db:"first_purchase_only"`
+	IsActive          bool      `json:"is_active"           db:"is_active"`
+	GymIDs            []int64   `json:"gym_ids"`
+	ProductNumbers    []int64   `json:"product_numbers"`
+	UsedCount         int       `json:"used_count"` // 결제 대기 + 완료 건수
+}
+
+// CouponUsage: 쿠폰별 사용 실적 (관리자 리포트)
+type CouponUsage struct {
+	CouponNumber  int64  `json:"coupon_number"`
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Redeemed      int    `json:"redeemed"`       // 결제 완료 건수
+	Reserved      int    `json:"reserved"`       // 결제 대기 건수
+	UniqueUsers   int    `json:"unique_users"`   // 사용 회원 수
+	TotalDiscount int64  `json:"total_discount"` // 결제 완료 건의 할인 합계
+	NetRevenue    int64  `json:"net_revenue"`    // 결제 완료 건의 실제 결제 금액 합계
+}
+
+// 환불 상태 (refund_table.refund_status)
+const (
+	RefundRequested    = "REQUESTED"     // 관리자 승인 대기 (또는 결제사 환불 실패 후 재시도 대기)
+	RefundProcessing   = "PROCESSING"    // 결제사 환불 진행 중 (중복 승인 방지)
+	RefundProviderDone = "PROVIDER_DONE" // 결제사 환불 완료, 장부 기록 대기 (기록 실패 시 재승인하면 기록만 마무리)
+	RefundCompleted    = "REFUNDED"
+	RefundRejected     = "REJECTED"
+)
+
+// 매출 유형: 환불은 음수 금액으로 매출 장부에 기록
+const SalesTypeRefund = "REFUND"
+
+// 4-7. 환불 정보 (refund_table)
+type Refund struct {
+	RefundNumber int64      `json:"refund_number"  db:"refund_number"`
+	OrderNumber  int64      `json:"order_number"   db:"fk_order_number"`
+	UserNumber   int64      `json:"user_number"    db:"fk_user_number"`
+	GymID        int64      `json:"gym_id"         db:"fk_guss_number"`
+	PaidAmount   int64      `json:"paid_amount"    db:"paid_amount"`
+	Amount       int64      `json:"amount"         db:"refund_amount"`
+	Status       string     `json:"status"         db:"refund_status"`
+	Reason       string     `json:"reason"         db:"refund_reason"`
+	AdminNote    string     `json:"admin_note,omitempty"   db:"admin_note"`
+	ProcessedBy  string     `json:"processed_by,omitempty" db:"processed_by"` // 승인/거절한 관리자 ID (자동 환불은 빈 값)
+	SalesNumber  int64      `json:"sales_number,omitempty" db:"fk_sales_number"`
+	CreatedAt    time.Time  `json:"created_at"     db:"created_at"`
+	ProcessedAt  *time.Time `json:"processed_at"   db:"processed_at"`
+}
+
 // 5. 관리자 정보 (admin_table)
 type Admin struct {
 	AdminNumber int64         `json:"admin_number"   db:"admin_number"`
 	AdminID     string        `json:"admin_id"       db:"admin_id"`
 	AdminPW     string        `json:"-"              db:"admin_pw"`
+	AdminRole   string        `json:"admin_role"     db:"admin_role"` // GYM_STAFF / GYM_ADMIN / SUPER_ADMIN
 	FKGussID    sql.NullInt64 `json:"fk_guss_number"`
 }
+
+// 5-1. 관리자 OTP 2단계 인증 (admin_mfa_table), EnabledAt이 nil이면 등록 진행 중(미활성)
+type AdminMFA struct {
+	AdminNumber  int64      `json:"admin_number"   db:"fk_admin_number"`
+	Secret       string     `json:"-"              db:"totp_secret"`
+	EnabledAt    *time.Time `json:"enabled_at"     db:"enabled_at"`
+	LastUsedStep int64      `json:"-"              db:"last_used_step"` // 마지막으로 사용된 OTP 시간 구간 (재사용 방지)
+}
+
+// 6. 매출 정보 (sales_table)
+type Sale struct {
+	SalesNumber int64     `json:"sales_number" db:"sales_number"`
+	GymID       int64     `json:"gym_id"       db:"fk_guss_number"`
+	SalesType   string    `json:"type"         db:"sales_type"`   // 'DAILY', 'MONTHLY' 등
+	SalesAmount int64     `json:"amount"       db:"sales_amount"` // 원 단위
+	SalesDate   time.Time `json:"date"         db:"sales_date"`
+	UserNumber  int64     `json:"user_number,omitempty"  db:"fk_user_number"`  // 구매 회원 (수동 기록은 0)
+	OrderNumber int64     `json:"order_number,omitempty" db:"fk_order_number"` // 온라인 결제 주문
+
+	Items []ReceiptLine `json:"-"` // 영수증 항목 (기록 시에만 사용, 비어 있으면 매출 유형 한 줄)
+}
+
+// 6-1. 영수증 (receipt_table + receipt_line_table), 매출 한 건당 하나씩 지점별 일련번호로 발행
+type Receipt struct {
+	ReceiptNumber int64         `json:"receipt_number" db:"receipt_number"`
+	ReceiptNo     string        `json:"receipt_no"` // 표시용 번호 (지점-일련번호)
+	GymID         int64         `json:"gym_id"         db:"fk_guss_number"`
+	Seq           int64         `json:"seq"            db:"receipt_seq"` // 지점별 일련번호
+	SalesNumber   int64         `json:"sales_number"   db:"fk_sales_number"`
+	UserNumber    int64         `json:"user_number,omitempty"  db:"fk_user_number"`
+	OrderNumber   int64         `json:"order_number,omitempty" db:"fk_order_number"`
+	IssuedAt      time.Time     `json:"issued_at"      db:"issued_at"`
+	Total         int64         `json:"total"          db:"total_amount"`  // 부가세 포함 합계 (환불은 음수)
+	Supply        int64         `json:"supply"         db:"supply_amount"` // 공급가액
+	VAT           int64         `json:"vat"            db:"vat_amount"`
+	GymName       string        `json:"gym_name"`
+	GymAddress    string        `json:"gym_address"`
+	GymPhone      string        `json:"gym_phone"`
+	Lines         []ReceiptLine `json:"lines"`
+}
+
+type ReceiptLine struct {
+	Description string `json:"description" db:"line_description"`
+	Quantity    int    `json:"quantity"    db:"quantity"`
+	UnitPrice   int64  `json:"unit_price"  db:"unit_price"`
+	Amount      int64  `json:"amount"      db:"line_amount"`
+}
+
+// SalesFilter: 매출 조회 조건 (0/빈 값은 조건 없음, To는 미포함)
+type SalesFilter struct {
+	GymID     int64
+	From      time.Time
+	To        time.Time
+	SalesType string
+}
+
+// RevenueBucket: 기간(일/주/월) x 지점 x 매출 유형 단위 매출 집계
+type RevenueBucket struct {
+	PeriodStart   string   `json:"period_start"` // 한국 시간 기준 기간 시작일 (YYYY-MM-DD)
+	GymID         int64    `json:"gym_id,omitempty"`
+	SalesType     string   `json:"type,omitempty"`
+	Total         int64    `json:"total"`          // 환불을 뺀 순매출
+	Count         int      `json:"count"`          // 판매 건수 (환불 제외)
+	AverageTicket int64    `json:"average_ticket"` // 판매 1건당 평균 금액 (환불 제외)
+	RefundCount   int      `json:"refund_count"`
+	RefundAmount  int64    `json:"refund_amount"` // 환불 금액 합계 (양수)
+	PrevTotal     int64    `json:"prev_total"`
+	ChangeRate    *float64 `json:"change_rate"` // 직전 기간 대비 증감률(%), 직전 매출이 0이면 null
+}
+
+// 7. 기구 자산 평가 리포트 (지점별 재고 평가)
+type InventoryValuationItem struct {
+	Equipment
+	UnitBookValue           int64   `json:"unitBookValue"`
+	TotalCost               int64   `json:"totalCost"`
+	AccumulatedDepreciation int64   `json:"accumulatedDepreciation"`
+	BookValue               int64   `json:"bookValue"`
+	ElapsedYears            float64 `json:"elapsedYears"`
+}
+
+type InventoryValuation struct {
+	GymID                   int64                    `json:"gym_id"`
+	AsOf                    string                   `json:"as_of"`
+	Items                   []InventoryValuationItem `json:"items"`
+	TotalCost               int64                    `json:"total_cost"`
+	AccumulatedDepreciation int64                    `json:"accumulated_depreciation"`
+	BookValue               int64                    `json:"book_value"`
+}
+
+// 8. 회원 활동 로그 (로그 저장소, 감사 이벤트 포함)
+type ActivityLog struct {
+	UserID string    `json:"user_id"`
+	Action string    `json:"action"`
+	At     time.Time `json:"at"`
+}
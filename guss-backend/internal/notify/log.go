@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// LogSender: 로컬 실행용 발송기 - 실제로 보내지 않고 서버 로그에 남긴다.
+// 인증 코드가 로그에 그대로 찍히므로 운영 환경에서는 사용하면 안 된다.
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (l *LogSender) Send(ctx context.Context, msg Message) error {
+	if strings.TrimSpace(msg.To) == "" {
+		return ErrNoRecipient
+	}
+	log.Printf("[FAKE %s] -> %s: %s", strings.ToUpper(msg.Channel), maskRecipient(msg.To), msg.Body)
+	return nil
+}
+
+// maskRecipient: 로그에 연락처 전체가 남지 않도록 가운데를 가림
+func maskRecipient(to string) string {
+	if len(to) <= 4 {
+		return "****"
+	}
+	return to[:3] + strings.Repeat("*", len(to)-5) + to[len(to)-2:]
+}
@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+	"errors"
+)
+
+// 발송 채널
+const (
+	ChannelSMS   = "sms"
+	ChannelEmail = "email"
+)
+
+var ErrNoRecipient = errors.New("수신자 정보가 없습니다")
+
+// Message: 회원에게 보내는 단건 알림 (인증 코드 등)
+type Message struct {
+	Channel string // ChannelSMS / ChannelEmail
+	To      string // 휴대폰 번호 또는 이메일 주소
+	Subject string // 이메일 제목 (SMS는 무시)
+	Body    string
+}
+
+// Sender: 문자/이메일 발송 추상화 (실제 발송 업체 연동 전까지는 LogSender 사용)
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
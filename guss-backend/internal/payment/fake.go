@@ -0,0 +1,213 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// webhookTolerance: 서명 타임스탬프 허용 오차 (재전송 공격 방지)
+const webhookTolerance = 5 * time.Minute
+
+// FakeProvider: 로컬 실행/테스트용 인메모리 결제사
+// 매입(Capture)과 환불(Refund) 시 실제 결제사처럼 서명된 웹훅을 WebhookURL로 비동기 전송한다.
+type FakeProvider struct {
+	secret     []byte
+	WebhookURL string // 비어 있으면 웹훅을 보내지 않음
+
+	mu       sync.Mutex
+	payments map[string]*Payment
+	client   *http.Client
+}
+
+func NewFakeProvider(secret, webhookURL string) *FakeProvider {
+	return &FakeProvider{
+		secret:     []byte(secret),
+		WebhookURL: webhookURL,
+		payments:   make(map[string]*Payment),
+		client:     &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (f *FakeProvider) Authorize(ctx context.Context, req AuthorizeRequest) (*Payment, error) {
+	if req.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	p := &Payment{
+		ID:      "fake_pay_" + hex.EncodeToString(buf),
+		OrderID: req.OrderID,
+		Amount:  req.Amount,
+		Status:  StatusAuthorized,
+	}
+
+	f.mu.Lock()
+	f.payments[p.ID] = p
+	f.mu.Unlock()
+
+	log.Printf("[FAKE PG] 승인: %s (주문 %s, %d원)", p.ID, req.OrderID, req.Amount)
+	copied := *p
+	return &copied, nil
+}
+
+func (f *FakeProvider) Capture(ctx context.Context, paymentID string, amount int64) (*Payment, error) {
+	f.mu.Lock()
+	p, ok := f.payments[paymentID]
+	if !ok {
+		f.mu.Unlock()
+		return nil, ErrPaymentNotFound
+	}
+	if p.Status != StatusAuthorized {
+		f.mu.Unlock()
+		return nil, ErrInvalidState
+	}
+	if amount <= 0 || amount > p.Amount {
+		f.mu.Unlock()
+		return nil, ErrInvalidAmount
+	}
+	p.CapturedAmount = amount
+	p.Status = StatusCaptured
+	copied := *p
+	f.mu.Unlock()
+
+	log.Printf("[FAKE PG] 매입: %s (%d원)", paymentID, amount)
+	f.deliver(Event{Type: EventCaptured, PaymentID: p.ID, OrderID: p.OrderID, Amount: amount, OccurredAt: time.Now()})
+	return &copied, nil
+}
+
+func (f *FakeProvider) Void(ctx context.Context, paymentID string) (*Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.payments[paymentID]
+	if !ok {
+		return nil, ErrPaymentNotFound
+	}
+	if p.Status != StatusAuthorized {
+		return nil, ErrInvalidState
+	}
+	p.Status = StatusCanceled
+	copied := *p
+
+	log.Printf("[FAKE PG] 승인 취소: %s", paymentID)
+	return &copied, nil
+}
+
+func (f *FakeProvider) Refund(ctx context.Context, paymentID string, amount int64) (*Payment, error) {
+	f.mu.Lock()
+	p, ok := f.payments[paymentID]
+	if !ok {
+		f.mu.Unlock()
+		return nil, ErrPaymentNotFound
+	}
+	if p.Status != StatusCaptured {
+		f.mu.Unlock()
+		return nil, ErrInvalidState
+	}
+	if amount <= 0 || p.RefundedAmount+amount > p.CapturedAmount {
+		f.mu.Unlock()
+		return nil, ErrInvalidAmount
+	}
+	p.RefundedAmount += amount
+	if p.RefundedAmount == p.CapturedAmount {
+		p.Status = StatusRefunded
+	}
+	copied := *p
+	f.mu.Unlock()
+
+	log.Printf("[FAKE PG] 환불: %s (%d원)", paymentID, amount)
+	f.deliver(Event{Type: EventRefunded, PaymentID: p.ID, OrderID: p.OrderID, Amount: amount, OccurredAt: time.Now()})
+	return &copied, nil
+}
+
+// Sign: 페이로드에 대한 웹훅 서명 헤더 값 생성
+func (f *FakeProvider) Sign(payload []byte, at time.Time) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + ts + ",v1=" + f.mac(ts, payload)
+}
+
+func (f *FakeProvider) VerifyWebhook(payload []byte, signature string) (*Event, error) {
+	var ts, sig string
+	for _, part := range strings.Split(signature, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+	if ts == "" || sig == "" {
+		return nil, ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if d := time.Since(time.Unix(unix, 0)); d > webhookTolerance || d < -webhookTolerance {
+		return nil, ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(f.mac(ts, payload))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var ev Event
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return nil, fmt.Errorf("웹훅 페이로드 해석 실패: %w", err)
+	}
+	return &ev, nil
+}
+
+func (f *FakeProvider) mac(ts string, payload []byte) string {
+	h := hmac.New(sha256.New, f.secret)
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// deliver: 서명된 웹훅을 비동기로 전송 (실패 시 로그만 남김)
+func (f *FakeProvider) deliver(ev Event) {
+	if f.WebhookURL == "" {
+		return
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	sig := f.Sign(payload, time.Now())
+
+	go func() {
+		req, err := http.NewRequest(http.MethodPost, f.WebhookURL, bytes.NewReader(payload))
+		if err != nil {
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(SignatureHeader, sig)
+
+		resp, err := f.client.Do(req)
+		if err != nil {
+			log.Printf("[FAKE PG] 웹훅 전송 실패 (%s): %v", ev.Type, err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			log.Printf("[FAKE PG] 웹훅 응답 코드 %d (%s)", resp.StatusCode, ev.Type)
+		}
+	}()
+}
@@ -0,0 +1,48 @@
+package payment
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFakeProviderVerifyWebhook(t *testing.T) {
+	f := NewFakeProvider("test-secret", "")
+	payload := []byte(`{"type":"payment.captured","payment_id":"fake_pay_1","order_id":"7","amount":10000}`)
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		signature string
+		wantErr   bool
+	}{
+		{"정상 서명", payload, f.Sign(payload, now), false},
+		{"허용 오차 안쪽의 지난 서명", payload, f.Sign(payload, now.Add(-4*time.Minute)), false},
+		{"오래된 타임스탬프", payload, f.Sign(payload, now.Add(-6*time.Minute)), true},
+		{"미래 타임스탬프", payload, f.Sign(payload, now.Add(6*time.Minute)), true},
+		{"다른 비밀키로 서명", payload, NewFakeProvider("other-secret", "").Sign(payload, now), true},
+		{"본문 변조", []byte(strings.Replace(string(payload), "10000", "100", 1)), f.Sign(payload, now), true},
+		{"서명 값 없음", payload, "t=" + f.Sign(payload, now)[2:12], true},
+		{"타임스탬프 형식 오류", payload, "t=abc,v1=00", true},
+		{"빈 헤더", payload, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := f.VerifyWebhook(tt.payload, tt.signature)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSignature) {
+					t.Errorf("err = %v, want ErrInvalidSignature", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if ev.Type != EventCaptured || ev.PaymentID != "fake_pay_1" || ev.Amount != 10000 {
+				t.Errorf("이벤트 = %+v", ev)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// 결제 상태
+const (
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusRefunded   = "refunded" // 전액 환불
+	StatusCanceled   = "canceled" // 매입 전 승인 취소
+	StatusFailed     = "failed"
+)
+
+// 웹훅 이벤트 유형
+const (
+	EventCaptured = "payment.captured"
+	EventFailed   = "payment.failed"
+	EventRefunded = "payment.refunded"
+)
+
+// SignatureHeader: 웹훅 서명이 담기는 헤더 ("t=<unix>,v1=<hex hmac-sha256>")
+const SignatureHeader = "X-Guss-Signature"
+
+var (
+	ErrPaymentNotFound  = errors.New("결제 정보를 찾을 수 없습니다")
+	ErrInvalidAmount    = errors.New("결제 금액이 올바르지 않습니다")
+	ErrInvalidState     = errors.New("현재 결제 상태에서 처리할 수 없는 요청입니다")
+	ErrInvalidSignature = errors.New("웹훅 서명이 유효하지 않습니다")
+)
+
+type AuthorizeRequest struct {
+	OrderID     string
+	Amount      int64 // 원 단위
+	Description string
+}
+
+type Payment struct {
+	ID             string
+	OrderID        string
+	Amount         int64
+	CapturedAmount int64
+	RefundedAmount int64
+	Status         string
+}
+
+// Event: 결제사에서 웹훅으로 전달되는 결제 이벤트
+type Event struct {
+	Type       string    `json:"type"`
+	PaymentID  string    `json:"payment_id"`
+	OrderID    string    `json:"order_id"`
+	Amount     int64     `json:"amount"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// PaymentProvider: 결제사 연동 추상화 (승인 -> 매입 -> 환불, 매입 전 승인 취소, 웹훅 서명 검증)
+type PaymentProvider interface {
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Payment, error)
+	Capture(ctx context.Context, paymentID string, amount int64) (*Payment, error)
+	// Void: 매입되지 않은 승인을 취소해 카드 한도 묶임을 푼다 (이미 매입된 결제는 ErrInvalidState)
+	Void(ctx context.Context, paymentID string) (*Payment, error)
+	Refund(ctx context.Context, paymentID string, amount int64) (*Payment, error)
+	VerifyWebhook(payload []byte, signature string) (*Event, error)
+}
@@ -0,0 +1,100 @@
+package privacy
+
+import (
+	"fmt"
+	"guss-backend/internal/domain"
+	"guss-backend/internal/repository"
+	"time"
+)
+
+// ArchiveFormat: 내보내기 파일 형식 버전 (필드를 빼거나 의미를 바꾸면 올린다)
+const ArchiveFormat = "guss-personal-data/1"
+
+// Archive: 회원 한 명에 대해 보관 중인 개인정보 전체 (개인정보 열람/이동 요구 대응용 JSON)
+type Archive struct {
+	Format      string    `json:"format"`
+	GeneratedAt time.Time `json:"generated_at"`
+
+	Profile           Profile                   `json:"profile"`
+	Reservations      []domain.Reservation      `json:"reservations"`
+	EquipmentBookings []domain.EquipmentBooking `json:"equipment_bookings"`
+	Passes            []PassRecord              `json:"passes"`
+	Orders            []domain.Order            `json:"orders"` // 결제 내역 (payment_id는 결제사 거래 번호)
+	Refunds           []domain.Refund           `json:"refunds"`
+	Receipts          []domain.Receipt          `json:"receipts"`
+	ActivityLogs      []domain.ActivityLog      `json:"activity_logs"`
+}
+
+// Profile: 회원 기본 정보 (비밀번호 해시 등 인증 정보는 개인정보 열람 대상이 아니므로 제외)
+type Profile struct {
+	UserNumber      int64      `json:"user_number"`
+	UserID          string     `json:"user_id"`
+	UserName        string     `json:"user_name"`
+	UserPhone       string     `json:"user_phone"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+}
+
+// PassRecord: 이용권과 일시정지 이력
+type PassRecord struct {
+	domain.Pass
+	Freezes []domain.PassFreeze `json:"freezes"`
+}
+
+// BuildArchive: 회원의 프로필, 예약, 이용권, 결제, 활동 로그를 모아 내보내기 파일 구성
+// logs가 nil이면 활동 로그는 빈 목록으로 둔다.
+func BuildArchive(repo repository.Repository, logs repository.LogRepository, user *domain.User) (*Archive, error) {
+	a := &Archive{
+		Format:      ArchiveFormat,
+		GeneratedAt: time.Now().UTC(),
+		Profile: Profile{
+			UserNumber:      user.UserNumber,
+			UserID:          user.UserID,
+			UserName:        user.UserName,
+			UserPhone:       user.UserPhone,
+			PhoneVerifiedAt: user.PhoneVerifiedAt,
+		},
+		ActivityLogs: []domain.ActivityLog{},
+	}
+
+	var err error
+	if a.Reservations, err = repo.GetReservationsByUser(user.UserNumber); err != nil {
+		return nil, fmt.Errorf("예약 조회: %w", err)
+	}
+	if a.EquipmentBookings, err = repo.GetEquipmentBookingsByUser(user.UserNumber); err != nil {
+		return nil, fmt.Errorf("기구 예약 조회: %w", err)
+	}
+
+	passes, err := repo.GetPassesByUser(user.UserNumber)
+	if err != nil {
+		return nil, fmt.Errorf("이용권 조회: %w", err)
+	}
+	a.Passes = make([]PassRecord, 0, len(passes))
+	for _, p := range passes {
+		freezes, err := repo.GetPassFreezes(p.PassNumber)
+		if err != nil {
+			return nil, fmt.Errorf("이용권 %d 정지 이력 조회: %w", p.PassNumber, err)
+		}
+		a.Passes = append(a.Passes, PassRecord{Pass: p, Freezes: freezes})
+	}
+
+	if a.Orders, err = repo.GetOrdersByUser(user.UserNumber); err != nil {
+		return nil, fmt.Errorf("주문 조회: %w", err)
+	}
+	if a.Refunds, err = repo.GetRefundsByUser(user.UserNumber); err != nil {
+		return nil, fmt.Errorf("환불 조회: %w", err)
+	}
+	// 처리한 관리자 아이디는 회원 본인의 정보가 아니므로 내보내지 않는다.
+	for i := range a.Refunds {
+		a.Refunds[i].ProcessedBy = ""
+	}
+	if a.Receipts, err = repo.GetReceiptsByUser(user.UserNumber); err != nil {
+		return nil, fmt.Errorf("영수증 조회: %w", err)
+	}
+
+	if logs != nil {
+		if a.ActivityLogs, err = logs.GetUserLogs(user.UserID); err != nil {
+			return nil, fmt.Errorf("활동 로그 조회: %w", err)
+		}
+	}
+	return a, nil
+}
@@ -0,0 +1,101 @@
+package privacy
+
+import (
+	"context"
+	"guss-backend/internal/repository"
+	"log"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// RetentionPolicy: 기록 종류별 보존 기간 (0이면 정리하지 않음)
+type RetentionPolicy struct {
+	AuthData          time.Duration // 만료된 인증 코드/리프레시 토큰, 오래된 로그인 실패 집계
+	Reservations      time.Duration // 예약/기구 예약 이력
+	PaymentRecords    time.Duration // 이용권/주문/환불 (매출/영수증은 금액만 남기고 회원 연결 해제)
+	WithdrawnAccounts time.Duration // 탈퇴 후 익명화된 회원 행과 남은 이력
+	ActivityLogs      time.Duration // 로그 저장소의 회원 활동 로그
+}
+
+// DefaultRetentionPolicy: 결제/계약 기록 5년, 예약 이력 3년 (전자상거래법 보관 기간 기준), 활동 로그는 로그 TTL과 같은 30일
+var DefaultRetentionPolicy = RetentionPolicy{
+	AuthData:          30 * day,
+	Reservations:      3 * 365 * day,
+	PaymentRecords:    5 * 365 * day,
+	WithdrawnAccounts: 5 * 365 * day,
+	ActivityLogs:      30 * day,
+}
+
+// RetentionResult: 기록 종류 하나의 정리 결과
+type RetentionResult struct {
+	Category string
+	Before   time.Time // 이 시각 이전 기록이 대상
+	Affected int64     // 삭제 또는 익명화된 행/항목 수
+	Err      error
+}
+
+// RetentionEngine: 보존 기간이 지난 개인정보를 MySQL과 로그 저장소에서 정리
+// 모든 정리는 조건부 DELETE/UPDATE라 여러 서버에서 동시에 돌아도 결과가 같다.
+type RetentionEngine struct {
+	Repo   repository.Repository
+	Logs   repository.LogRepository // nil이면 활동 로그는 건너뜀
+	Policy RetentionPolicy
+}
+
+// Run: 한 번 정리 실행 (한 종류가 실패해도 나머지는 계속 진행)
+// 탈퇴 회원 행 삭제는 FK CASCADE로 이력까지 지우므로 다른 종류를 먼저 정리한 뒤 마지막에 실행한다.
+func (e *RetentionEngine) Run(now time.Time) []RetentionResult {
+	steps := []struct {
+		category string
+		period   time.Duration
+		purge    func(before time.Time) (int64, error)
+	}{
+		{"auth_data", e.Policy.AuthData, e.Repo.PurgeExpiredAuthData},
+		{"reservations", e.Policy.Reservations, e.Repo.PurgeReservations},
+		{"payment_records", e.Policy.PaymentRecords, e.Repo.PurgePaymentRecords},
+		{"activity_logs", e.Policy.ActivityLogs, e.purgeActivityLogs},
+		{"withdrawn_accounts", e.Policy.WithdrawnAccounts, e.Repo.PurgeWithdrawnUsers},
+	}
+
+	var results []RetentionResult
+	for _, step := range steps {
+		if step.period <= 0 {
+			continue
+		}
+		res := RetentionResult{Category: step.category, Before: now.Add(-step.period)}
+		res.Affected, res.Err = step.purge(res.Before)
+		if res.Err != nil {
+			log.Printf("[RETENTION ERROR] %s 정리 실패 (%d건 처리 후): %v", res.Category, res.Affected, res.Err)
+		} else if res.Affected > 0 {
+			log.Printf("[RETENTION] %s: %s 이전 기록 %d건 정리", res.Category, res.Before.Format(time.RFC3339), res.Affected)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func (e *RetentionEngine) purgeActivityLogs(before time.Time) (int64, error) {
+	if e.Logs == nil {
+		return 0, nil
+	}
+	n, err := e.Logs.PurgeUserLogsBefore(before)
+	return int64(n), err
+}
+
+// Start: 시작 직후 한 번, 이후 interval마다 정리 (ctx가 끝나면 종료)
+func (e *RetentionEngine) Start(ctx context.Context, interval time.Duration) {
+	log.Printf("--- [RETENTION] 보존 기간 정리 시작 (주기: %s) ---", interval)
+	e.Run(time.Now())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			e.Run(now)
+		}
+	}
+}
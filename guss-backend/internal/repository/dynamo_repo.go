@@ -2,13 +2,19 @@ package repository
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"guss-backend/internal/domain"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
 /* // [주의] 이 부분은 repository.go와 중복되므로 주석 처리합니다.
@@ -18,23 +24,47 @@ type LogRepository interface {
 }
 */
 
+// dynamoLogClient: 로그 저장소가 쓰는 DynamoDB API (테스트에서 메모리 구현으로 대체)
+type dynamoLogClient interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+}
+
+// UserLogTimeIndex: 보존 기간 정리용 GSI (파티션 키 LogType(S), 정렬 키 LoggedAt(N), 키만 프로젝션)
+// 회원 활동 로그에만 LogType이 있으므로 기구 로그는 인덱스에 들어가지 않는다.
+const (
+	UserLogTimeIndex = "LogType-LoggedAt-index"
+	userLogType      = "USER_ACT"
+)
+
 // dynamoLogRepo: DynamoDB를 사용하는 로그 저장소 실체
 type dynamoLogRepo struct {
-	client *dynamodb.Client
+	client dynamoLogClient
+	now    func() time.Time
 }
 
 // NewDynamoLogRepository: DynamoDB 저장소 생성함수
 func NewDynamoLogRepository(client *dynamodb.Client) LogRepository {
 	return &dynamoLogRepo{
 		client: client,
+		now:    time.Now,
 	}
 }
 
+// logSortKey: "<prefix>#<유닉스 나노초 19자리>#<임의 8자>" - 같은 순간에 기록한 로그끼리도 키가 겹쳐 덮어쓰지 않도록
+// 나노초를 자릿수 고정으로 넣어 같은 PK 안에서 SK 순서가 기록 순서와 같다.
+func logSortKey(prefix string, at time.Time) string {
+	var suffix [4]byte
+	rand.Read(suffix[:])
+	return fmt.Sprintf("%s#%019d#%s", prefix, at.UnixNano(), hex.EncodeToString(suffix[:]))
+}
+
 // SaveEqLog: 기구 상태 변경 로그를 DynamoDB에 저장
 func (d *dynamoLogRepo) SaveEqLog(gussNumber int64, equipID, status string) error {
 	item := map[string]interface{}{
 		"PK":          fmt.Sprintf("GYM#%d", gussNumber),
-		"SK":          fmt.Sprintf("EQ#%s#%d", equipID, time.Now().Unix()),
+		"SK":          logSortKey("EQ#"+equipID, d.now()),
 		"GussNumber":  gussNumber,
 		"EquipID":     equipID,
 		"Status":      status,
@@ -59,13 +89,16 @@ func (d *dynamoLogRepo) SaveEqLog(gussNumber int64, equipID, status string) erro
 
 // SaveUserLog: 사용자 활동 로그를 DynamoDB에 저장
 func (d *dynamoLogRepo) SaveUserLog(userID, action string) error {
+	now := d.now()
 	item := map[string]interface{}{
 		"PK":        fmt.Sprintf("USER#%s", userID),
-		"SK":        fmt.Sprintf("ACT#%d", time.Now().Unix()),
+		"SK":        logSortKey("ACT", now),
 		"UserID":    userID,
 		"Action":    action,
-		"Timestamp": time.Now().Format(time.RFC3339),
-		"TTL":       time.Now().Add(time.Hour * 24 * 30).Unix(), // 30일 후 자동 삭제
+		"LogType":   userLogType,
+		"LoggedAt":  now.UnixNano(),
+		"Timestamp": now.Format(time.RFC3339),
+		"TTL":       now.Add(time.Hour * 24 * 30).Unix(), // 30일 후 자동 삭제
 	}
 
 	av, err := attributevalue.MarshalMap(item)
@@ -82,4 +115,149 @@ func (d *dynamoLogRepo) SaveUserLog(userID, action string) error {
 		log.Printf("DynamoDB 유저 로그 저장 실패: %v", err)
 	}
 	return err
-}
\ No newline at end of file
+}
+// userLogItem: guss_logs의 회원 활동 로그 항목 (SK는 "ACT#<유닉스 나노초>#<임의 값>", 이전 형식은 "ACT#<유닉스 초>")
+type userLogItem struct {
+	PK       string `dynamodbav:"PK"`
+	SK       string `dynamodbav:"SK"`
+	UserID   string `dynamodbav:"UserID"`
+	Action   string `dynamodbav:"Action"`
+	LoggedAt int64  `dynamodbav:"LoggedAt"` // 유닉스 나노초 (이전 형식 항목에는 없음)
+}
+
+// loggedAt: 기록 시각 (Timestamp 문자열은 서버 시간대에 따라 형식이 달라 비교에 쓰지 않음)
+// LoggedAt 속성이 없는 이전 형식 항목은 SK의 유닉스 초를 사용한다.
+func (it userLogItem) loggedAt() (time.Time, bool) {
+	if it.LoggedAt > 0 {
+		return time.Unix(0, it.LoggedAt), true
+	}
+	sec, err := strconv.ParseInt(strings.TrimPrefix(it.SK, "ACT#"), 10, 64)
+	if err != nil || !strings.HasPrefix(it.SK, "ACT#") {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0), true
+}
+
+func (d *dynamoLogRepo) queryUserLogs(userID string, keysOnly bool) ([]userLogItem, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String("guss_logs"),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: fmt.Sprintf("USER#%s", userID)},
+		},
+	}
+	if keysOnly {
+		input.ProjectionExpression = aws.String("PK, SK")
+	}
+
+	var items []userLogItem
+	pages := dynamodb.NewQueryPaginator(d.client, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		var batch []userLogItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return nil, err
+		}
+		items = append(items, batch...)
+	}
+	return items, nil
+}
+
+// GetUserLogs: 회원 활동 로그 전체 (개인정보 열람용, 오래된 순)
+func (d *dynamoLogRepo) GetUserLogs(userID string) ([]domain.ActivityLog, error) {
+	items, err := d.queryUserLogs(userID, false)
+	if err != nil {
+		log.Printf("DynamoDB 유저 로그 조회 실패: %v", err)
+		return nil, err
+	}
+	logs := make([]domain.ActivityLog, 0, len(items))
+	for _, it := range items {
+		at, _ := it.loggedAt()
+		logs = append(logs, domain.ActivityLog{UserID: it.UserID, Action: it.Action, At: at})
+	}
+	return logs, nil
+}
+
+// DeleteUserLogs: 회원 활동 로그 전체 삭제 (탈퇴 시)
+func (d *dynamoLogRepo) DeleteUserLogs(userID string) (int, error) {
+	items, err := d.queryUserLogs(userID, true)
+	if err != nil {
+		return 0, err
+	}
+	return d.deleteItems(items)
+}
+
+// PurgeUserLogsBefore: 보존 기간이 지난 회원 활동 로그 삭제
+// 항목마다 TTL(30일)이 있지만 DynamoDB의 TTL 삭제는 지연될 수 있어 보존 기간 정리에서 한 번 더 지운다.
+// 테이블 전체를 읽지 않도록 LoggedAt 인덱스에서 기준 시각 이전 항목의 키만 조회한다.
+// (LoggedAt이 없는 이전 형식 항목은 인덱스에 없으므로 TTL로만 삭제된다.)
+func (d *dynamoLogRepo) PurgeUserLogsBefore(before time.Time) (int, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String("guss_logs"),
+		IndexName:              aws.String(UserLogTimeIndex),
+		KeyConditionExpression: aws.String("LogType = :type AND LoggedAt < :before"),
+		ProjectionExpression:   aws.String("PK, SK"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":type":   &types.AttributeValueMemberS{Value: userLogType},
+			":before": &types.AttributeValueMemberN{Value: strconv.FormatInt(before.UnixNano(), 10)},
+		},
+	}
+
+	deleted := 0
+	pages := dynamodb.NewQueryPaginator(d.client, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(context.TODO())
+		if err != nil {
+			return deleted, err
+		}
+		var batch []userLogItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return deleted, err
+		}
+		n, err := d.deleteItems(batch)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// deleteItems: BatchWriteItem 한도(25건)씩 나눠 삭제하고, 처리되지 않은 항목은 다시 요청
+func (d *dynamoLogRepo) deleteItems(items []userLogItem) (int, error) {
+	deleted := 0
+	for start := 0; start < len(items); start += 25 {
+		end := start + 25
+		if end > len(items) {
+			end = len(items)
+		}
+		requests := make([]types.WriteRequest, 0, end-start)
+		for _, it := range items[start:end] {
+			requests = append(requests, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: it.PK},
+				"SK": &types.AttributeValueMemberS{Value: it.SK},
+			}}})
+		}
+
+		pending := map[string][]types.WriteRequest{"guss_logs": requests}
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt == 5 {
+				return deleted, fmt.Errorf("DynamoDB 로그 삭제 재시도 초과 (%d건 남음)", len(pending["guss_logs"]))
+			}
+			if attempt > 0 {
+				time.Sleep(time.Duration(attempt) * 200 * time.Millisecond)
+			}
+			out, err := d.client.BatchWriteItem(context.TODO(), &dynamodb.BatchWriteItemInput{RequestItems: pending})
+			if err != nil {
+				log.Printf("DynamoDB 로그 삭제 실패: %v", err)
+				return deleted, err
+			}
+			pending = out.UnprocessedItems
+		}
+		deleted += end - start
+	}
+	return deleted, nil
+}
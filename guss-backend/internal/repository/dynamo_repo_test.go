@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// memoryDynamo: guss_logs 테이블을 흉내 내는 메모리 구현 (같은 PK+SK로 쓰면 DynamoDB처럼 덮어쓴다)
+type memoryDynamo struct {
+	items   map[string]map[string]types.AttributeValue
+	queries []*dynamodb.QueryInput
+}
+
+func newMemoryDynamo() *memoryDynamo {
+	return &memoryDynamo{items: make(map[string]map[string]types.AttributeValue)}
+}
+
+func attrS(item map[string]types.AttributeValue, name string) string {
+	if v, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return v.Value
+	}
+	return ""
+}
+
+func itemKey(item map[string]types.AttributeValue) string {
+	return attrS(item, "PK") + "|" + attrS(item, "SK")
+}
+
+func (m *memoryDynamo) PutItem(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	m.items[itemKey(in.Item)] = in.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func attrN(item map[string]types.AttributeValue, name string) (int64, bool) {
+	v, ok := item[name].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(v.Value, 10, 64)
+	return n, err == nil
+}
+
+// Query: 기본 테이블의 "PK = :pk"와 LoggedAt 인덱스의 "LogType = :type AND LoggedAt < :before"만 지원
+// 인덱스 조회는 실제 GSI(키만 프로젝션)처럼 LogType이 있는 항목의 PK/SK만 돌려준다.
+func (m *memoryDynamo) Query(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	m.queries = append(m.queries, in)
+	var out []map[string]types.AttributeValue
+	if in.IndexName != nil {
+		logType := in.ExpressionAttributeValues[":type"].(*types.AttributeValueMemberS).Value
+		before, _ := strconv.ParseInt(in.ExpressionAttributeValues[":before"].(*types.AttributeValueMemberN).Value, 10, 64)
+		for _, item := range m.items {
+			if at, ok := attrN(item, "LoggedAt"); ok && attrS(item, "LogType") == logType && at < before {
+				out = append(out, map[string]types.AttributeValue{"PK": item["PK"], "SK": item["SK"]})
+			}
+		}
+		return &dynamodb.QueryOutput{Items: out}, nil
+	}
+
+	pk := in.ExpressionAttributeValues[":pk"].(*types.AttributeValueMemberS).Value
+	for _, item := range m.items {
+		if attrS(item, "PK") == pk {
+			out = append(out, item)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return attrS(out[i], "SK") < attrS(out[j], "SK") })
+	return &dynamodb.QueryOutput{Items: out}, nil
+}
+
+func (m *memoryDynamo) BatchWriteItem(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	for _, requests := range in.RequestItems {
+		for _, req := range requests {
+			if req.DeleteRequest != nil {
+				delete(m.items, itemKey(req.DeleteRequest.Key))
+			}
+		}
+	}
+	return &dynamodb.BatchWriteItemOutput{}, nil
+}
+
+func TestSaveUserLogKeepsEventsInSameSecond(t *testing.T) {
+	db := newMemoryDynamo()
+	at := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	repo := &dynamoLogRepo{client: db, now: func() time.Time { return at }}
+
+	// 실패 기록 직후 잠금 기록 (recordLoginFailure와 같은 순서, 시각까지 완전히 같은 경우)
+	if err := repo.SaveUserLog("member01", "LOGIN_FAILED ip=192.0.2.1 failures=5"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveUserLog("member01", "LOGIN_LOCKED key=acct:USER:member01"); err != nil {
+		t.Fatal(err)
+	}
+	if len(db.items) != 2 {
+		t.Fatalf("저장된 항목 %d건, want 2 (같은 초의 로그가 덮어써짐)", len(db.items))
+	}
+
+	logs, err := repo.GetUserLogs("member01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("조회된 로그 %d건, want 2", len(logs))
+	}
+	actions := map[string]bool{}
+	for _, l := range logs {
+		actions[l.Action] = true
+		if !l.At.Equal(at) {
+			t.Errorf("%s 기록 시각 = %v, want %v", l.Action, l.At, at)
+		}
+	}
+	if !actions["LOGIN_FAILED ip=192.0.2.1 failures=5"] || !actions["LOGIN_LOCKED key=acct:USER:member01"] {
+		t.Errorf("조회된 로그 = %+v", logs)
+	}
+}
+
+func TestUserLogItemLoggedAt(t *testing.T) {
+	tests := []struct {
+		name string
+		item userLogItem
+		want time.Time
+		ok   bool
+	}{
+		{"LoggedAt 속성", userLogItem{SK: "ACT#1760864400000000123#0a1b2c3d", LoggedAt: 1760864400000000123}, time.Unix(0, 1760864400000000123), true},
+		{"이전 형식 SK", userLogItem{SK: "ACT#1760864400"}, time.Unix(1760864400, 0), true},
+		{"다른 종류 항목", userLogItem{SK: "EQ#3#1760864400"}, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.item.loggedAt()
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("loggedAt() = %v, %v; want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPurgeUserLogsBeforeUsesTimeIndex(t *testing.T) {
+	db := newMemoryDynamo()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	repo := &dynamoLogRepo{client: db}
+
+	for _, entry := range []struct {
+		user string
+		at   time.Time
+	}{
+		{"member01", now.AddDate(0, 0, -400)},
+		{"member01", now.AddDate(0, 0, -10)},
+		{"member02", now.AddDate(0, 0, -366)},
+		{"member02", now},
+	} {
+		at := entry.at
+		repo.now = func() time.Time { return at }
+		if err := repo.SaveUserLog(entry.user, "LOGIN"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo.now = func() time.Time { return now }
+	if err := repo.SaveEqLog(1, "3", "BROKEN"); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := repo.PurgeUserLogsBefore(now.AddDate(-1, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 2 {
+		t.Errorf("삭제 %d건, want 2", deleted)
+	}
+	last := db.queries[len(db.queries)-1]
+	if last.IndexName == nil || *last.IndexName != UserLogTimeIndex {
+		t.Errorf("보존 기간 정리가 %s 인덱스를 쓰지 않음", UserLogTimeIndex)
+	}
+
+	for user, want := range map[string]int{"member01": 1, "member02": 1} {
+		logs, err := repo.GetUserLogs(user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(logs) != want {
+			t.Errorf("%s 남은 로그 %d건, want %d", user, len(logs), want)
+		}
+	}
+	if len(db.items) != 3 {
+		t.Errorf("남은 항목 %d건, want 3 (기구 로그는 유지)", len(db.items))
+	}
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"database/sql"
+	"guss-backend/internal/auth"
 	"guss-backend/internal/domain"
 	"log"
+	"time"
 )
 
 type MockRepository struct{}
@@ -24,20 +26,186 @@ func (m *MockRepository) GetUserByID(id string) (*domain.User, error) {
 		UserNumber: 1,
 		UserID:     id,
 		UserName:   "Mock일반유저",
+		UserPhone:  "01000000000",
 		UserPW:     "$2a$10$Wp6S7Vf4X.0pGzXz9XyYduzI6.R8z8L5v5.m7Gz8z8z8z8z8z8z8z", // '1234'의 해시
 	}, nil
 }
 
+// 1-0. 비밀번호 재설정 Mock (항상 코드 123456이 발급된 상태로 취급)
+func (m *MockRepository) CreatePasswordReset(pr *domain.PasswordReset) error {
+	pr.ResetNumber = 1
+	return nil
+}
+
+func (m *MockRepository) GetActivePasswordReset(userNum int64) (*domain.PasswordReset, error) {
+	return &domain.PasswordReset{ResetNumber: 1, UserNumber: userNum, CodeHash: auth.HashVerificationCode("123456"),
+		ExpiresAt: time.Now().Add(auth.VerificationCodeTTL / 2), CreatedAt: time.Now().Add(-auth.VerificationCodeTTL / 2)}, nil
+}
+
+func (m *MockRepository) UsePasswordResetAttempt(resetNum int64, maxAttempts int) error {
+	return nil
+}
+
+func (m *MockRepository) CompletePasswordReset(resetNum, userNum int64, passwordHash string) error {
+	log.Printf("[MOCK] Password Reset: user %d, all sessions revoked", userNum)
+	return nil
+}
+
+func (m *MockRepository) RehashPassword(principalType string, principalNumber int64, oldHash, newHash string) error {
+	log.Printf("[MOCK] Password Rehashed: %s %d", principalType, principalNumber)
+	return nil
+}
+
+// 1-0-1. 가입 인증 Mock (중복 없음, 어떤 번호든 코드 123456으로 대기 중인 것으로 취급)
+func (m *MockRepository) CheckUserDuplicate(userID, phone string) (bool, bool, error) {
+	return false, false, nil
+}
+
+func (m *MockRepository) CreatePendingSignup(ps *domain.PendingSignup) error {
+	ps.SignupNumber = 1
+	return nil
+}
+
+func (m *MockRepository) GetPendingSignup(phone string) (*domain.PendingSignup, error) {
+	return &domain.PendingSignup{SignupNumber: 1, UserID: "mockuser", UserName: "Mock일반유저", UserPhone: phone,
+		CodeHash: auth.HashVerificationCode("123456"), ExpiresAt: time.Now().Add(auth.VerificationCodeTTL / 2),
+		CreatedAt: time.Now().Add(-auth.VerificationCodeTTL / 2)}, nil
+}
+
+func (m *MockRepository) UsePendingSignupAttempt(signupNum int64, maxAttempts int) error {
+	return nil
+}
+
+func (m *MockRepository) CompleteSignup(signupNum int64) (*domain.User, error) {
+	ps, _ := m.GetPendingSignup("01000000000")
+	log.Printf("[MOCK] User Created: %v", ps.UserName)
+	return &domain.User{UserNumber: 1, UserID: ps.UserID, UserName: ps.UserName, UserPhone: ps.UserPhone}, nil
+}
+
+// 1-0-2. 회원 정보 관리 Mock (번호 변경은 항상 코드 123456으로 대기 중인 것으로 취급)
+func (m *MockRepository) GetUserByNumber(userNum int64) (*domain.User, error) {
+	u, _ := m.GetUserByID("mockuser")
+	u.UserNumber = userNum
+	return u, nil
+}
+
+func (m *MockRepository) UpdateUserName(userNum int64, name string) error {
+	log.Printf("[MOCK] User %d Renamed: %s", userNum, name)
+	return nil
+}
+
+func (m *MockRepository) CreatePhoneChange(pc *domain.PhoneChange) error {
+	pc.ChangeNumber = 1
+	return nil
+}
+
+func (m *MockRepository) GetActivePhoneChange(userNum int64) (*domain.PhoneChange, error) {
+	return &domain.PhoneChange{ChangeNumber: 1, UserNumber: userNum, NewPhone: "01099999999", CodeHash: auth.HashVerificationCode("123456"),
+		ExpiresAt: time.Now().Add(auth.VerificationCodeTTL / 2), CreatedAt: time.Now().Add(-auth.VerificationCodeTTL / 2)}, nil
+}
+
+func (m *MockRepository) UsePhoneChangeAttempt(changeNum int64, maxAttempts int) error {
+	return nil
+}
+
+func (m *MockRepository) CompletePhoneChange(changeNum, userNum int64) (string, error) {
+	pc, _ := m.GetActivePhoneChange(userNum)
+	log.Printf("[MOCK] User %d Phone Changed", userNum)
+	return pc.NewPhone, nil
+}
+
+func (m *MockRepository) ChangePassword(userNum int64, oldHash, newHash string) error {
+	log.Printf("[MOCK] Password Changed: user %d, all sessions revoked", userNum)
+	return nil
+}
+
+func (m *MockRepository) AnonymizeUser(userNum int64) error {
+	log.Printf("[MOCK] User %d Anonymized", userNum)
+	return nil
+}
+
+// 1-1. 리프레시 토큰 Mock (어떤 토큰이든 mock 유저의 유효한 토큰으로 취급)
+func (m *MockRepository) SaveRefreshToken(t *domain.RefreshToken) error {
+	t.TokenNumber = 1
+	return nil
+}
+
+func (m *MockRepository) GetRefreshToken(hash string) (*domain.RefreshToken, error) {
+	return &domain.RefreshToken{TokenNumber: 1, TokenHash: hash, FamilyID: "mockfamily", PrincipalType: domain.PrincipalUser,
+		PrincipalNumber: 1, PrincipalID: "mockuser", ExpiresAt: time.Now().Add(time.Hour), CreatedAt: time.Now()}, nil
+}
+
+func (m *MockRepository) RotateRefreshToken(oldHash string, next *domain.RefreshToken) (*domain.RefreshToken, error) {
+	old, _ := m.GetRefreshToken(oldHash)
+	next.TokenNumber = 2
+	next.FamilyID, next.PrincipalType, next.PrincipalNumber, next.PrincipalID = old.FamilyID, old.PrincipalType, old.PrincipalNumber, old.PrincipalID
+	return old, nil
+}
+
+func (m *MockRepository) RevokeTokenFamily(familyID string) error {
+	log.Printf("[MOCK] Token Family Revoked: %s", familyID)
+	return nil
+}
+
+func (m *MockRepository) RevokeAllRefreshTokens(principalType string, principalNumber int64) error {
+	log.Printf("[MOCK] All Tokens Revoked: %s %d", principalType, principalNumber)
+	return nil
+}
+
 // 2. [추가] 관리자 관련 Mock (오류 해결 지점)
 func (m *MockRepository) GetAdminByID(id string) (*domain.Admin, error) {
 	return &domain.Admin{
 		AdminNumber: 1,
 		AdminID:     id,
 		AdminPW:     "$2a$10$7cQkLrgVQGuNCvYyONufFOwO3EwmBl1H.1lJ1y906WRBaNTH2t1Fe",
+		AdminRole:   auth.RoleGymAdmin,
 		FKGussID:    sql.NullInt64{Int64: 1, Valid: true},
 	}, nil
 }
 
+func (m *MockRepository) GetRolePermissions(role string) ([]string, error) {
+	perms, ok := auth.DefaultRolePermissions[role]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return perms, nil
+}
+
+// 2-1. 관리자 OTP Mock (등록 기록이 없는 상태로 동작)
+func (m *MockRepository) GetRoleMFARequired(role string) (bool, error) {
+	if _, ok := auth.DefaultRolePermissions[role]; !ok {
+		return false, ErrNotFound
+	}
+	return auth.DefaultMFARequiredRoles[role], nil
+}
+
+func (m *MockRepository) GetAdminMFA(adminNum int64) (*domain.AdminMFA, error) {
+	return nil, ErrNotFound
+}
+
+func (m *MockRepository) SaveAdminMFASecret(adminNum int64, secret string) error {
+	log.Printf("[MOCK] Admin MFA Secret Saved: %d", adminNum)
+	return nil
+}
+
+func (m *MockRepository) ActivateAdminMFA(adminNum, step int64, recoveryHashes []string) error {
+	log.Printf("[MOCK] Admin MFA Activated: %d (%d recovery codes)", adminNum, len(recoveryHashes))
+	return nil
+}
+
+func (m *MockRepository) UseAdminTOTPStep(adminNum, step int64) error {
+	return nil
+}
+
+func (m *MockRepository) UseRecoveryCode(adminNum int64, hash string) (int, error) {
+	return 0, ErrNotFound
+}
+
+func (m *MockRepository) DisableAdminMFA(adminNum int64) error {
+	log.Printf("[MOCK] Admin MFA Disabled: %d", adminNum)
+	return nil
+}
+
 // 3. 체육관 관련 Mock
 func (m *MockRepository) GetGyms() ([]domain.Gym, error) {
 	return []domain.Gym{
@@ -64,35 +232,368 @@ func (m *MockRepository) GetReservationsByGym(gymID int64) ([]domain.Reservation
 	return []domain.Reservation{}, nil
 }
 
+func (m *MockRepository) GetReservationsByUser(userNum int64) ([]domain.Reservation, error) {
+	return []domain.Reservation{
+		{RevsNumber: 1, FKUserID: userNum, FKGussID: 1, RevsTime: time.Now().AddDate(0, 0, -7), RevsStatus: domain.RevsCompleted},
+	}, nil
+}
+
+func (m *MockRepository) CheckOut(userNum int64) error {
+	log.Printf("[MOCK] Check-out: User %d", userNum)
+	return nil
+}
+
 // 5. 기구 관리 Mock
 func (m *MockRepository) GetEquipmentsByGymID(gymID int64) ([]domain.Equipment, error) {
 	return []domain.Equipment{
-		{ID: 1, Name: "Mock 트레드밀", Category: "유산소", Quantity: 5, Status: "active"},
+		{ID: 1, GymID: gymID, Name: "Mock 트레드밀", Category: "유산소", Quantity: 5, Status: "active", PurchaseDate: "2024-01-15",
+			PurchasePrice: 4500000, Supplier: "Mock 스포츠", WarrantyExpiry: "2026-01-15",
+			DepreciationMethod: "straight_line", UsefulLifeYears: 5, SalvageValue: 500000},
 	}, nil
 }
 
+func (m *MockRepository) GetEquipmentByID(eqID int64) (*domain.Equipment, error) {
+	list, _ := m.GetEquipmentsByGymID(1)
+	eq := list[0]
+	eq.ID = eqID
+	return &eq, nil
+}
+
 func (m *MockRepository) AddEquipment(eq *domain.Equipment) error {
 	log.Printf("[MOCK] Equipment Added: %s", eq.Name)
 	return nil
 }
 
-func (m *MockRepository) UpdateEquipment(eq *domain.Equipment) error {
+// checkEquipmentScope: MySQL 구현과 같은 지점 범위 확인 (Mock 기구는 모두 1번 지점 소속)
+func (m *MockRepository) checkEquipmentScope(gymScope, eqID int64) error {
+	eq, err := m.GetEquipmentByID(eqID)
+	if err != nil {
+		return err
+	}
+	if gymScope != 0 && eq.GymID != gymScope {
+		log.Printf("[SECURITY] 지점 %d 관리자가 지점 %d의 기구 %d번 접근 시도", gymScope, eq.GymID, eqID)
+		return ErrGymScopeDenied
+	}
+	return nil
+}
+
+func (m *MockRepository) UpdateEquipment(gymScope int64, eq *domain.Equipment) error {
+	if err := m.checkEquipmentScope(gymScope, eq.ID); err != nil {
+		return err
+	}
 	log.Printf("[MOCK] Equipment Updated: ID %d", eq.ID)
 	return nil
 }
 
-func (m *MockRepository) DeleteEquipment(eqID int64) error {
+func (m *MockRepository) DeleteEquipment(gymScope, eqID int64) error {
+	if err := m.checkEquipmentScope(gymScope, eqID); err != nil {
+		return err
+	}
 	log.Printf("[MOCK] Equipment Deleted: ID %d", eqID)
 	return nil
 }
 
+// 5-1. 기구 예약 Mock
+func (m *MockRepository) CreateEquipmentBooking(b *domain.EquipmentBooking) error {
+	b.BookingNumber = 1
+	b.RevsNumber = 1
+	if b.UnitNo == 0 {
+		b.UnitNo = 1
+	}
+	b.Status = domain.BookingBooked
+	log.Printf("[MOCK] Equipment Booking Created: User %d -> Equip %d #%d", b.UserNumber, b.EquipID, b.UnitNo)
+	return nil
+}
+
+func (m *MockRepository) GetEquipmentBookings(equipID int64, from, to time.Time) ([]domain.EquipmentBooking, error) {
+	return []domain.EquipmentBooking{}, nil
+}
+
+func (m *MockRepository) CancelEquipmentBooking(userNum, bookingNum int64) error {
+	log.Printf("[MOCK] Equipment Booking Cancelled: ID %d", bookingNum)
+	return nil
+}
+
+func (m *MockRepository) GetEquipmentBookingsByUser(userNum int64) ([]domain.EquipmentBooking, error) {
+	return []domain.EquipmentBooking{}, nil
+}
+
+// 5-2. 상품/이용권 Mock
+func (m *MockRepository) GetProducts(gymID int64, activeOnly bool) ([]domain.Product, error) {
+	return []domain.Product{
+		{ProductNumber: 1, GymID: gymID, Name: "Mock 일일권", Type: domain.ProductDayPass, Price: 10000, VisitCount: 1, ValidityDays: 1, IsActive: true},
+		{ProductNumber: 2, GymID: gymID, Name: "Mock 1개월 회원권", Type: domain.ProductMembership, Price: 100000, ValidityDays: 30, IsActive: true},
+	}, nil
+}
+
+func (m *MockRepository) GetProductByID(productNum int64) (*domain.Product, error) {
+	list, _ := m.GetProducts(1, false)
+	for _, p := range list {
+		if p.ProductNumber == productNum {
+			return &p, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *MockRepository) CreateProduct(p *domain.Product) error {
+	p.ProductNumber = 3
+	log.Printf("[MOCK] Product Created: %s", p.Name)
+	return nil
+}
+
+func (m *MockRepository) UpdateProduct(gymScope int64, p *domain.Product) error {
+	log.Printf("[MOCK] Product Updated: ID %d", p.ProductNumber)
+	return nil
+}
+
+func (m *MockRepository) IssuePass(pass *domain.Pass, sale *domain.Sale) error {
+	pass.PassNumber = 1
+	pass.Status = domain.PassActive
+	log.Printf("[MOCK] Pass Issued: User %d <- Product %d", pass.UserNumber, pass.ProductNumber)
+	return nil
+}
+
+func (m *MockRepository) GetPassesByUser(userNum int64) ([]domain.Pass, error) {
+	now := time.Now()
+	return []domain.Pass{
+		{PassNumber: 1, UserNumber: userNum, ProductNumber: 2, GymID: 1, StartsAt: now, ExpiresAt: now.AddDate(0, 0, 30),
+			Status: domain.PassActive, ProductName: "Mock 1개월 회원권", ProductType: domain.ProductMembership},
+	}, nil
+}
+
+func (m *MockRepository) GetPassByID(passNum int64) (*domain.Pass, error) {
+	list, _ := m.GetPassesByUser(1)
+	p := list[0]
+	p.PassNumber = passNum
+	return &p, nil
+}
+
+func (m *MockRepository) GetPassFreezes(passNum int64) ([]domain.PassFreeze, error) {
+	return []domain.PassFreeze{}, nil
+}
+
+func (m *MockRepository) FreezePass(userNum int64, f *domain.PassFreeze, check func(history []domain.PassFreeze) error) error {
+	history, _ := m.GetPassFreezes(f.PassNumber)
+	if err := check(history); err != nil {
+		return err
+	}
+	f.FreezeNumber = 1
+	log.Printf("[MOCK] Pass Frozen: Pass %d (%d days)", f.PassNumber, f.Days)
+	return nil
+}
+
+func (m *MockRepository) TransferPass(passNum, fromUser, toUser int64) error {
+	log.Printf("[MOCK] Pass Transferred: Pass %d, User %d -> %d", passNum, fromUser, toUser)
+	return nil
+}
+
+// 5-3. 주문 Mock
+func (m *MockRepository) CreateOrder(o *domain.Order) error {
+	o.OrderNumber = 1
+	o.Status = domain.OrderPending
+	o.CreatedAt = time.Now()
+	log.Printf("[MOCK] Order Created: User %d, Product %d (%d원)", o.UserNumber, o.ProductNumber, o.Amount)
+	return nil
+}
+
+func (m *MockRepository) SetOrderPayment(orderNum int64, paymentID string) error {
+	return nil
+}
+
+func (m *MockRepository) GetOrderByID(orderNum int64) (*domain.Order, error) {
+	return &domain.Order{OrderNumber: orderNum, UserNumber: 1, GymID: 1, ProductNumber: 1, Amount: 10000,
+		Status: domain.OrderPending, CreatedAt: time.Now()}, nil
+}
+
+func (m *MockRepository) GetOrderByPaymentID(paymentID string) (*domain.Order, error) {
+	o, _ := m.GetOrderByID(1)
+	o.PaymentID = paymentID
+	return o, nil
+}
+
+func (m *MockRepository) GetOrdersByUser(userNum int64) ([]domain.Order, error) {
+	return []domain.Order{}, nil
+}
+
+func (m *MockRepository) ConfirmOrder(orderNum int64, pass *domain.Pass, sale *domain.Sale) error {
+	pass.PassNumber = 1
+	sale.SalesNumber = 1
+	sale.OrderNumber = orderNum
+	log.Printf("[MOCK] Order Paid: %d", orderNum)
+	return nil
+}
+
+func (m *MockRepository) FailOrder(orderNum int64) error {
+	log.Printf("[MOCK] Order Failed: %d", orderNum)
+	return nil
+}
+
+// 5-4. 쿠폰 Mock
+func (m *MockRepository) GetCouponByCode(code string) (*domain.Coupon, error) {
+	list, _ := m.GetCoupons(0)
+	for _, c := range list {
+		if c.Code == code {
+			return &c, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *MockRepository) GetCouponByID(couponNum int64) (*domain.Coupon, error) {
+	list, _ := m.GetCoupons(0)
+	for _, c := range list {
+		if c.CouponNumber == couponNum {
+			return &c, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *MockRepository) GetCoupons(gymScope int64) ([]domain.Coupon, error) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []domain.Coupon{
+		{CouponNumber: 1, Code: "FIRSTDAY", Name: "첫 방문 일일권 무료", DiscountType: domain.DiscountFree, ProductType: domain.ProductDayPass,
+			StartsAt: start, EndsAt: start.AddDate(1, 0, 0), PerUserLimit: 1, FirstPurchaseOnly: true, IsActive: true,
+			GymIDs: []int64{}, ProductNumbers: []int64{}},
+		{CouponNumber: 2, Code: "WELCOME10", Name: "신규 오픈 10% 할인", DiscountType: domain.DiscountPercent, DiscountValue: 10, MaxDiscount: 20000,
+			StartsAt: start, EndsAt: start.AddDate(1, 0, 0), TotalLimit: 500, PerUserLimit: 1, IsActive: true,
+			GymIDs: []int64{}, ProductNumbers: []int64{}},
+	}, nil
+}
+
+func (m *MockRepository) CreateCoupon(c *domain.Coupon) error {
+	c.CouponNumber = 3
+	log.Printf("[MOCK] Coupon Created: %s", c.Code)
+	return nil
+}
+
+func (m *MockRepository) UpdateCoupon(c *domain.Coupon) error {
+	log.Printf("[MOCK] Coupon Updated: %d", c.CouponNumber)
+	return nil
+}
+
+func (m *MockRepository) GetCouponUsage(gymScope int64, from, to time.Time) ([]domain.CouponUsage, error) {
+	return []domain.CouponUsage{}, nil
+}
+
+// 5-5. 환불 Mock
+func (m *MockRepository) GetPassUsage(passNum int64) (int, int, error) {
+	return 0, 0, nil
+}
+
+func (m *MockRepository) CreateRefund(rf *domain.Refund) error {
+	rf.RefundNumber = 1
+	rf.Status = domain.RefundRequested
+	rf.CreatedAt = time.Now()
+	log.Printf("[MOCK] Refund Requested: Order %d (%d원)", rf.OrderNumber, rf.Amount)
+	return nil
+}
+
+func (m *MockRepository) GetRefundByID(refundNum int64) (*domain.Refund, error) {
+	return &domain.Refund{RefundNumber: refundNum, OrderNumber: 1, UserNumber: 1, GymID: 1, PaidAmount: 150000, Amount: 150000,
+		Status: domain.RefundRequested, CreatedAt: time.Now()}, nil
+}
+
+func (m *MockRepository) GetRefunds(gymScope int64, status string) ([]domain.Refund, error) {
+	return []domain.Refund{}, nil
+}
+
+func (m *MockRepository) ClaimRefund(refundNum, amount int64) error {
+	return nil
+}
+
+func (m *MockRepository) ReleaseRefund(refundNum int64) error {
+	return nil
+}
+
+func (m *MockRepository) MarkRefundProviderDone(refundNum int64) error {
+	return nil
+}
+
+func (m *MockRepository) CompleteRefund(rf *domain.Refund, passNum int64, sale *domain.Sale) error {
+	sale.SalesNumber = 1
+	rf.Status = domain.RefundCompleted
+	rf.SalesNumber = sale.SalesNumber
+	log.Printf("[MOCK] Refund Completed: %d (%d원)", rf.RefundNumber, sale.SalesAmount)
+	return nil
+}
+
+func (m *MockRepository) RefundCounterPass(passNum int64, sale *domain.Sale) error {
+	sale.SalesNumber = 1
+	log.Printf("[MOCK] Counter Pass Refunded: Pass %d (%d원)", passNum, sale.SalesAmount)
+	return nil
+}
+
+func (m *MockRepository) RejectRefund(refundNum int64, adminID, note string) error {
+	log.Printf("[MOCK] Refund Rejected: %d by %s", refundNum, adminID)
+	return nil
+}
+
+func (m *MockRepository) MarkNoShow(gymScope, revsNum int64) error {
+	log.Printf("[MOCK] No-Show: Reservation %d", revsNum)
+	return nil
+}
+
+func (m *MockRepository) GetRefundsByUser(userNum int64) ([]domain.Refund, error) {
+	return []domain.Refund{}, nil
+}
+
 // 6. 매출 관련 Mock
-func (m *MockRepository) GetSalesByGym(gymID int64) ([]map[string]interface{}, error) {
-	return []map[string]interface{}{
-		{"type": "Membership", "amount": 100000, "date": "2026-01-13"},
+func (m *MockRepository) CreateSale(sale *domain.Sale) error {
+	sale.SalesNumber = 1
+	log.Printf("[MOCK] Sale Recorded: Gym %d %s %d원", sale.GymID, sale.SalesType, sale.SalesAmount)
+	return nil
+}
+
+func (m *MockRepository) GetSales(filter domain.SalesFilter) ([]domain.Sale, error) {
+	gymID := filter.GymID
+	if gymID == 0 {
+		gymID = 1
+	}
+	return []domain.Sale{
+		{SalesNumber: 1, GymID: gymID, SalesType: "MONTHLY", SalesAmount: 100000, SalesDate: time.Date(2026, 1, 13, 3, 0, 0, 0, time.UTC)},
+		{SalesNumber: 2, GymID: gymID, SalesType: "DAILY", SalesAmount: 10000, SalesDate: time.Date(2026, 1, 13, 9, 30, 0, 0, time.UTC)},
 	}, nil
 }
 
+// 6-1. 영수증 Mock
+func (m *MockRepository) GetReceiptByID(receiptNum int64) (*domain.Receipt, error) {
+	return &domain.Receipt{
+		ReceiptNumber: receiptNum, ReceiptNo: "001-000001", GymID: 1, Seq: 1, SalesNumber: 1, UserNumber: 1, OrderNumber: 1,
+		IssuedAt: time.Date(2026, 1, 13, 3, 0, 0, 0, time.UTC), Total: 90000, Supply: 81818, VAT: 8182,
+		GymName: "명지대 MCC 체육시설", GymAddress: "서울 서대문구 거북골로 34", GymPhone: "02-300-1521",
+		Lines: []domain.ReceiptLine{
+			{Description: "Mock 1개월 회원권", Quantity: 1, UnitPrice: 100000, Amount: 100000},
+			{Description: "쿠폰 할인 (WELCOME10)", Quantity: 1, UnitPrice: -10000, Amount: -10000},
+		},
+	}, nil
+}
+
+func (m *MockRepository) GetReceiptsByUser(userNum int64) ([]domain.Receipt, error) {
+	rc, _ := m.GetReceiptByID(1)
+	rc.UserNumber = userNum
+	rc.Lines = nil
+	return []domain.Receipt{*rc}, nil
+}
+
+// 7. 보존 기간 정리 Mock (정리할 기록 없음)
+func (m *MockRepository) PurgeExpiredAuthData(before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockRepository) PurgeReservations(before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockRepository) PurgePaymentRecords(before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockRepository) PurgeWithdrawnUsers(before time.Time) (int64, error) {
+	return 0, nil
+}
+
 // --- LogRepository Mock ---
 type MockLogRepository struct{}
 
@@ -107,3 +608,15 @@ func (m *MockLogRepository) SaveEqLog(gID int64, eID string, stat string) error
 func (m *MockLogRepository) SaveUserLog(uID string, act string) error {
 	return nil
 }
+
+func (m *MockLogRepository) GetUserLogs(uID string) ([]domain.ActivityLog, error) {
+	return []domain.ActivityLog{}, nil
+}
+
+func (m *MockLogRepository) DeleteUserLogs(uID string) (int, error) {
+	return 0, nil
+}
+
+func (m *MockLogRepository) PurgeUserLogsBefore(before time.Time) (int, error) {
+	return 0, nil
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+	"time"
+)
+
+const couponColumns = `c.coupon_number, c.coupon_code, c.coupon_name, c.discount_type, c.discount_value, c.max_discount,
+                       COALESCE(c.product_type, ''), c.starts_at, c.ends_at, c.total_limit, c.per_user_limit,
+                       c.first_purchase_only, c.is_active,
+                       (SELECT COUNT(*) FROM coupon_redemption_table cr
+                        WHERE cr.fk_coupon_number = c.coupon_number AND cr.redemption_status <> 'RELEASED')`
+
+func scanCoupon(row interface{ Scan(...interface{}) error }, c *domain.Coupon) error {
+	return row.Scan(&c.CouponNumber, &c.Code, &c.Name, &c.DiscountType, &c.DiscountValue, &c.MaxDiscount,
+		&c.ProductType, &c.StartsAt, &c.EndsAt, &c.TotalLimit, &c.PerUserLimit,
+		&c.FirstPurchaseOnly, &c.IsActive, &c.UsedCount)
+}
+
+// checkCouponLimits: 쿠폰 행을 잠그고 전체/회원당 한도와 첫 구매 조건 확인 (주문 생성 트랜잭션 안에서 호출)
+func checkCouponLimits(tx *sql.Tx, couponNum, userNum int64) error {
+	var totalLimit, perUserLimit int
+	var firstOnly bool
+	err := tx.QueryRow(`SELECT total_limit, per_user_limit, first_purchase_only FROM coupon_table WHERE coupon_number = ? FOR UPDATE`,
+		couponNum).Scan(&totalLimit, &perUserLimit, &firstOnly)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	var used, usedByUser int
+	err = tx.QueryRow(`SELECT COUNT(*), COALESCE(SUM(fk_user_number = ?), 0) FROM coupon_redemption_table
+                       WHERE fk_coupon_number = ? AND redemption_status <> 'RELEASED'`, userNum, couponNum).Scan(&used, &usedByUser)
+	if err != nil {
+		return err
+	}
+	if totalLimit > 0 && used >= totalLimit {
+		return ErrCouponExhausted
+	}
+	if perUserLimit > 0 && usedByUser >= perUserLimit {
+		return ErrCouponAlreadyUsed
+	}
+
+	if firstOnly {
+		// 이용권을 한 번이라도 발급받았거나 결제 대기 중인 주문이 있으면 첫 구매가 아니다.
+		var history int
+		err = tx.QueryRow(`SELECT (SELECT COUNT(*) FROM pass_table WHERE fk_user_number = ?) +
+                                  (SELECT COUNT(*) FROM order_table WHERE fk_user_number = ? AND order_status = 'PENDING')`,
+			userNum, userNum).Scan(&history)
+		if err != nil {
+			return err
+		}
+		if history > 0 {
+			return ErrCouponNotEligible
+		}
+	}
+	return nil
+}
+
+// loadCouponTargets: 쿠폰 적용 대상 지점/상품 목록 채우기
+func (r *mysqlRepo) loadCouponTargets(c *domain.Coupon) error {
+	rows, err := r.db.Query(`SELECT target_type, target_id FROM coupon_target_table WHERE fk_coupon_number = ? ORDER BY target_id`, c.CouponNumber)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	c.GymIDs, c.ProductNumbers = []int64{}, []int64{}
+	for rows.Next() {
+		var t string
+		var id int64
+		if err := rows.Scan(&t, &id); err != nil {
+			return err
+		}
+		if t == "GYM" {
+			c.GymIDs = append(c.GymIDs, id)
+		} else {
+			c.ProductNumbers = append(c.ProductNumbers, id)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *mysqlRepo) getCoupon(where string, arg interface{}) (*domain.Coupon, error) {
+	var c domain.Coupon
+	if err := scanCoupon(r.db.QueryRow(`SELECT `+couponColumns+` FROM coupon_table c WHERE `+where, arg), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if err := r.loadCouponTargets(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetCouponByCode: 코드로 쿠폰 조회 (코드는 대문자로 저장됨)
+func (r *mysqlRepo) GetCouponByCode(code string) (*domain.Coupon, error) {
+	return r.getCoupon(`c.coupon_code = ?`, code)
+}
+
+func (r *mysqlRepo) GetCouponByID(couponNum int64) (*domain.Coupon, error) {
+	return r.getCoupon(`c.coupon_number = ?`, couponNum)
+}
+
+// GetCoupons: 관리자용 쿠폰 목록 (gymScope가 있으면 해당 지점에서 쓸 수 있는 쿠폰만)
+func (r *mysqlRepo) GetCoupons(gymScope int64) ([]domain.Coupon, error) {
+	query := `SELECT ` + couponColumns + ` FROM coupon_table c`
+	var args []interface{}
+	if gymScope != 0 {
+		query += ` WHERE NOT EXISTS (SELECT 1 FROM coupon_target_table t WHERE t.fk_coupon_number = c.coupon_number AND t.target_type = 'GYM')
+                   OR EXISTS (SELECT 1 FROM coupon_target_table t WHERE t.fk_coupon_number = c.coupon_number AND t.target_type = 'GYM' AND t.target_id = ?)`
+		args = append(args, gymScope)
+	}
+	query += ` ORDER BY c.coupon_number DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		log.Printf("[DB ERROR] GetCoupons: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Coupon{}
+	for rows.Next() {
+		var c domain.Coupon
+		if err := scanCoupon(rows, &c); err != nil {
+			log.Printf("[DB ERROR] Scan Coupon: %v", err)
+			continue
+		}
+		list = append(list, c)
+	}
+	rows.Close()
+
+	for i := range list {
+		if err := r.loadCouponTargets(&list[i]); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (r *mysqlRepo) CreateCoupon(c *domain.Coupon) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`INSERT INTO coupon_table (coupon_code, coupon_name, discount_type, discount_value, max_discount, product_type,
+                                                      starts_at, ends_at, total_limit, per_user_limit, first_purchase_only, is_active)
+                            VALUES (?, ?, ?, ?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?)`,
+		c.Code, c.Name, c.DiscountType, c.DiscountValue, c.MaxDiscount, c.ProductType,
+		c.StartsAt.UTC(), c.EndsAt.UTC(), c.TotalLimit, c.PerUserLimit, c.FirstPurchaseOnly, c.IsActive)
+	if err != nil {
+		log.Printf("[DB ERROR] CreateCoupon: %v", err)
+		return err
+	}
+	c.CouponNumber, _ = result.LastInsertId()
+
+	if err := insertCouponTargets(tx, c); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateCoupon: 쿠폰 수정 (코드는 변경 불가, 적용 대상은 통째로 교체)
+func (r *mysqlRepo) UpdateCoupon(c *domain.Coupon) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE coupon_table SET coupon_name=?, discount_type=?, discount_value=?, max_discount=?, product_type=NULLIF(?, ''),
+                                                    starts_at=?, ends_at=?, total_limit=?, per_user_limit=?, first_purchase_only=?, is_active=?
+                            WHERE coupon_number=?`,
+		c.Name, c.DiscountType, c.DiscountValue, c.MaxDiscount, c.ProductType,
+		c.StartsAt.UTC(), c.EndsAt.UTC(), c.TotalLimit, c.PerUserLimit, c.FirstPurchaseOnly, c.IsActive, c.CouponNumber)
+	if err != nil {
+		log.Printf("[DB ERROR] UpdateCoupon: %v", err)
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		var exists int
+		if tx.QueryRow(`SELECT COUNT(*) FROM coupon_table WHERE coupon_number = ?`, c.CouponNumber).Scan(&exists); exists == 0 {
+			return ErrNotFound
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM coupon_target_table WHERE fk_coupon_number = ?`, c.CouponNumber); err != nil {
+		return err
+	}
+	if err := insertCouponTargets(tx, c); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertCouponTargets(db execer, c *domain.Coupon) error {
+	for _, id := range c.GymIDs {
+		if _, err := db.Exec(`INSERT IGNORE INTO coupon_target_table (fk_coupon_number, target_type, target_id) VALUES (?, 'GYM', ?)`, c.CouponNumber, id); err != nil {
+			return err
+		}
+	}
+	for _, id := range c.ProductNumbers {
+		if _, err := db.Exec(`INSERT IGNORE INTO coupon_target_table (fk_coupon_number, target_type, target_id) VALUES (?, 'PRODUCT', ?)`, c.CouponNumber, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCouponUsage: 쿠폰별 사용 실적 (주문 생성 시각 기준 [from, to), gymScope가 있으면 해당 지점 주문만)
+func (r *mysqlRepo) GetCouponUsage(gymScope int64, from, to time.Time) ([]domain.CouponUsage, error) {
+	query := `SELECT c.coupon_number, c.coupon_code, c.coupon_name,
+                     COALESCE(SUM(cr.redemption_status = 'REDEEMED'), 0),
+                     COALESCE(SUM(cr.redemption_status = 'RESERVED'), 0),
+                     COUNT(DISTINCT IF(cr.redemption_status = 'REDEEMED', cr.fk_user_number, NULL)),
+                     COALESCE(SUM(IF(cr.redemption_status = 'REDEEMED', cr.discount_amount, 0)), 0),
+                     COALESCE(SUM(IF(cr.redemption_status = 'REDEEMED', o.order_amount, 0)), 0)
+              FROM coupon_redemption_table cr
+              JOIN coupon_table c ON cr.fk_coupon_number = c.coupon_number
+              JOIN order_table o ON cr.fk_order_number = o.order_number
+              WHERE cr.created_at >= ? AND cr.created_at < ?`
+	args := []interface{}{from.UTC(), to.UTC()}
+	if gymScope != 0 {
+		query += ` AND o.fk_guss_number = ?`
+		args = append(args, gymScope)
+	}
+	query += ` GROUP BY c.coupon_number, c.coupon_code, c.coupon_name ORDER BY c.coupon_number DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		log.Printf("[DB ERROR] GetCouponUsage: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.CouponUsage{}
+	for rows.Next() {
+		var u domain.CouponUsage
+		if err := rows.Scan(&u.CouponNumber, &u.Code, &u.Name, &u.Redeemed, &u.Reserved, &u.UniqueUsers, &u.TotalDiscount, &u.NetRevenue); err != nil {
+			log.Printf("[DB ERROR] Scan CouponUsage: %v", err)
+			continue
+		}
+		list = append(list, u)
+	}
+	return list, nil
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+	"time"
+)
+
+// CreateEquipmentBooking: 기구 한 대를 시간 블록 단위로 예약
+// 같은 기구 행을 FOR UPDATE로 잠가 동시 예약 시에도 대(unit)별 시간 중복을 막는다.
+// b.UnitNo가 0이면 비어 있는 가장 앞 번호의 기구를 배정한다.
+func (r *mysqlRepo) CreateEquipmentBooking(b *domain.EquipmentBooking) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var gymID int64
+	var quantity int
+	var status string
+	err = tx.QueryRow(`SELECT fk_guss_number, equip_quantity, equip_status FROM equipment_table WHERE equip_id = ? FOR UPDATE`,
+		b.EquipID).Scan(&gymID, &quantity, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if status != "active" || quantity <= 0 || b.UnitNo < 0 || b.UnitNo > quantity {
+		return ErrEquipmentUnavailable
+	}
+
+	// [체크] 해당 체육관에 입장(예약)한 상태여야 기구 예약 가능
+	err = tx.QueryRow(`SELECT revs_number FROM revs_table
+                       WHERE fk_user_number = ? AND fk_guss_number = ? AND revs_status = 'CONFIRMED'
+                       ORDER BY revs_number DESC LIMIT 1`, b.UserNumber, gymID).Scan(&b.RevsNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNoActiveReservation
+		}
+		return err
+	}
+
+	// [체크] 같은 시간대에 다른 기구를 이미 잡아둔 경우 (한 사람이 여러 대 독점 방지)
+	var mine int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM equip_booking_table
+                       WHERE fk_user_number = ? AND booking_status = 'BOOKED' AND start_time < ? AND end_time > ?`,
+		b.UserNumber, b.EndTime, b.StartTime).Scan(&mine)
+	if err != nil {
+		return err
+	}
+	if mine > 0 {
+		return ErrBookingConflict
+	}
+
+	rows, err := tx.Query(`SELECT DISTINCT unit_no FROM equip_booking_table
+                           WHERE fk_equip_id = ? AND booking_status = 'BOOKED' AND start_time < ? AND end_time > ?`,
+		b.EquipID, b.EndTime, b.StartTime)
+	if err != nil {
+		return err
+	}
+	busy := map[int]bool{}
+	for rows.Next() {
+		var unit int
+		if err := rows.Scan(&unit); err != nil {
+			rows.Close()
+			return err
+		}
+		busy[unit] = true
+	}
+	rows.Close()
+
+	if b.UnitNo > 0 {
+		if busy[b.UnitNo] {
+			return ErrBookingConflict
+		}
+	} else {
+		for unit := 1; unit <= quantity; unit++ {
+			if !busy[unit] {
+				b.UnitNo = unit
+				break
+			}
+		}
+		if b.UnitNo == 0 {
+			return ErrBookingConflict
+		}
+	}
+
+	result, err := tx.Exec(`INSERT INTO equip_booking_table (fk_equip_id, fk_user_number, fk_revs_number, unit_no, start_time, end_time, booking_status)
+                            VALUES (?, ?, ?, ?, ?, ?, 'BOOKED')`,
+		b.EquipID, b.UserNumber, b.RevsNumber, b.UnitNo, b.StartTime, b.EndTime)
+	if err != nil {
+		log.Printf("[DB ERROR] CreateEquipmentBooking: %v", err)
+		return err
+	}
+	b.BookingNumber, _ = result.LastInsertId()
+	b.Status = domain.BookingBooked
+
+	return tx.Commit()
+}
+
+// GetEquipmentBookings: 기간 내 유효한(BOOKED) 기구 예약 목록 (빈 시간대 확인용)
+func (r *mysqlRepo) GetEquipmentBookings(equipID int64, from, to time.Time) ([]domain.EquipmentBooking, error) {
+	query := `SELECT booking_number, fk_equip_id, fk_user_number, fk_revs_number, unit_no, start_time, end_time, booking_status
+              FROM equip_booking_table
+              WHERE fk_equip_id = ? AND booking_status = 'BOOKED' AND start_time < ? AND end_time > ?
+              ORDER BY start_time, unit_no`
+
+	rows, err := r.db.Query(query, equipID, to, from)
+	if err != nil {
+		log.Printf("[DB ERROR] GetEquipmentBookings: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.EquipmentBooking{}
+	for rows.Next() {
+		var b domain.EquipmentBooking
+		err := rows.Scan(&b.BookingNumber, &b.EquipID, &b.UserNumber, &b.RevsNumber, &b.UnitNo, &b.StartTime, &b.EndTime, &b.Status)
+		if err != nil {
+			log.Printf("[DB ERROR] Scan EquipmentBooking: %v", err)
+			continue
+		}
+		list = append(list, b)
+	}
+	return list, nil
+}
+
+// GetEquipmentBookingsByUser: 회원의 전체 기구 예약 이력 (개인정보 열람용, 최신순)
+func (r *mysqlRepo) GetEquipmentBookingsByUser(userNum int64) ([]domain.EquipmentBooking, error) {
+	rows, err := r.db.Query(`SELECT booking_number, fk_equip_id, fk_user_number, fk_revs_number, unit_no, start_time, end_time, booking_status
+                             FROM equip_booking_table WHERE fk_user_number = ? ORDER BY start_time DESC`, userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] GetEquipmentBookingsByUser: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.EquipmentBooking{}
+	for rows.Next() {
+		var b domain.EquipmentBooking
+		if err := rows.Scan(&b.BookingNumber, &b.EquipID, &b.UserNumber, &b.RevsNumber, &b.UnitNo, &b.StartTime, &b.EndTime, &b.Status); err != nil {
+			log.Printf("[DB ERROR] Scan EquipmentBooking: %v", err)
+			continue
+		}
+		list = append(list, b)
+	}
+	return list, rows.Err()
+}
+
+// CancelEquipmentBooking: 본인의 기구 예약 취소
+func (r *mysqlRepo) CancelEquipmentBooking(userNum, bookingNum int64) error {
+	result, err := r.db.Exec(`UPDATE equip_booking_table SET booking_status = 'CANCELLED'
+                              WHERE booking_number = ? AND fk_user_number = ? AND booking_status = 'BOOKED'`, bookingNum, userNum)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CheckOut: 퇴실 처리 - 예약 완료, 이용 인원 감소, 남은 기구 예약 일괄 반납
+func (r *mysqlRepo) CheckOut(userNum int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var revsNum, gymNum int64
+	err = tx.QueryRow(`SELECT revs_number, fk_guss_number FROM revs_table
+                       WHERE fk_user_number = ? AND revs_status = 'CONFIRMED'
+                       ORDER BY revs_number DESC LIMIT 1 FOR UPDATE`, userNum).Scan(&revsNum, &gymNum)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNoActiveReservation
+		}
+		return err
+	}
+
+	if _, err = tx.Exec(`UPDATE revs_table SET revs_status = 'COMPLETED' WHERE revs_number = ?`, revsNum); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE guss_table SET guss_user_count = GREATEST(guss_user_count - 1, 0) WHERE guss_number = ?`, gymNum)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE equip_booking_table SET booking_status = 'RELEASED'
+                      WHERE fk_revs_number = ? AND booking_status = 'BOOKED'`, revsNum)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
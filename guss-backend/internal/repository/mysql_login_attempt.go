@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/auth"
+	"log"
+	"time"
+)
+
+// mysqlAttemptStore: 여러 서버 인스턴스가 로그인 실패 집계를 공유하기 위한 저장소 (login_attempt_table)
+type mysqlAttemptStore struct {
+	db *sql.DB
+}
+
+func NewMySQLAttemptStore(db *sql.DB) auth.AttemptStore {
+	return &mysqlAttemptStore{db: db}
+}
+
+func scanAttemptState(row interface{ Scan(...interface{}) error }) (auth.AttemptState, error) {
+	var st auth.AttemptState
+	var lockedUntil sql.NullTime
+	if err := row.Scan(&st.Failures, &st.LastFailure, &lockedUntil); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.AttemptState{}, nil
+		}
+		return st, err
+	}
+	if lockedUntil.Valid {
+		st.LockedUntil = lockedUntil.Time
+	}
+	return st, nil
+}
+
+func (s *mysqlAttemptStore) Get(key string) (auth.AttemptState, error) {
+	return scanAttemptState(s.db.QueryRow(`SELECT failures, last_failure, locked_until FROM login_attempt_table WHERE attempt_key = ?`, key))
+}
+
+// Acquire: 행 잠금 상태에서 대기/잠금 확인 후 증가 (동시 시도가 같은 횟수를 읽고 함께 통과하지 않도록 한 트랜잭션으로 처리)
+func (s *mysqlAttemptStore) Acquire(key string, now time.Time, window time.Duration, blocked func(auth.AttemptState) bool) (auth.AttemptState, bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return auth.AttemptState{}, false, err
+	}
+	defer tx.Rollback()
+
+	// 첫 시도도 잠글 행이 있도록 빈 기록을 먼저 만든다.
+	_, err = tx.Exec(`INSERT IGNORE INTO login_attempt_table (attempt_key, failures, last_failure) VALUES (?, 0, ?)`, key, now.UTC())
+	if err != nil {
+		log.Printf("[DB ERROR] login attempt acquire: %v", err)
+		return auth.AttemptState{}, false, err
+	}
+	st, err := scanAttemptState(tx.QueryRow(`SELECT failures, last_failure, locked_until FROM login_attempt_table WHERE attempt_key = ? FOR UPDATE`, key))
+	if err != nil {
+		return st, false, err
+	}
+	if blocked(st) {
+		return st, false, tx.Commit()
+	}
+
+	// failures를 먼저 갱신해야 이전 last_failure 값으로 window를 판단한다.
+	_, err = tx.Exec(`UPDATE login_attempt_table SET failures = IF(last_failure < ?, 1, failures + 1), last_failure = ? WHERE attempt_key = ?`,
+		now.Add(-window).UTC(), now.UTC(), key)
+	if err != nil {
+		log.Printf("[DB ERROR] login attempt acquire: %v", err)
+		return st, false, err
+	}
+
+	st, err = scanAttemptState(tx.QueryRow(`SELECT failures, last_failure, locked_until FROM login_attempt_table WHERE attempt_key = ?`, key))
+	if err != nil {
+		return st, false, err
+	}
+	return st, true, tx.Commit()
+}
+
+func (s *mysqlAttemptStore) Release(key string) error {
+	_, err := s.db.Exec(`UPDATE login_attempt_table SET failures = GREATEST(failures - 1, 0) WHERE attempt_key = ?`, key)
+	return err
+}
+
+func (s *mysqlAttemptStore) Lock(key string, until time.Time) error {
+	_, err := s.db.Exec(`UPDATE login_attempt_table SET locked_until = ? WHERE attempt_key = ?`, until.UTC(), key)
+	return err
+}
+
+func (s *mysqlAttemptStore) Reset(key string) error {
+	_, err := s.db.Exec(`DELETE FROM login_attempt_table WHERE attempt_key = ?`, key)
+	return err
+}
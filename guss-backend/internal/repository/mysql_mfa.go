@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+	"time"
+)
+
+// GetRoleMFARequired: 역할의 OTP 2단계 인증 필수 여부 (없는 역할이면 ErrNotFound)
+func (r *mysqlRepo) GetRoleMFARequired(role string) (bool, error) {
+	var required bool
+	if err := r.db.QueryRow(`SELECT mfa_required FROM role_table WHERE role_code = ?`, role).Scan(&required); err != nil {
+		if err == sql.ErrNoRows {
+			return false, ErrNotFound
+		}
+		return false, err
+	}
+	return required, nil
+}
+
+func (r *mysqlRepo) GetAdminMFA(adminNum int64) (*domain.AdminMFA, error) {
+	var m domain.AdminMFA
+	var enabledAt sql.NullTime
+	err := r.db.QueryRow(`SELECT fk_admin_number, totp_secret, enabled_at, last_used_step FROM admin_mfa_table WHERE fk_admin_number = ?`, adminNum).
+		Scan(&m.AdminNumber, &m.Secret, &enabledAt, &m.LastUsedStep)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if enabledAt.Valid {
+		m.EnabledAt = &enabledAt.Time
+	}
+	return &m, nil
+}
+
+// SaveAdminMFASecret: OTP 등록 시작 (활성화 전에 다시 요청하면 새 비밀키로 교체)
+func (r *mysqlRepo) SaveAdminMFASecret(adminNum int64, secret string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var enabledAt sql.NullTime
+	err = tx.QueryRow(`SELECT enabled_at FROM admin_mfa_table WHERE fk_admin_number = ? FOR UPDATE`, adminNum).Scan(&enabledAt)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if enabledAt.Valid {
+		return ErrMFAAlreadyEnabled
+	}
+
+	_, err = tx.Exec(`INSERT INTO admin_mfa_table (fk_admin_number, totp_secret, last_used_step, created_at) VALUES (?, ?, 0, ?)
+                      ON DUPLICATE KEY UPDATE totp_secret = VALUES(totp_secret), created_at = VALUES(created_at)`,
+		adminNum, secret, time.Now().UTC())
+	if err != nil {
+		log.Printf("[DB ERROR] SaveAdminMFASecret: %v", err)
+		return err
+	}
+	return tx.Commit()
+}
+
+// ActivateAdminMFA: 첫 코드 확인 후 활성화하고 복구 코드를 새로 저장 (확인에 쓴 구간은 재사용 불가)
+func (r *mysqlRepo) ActivateAdminMFA(adminNum, step int64, recoveryHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`UPDATE admin_mfa_table SET enabled_at = UTC_TIMESTAMP(), last_used_step = ?
+                         WHERE fk_admin_number = ? AND enabled_at IS NULL`, step, adminNum)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrMFAAlreadyEnabled
+	}
+
+	if _, err := tx.Exec(`DELETE FROM admin_recovery_code_table WHERE fk_admin_number = ?`, adminNum); err != nil {
+		return err
+	}
+	for _, hash := range recoveryHashes {
+		if _, err := tx.Exec(`INSERT INTO admin_recovery_code_table (fk_admin_number, code_hash) VALUES (?, ?)`, adminNum, hash); err != nil {
+			log.Printf("[DB ERROR] ActivateAdminMFA recovery code: %v", err)
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// UseAdminTOTPStep: 사용한 OTP 구간 기록 (조건부 UPDATE로 동시 요청 중 하나만 통과)
+func (r *mysqlRepo) UseAdminTOTPStep(adminNum, step int64) error {
+	res, err := r.db.Exec(`UPDATE admin_mfa_table SET last_used_step = ?
+                           WHERE fk_admin_number = ? AND enabled_at IS NOT NULL AND last_used_step < ?`, step, adminNum, step)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrMFACodeReused
+	}
+	return nil
+}
+
+// UseRecoveryCode: 복구 코드 1회 사용 처리 후 남은 개수 반환
+func (r *mysqlRepo) UseRecoveryCode(adminNum int64, hash string) (int, error) {
+	res, err := r.db.Exec(`UPDATE admin_recovery_code_table SET used_at = UTC_TIMESTAMP()
+                           WHERE fk_admin_number = ? AND code_hash = ? AND used_at IS NULL`, adminNum, hash)
+	if err != nil {
+		return 0, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return 0, ErrNotFound
+	}
+
+	var remaining int
+	err = r.db.QueryRow(`SELECT COUNT(*) FROM admin_recovery_code_table WHERE fk_admin_number = ? AND used_at IS NULL`, adminNum).Scan(&remaining)
+	return remaining, err
+}
+
+// DisableAdminMFA: OTP 등록과 복구 코드 삭제
+func (r *mysqlRepo) DisableAdminMFA(adminNum int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM admin_recovery_code_table WHERE fk_admin_number = ?`, adminNum); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM admin_mfa_table WHERE fk_admin_number = ?`, adminNum); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+)
+
+const orderColumns = `order_number, fk_user_number, fk_guss_number, fk_product_number,
+                      product_name, product_type, visit_count, validity_days, order_amount, list_price, discount_amount,
+                      COALESCE(fk_coupon_number, 0), coupon_code, order_status,
+                      COALESCE(payment_id, ''), COALESCE(fk_pass_number, 0), COALESCE(fk_sales_number, 0), created_at, paid_at`
+
+func scanOrder(row interface{ Scan(...interface{}) error }, o *domain.Order) error {
+	var paidAt sql.NullTime
+	err := row.Scan(&o.OrderNumber, &o.UserNumber, &o.GymID, &o.ProductNumber,
+		&o.ProductName, &o.ProductType, &o.VisitCount, &o.ValidityDays, &o.Amount, &o.ListPrice, &o.Discount,
+		&o.CouponNumber, &o.CouponCode, &o.Status,
+		&o.PaymentID, &o.PassNumber, &o.SalesNumber, &o.CreatedAt, &paidAt)
+	if err != nil {
+		return err
+	}
+	if paidAt.Valid {
+		o.PaidAt = &paidAt.Time
+	}
+	return nil
+}
+
+// CreateOrder: 결제 대기(PENDING) 주문 생성
+// 쿠폰이 있으면 쿠폰 행을 잠근 상태로 전체/회원당 한도와 첫 구매 조건을 확인하고 사용 이력(RESERVED)을 함께 남긴다.
+func (r *mysqlRepo) CreateOrder(o *domain.Order) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if o.CouponNumber != 0 {
+		if err := checkCouponLimits(tx, o.CouponNumber, o.UserNumber); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.Exec(`INSERT INTO order_table (fk_user_number, fk_guss_number, fk_product_number, product_name, product_type, visit_count, validity_days,
+                                                     order_amount, list_price, discount_amount, fk_coupon_number, coupon_code, order_status)
+                            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, 0), ?, 'PENDING')`,
+		o.UserNumber, o.GymID, o.ProductNumber, o.ProductName, o.ProductType, o.VisitCount, o.ValidityDays,
+		o.Amount, o.ListPrice, o.Discount, o.CouponNumber, o.CouponCode)
+	if err != nil {
+		log.Printf("[DB ERROR] CreateOrder: %v", err)
+		return err
+	}
+	o.OrderNumber, _ = result.LastInsertId()
+	o.Status = domain.OrderPending
+
+	if o.CouponNumber != 0 {
+		_, err = tx.Exec(`INSERT INTO coupon_redemption_table (fk_coupon_number, fk_user_number, fk_order_number, discount_amount, redemption_status, created_at)
+                          VALUES (?, ?, ?, ?, 'RESERVED', UTC_TIMESTAMP())`, o.CouponNumber, o.UserNumber, o.OrderNumber, o.Discount)
+		if err != nil {
+			log.Printf("[DB ERROR] CreateOrder redemption: %v", err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetOrderPayment: 결제사 승인 후 결제 ID 연결
+func (r *mysqlRepo) SetOrderPayment(orderNum int64, paymentID string) error {
+	_, err := r.db.Exec(`UPDATE order_table SET payment_id = ? WHERE order_number = ? AND order_status = 'PENDING'`, paymentID, orderNum)
+	return err
+}
+
+func (r *mysqlRepo) GetOrderByID(orderNum int64) (*domain.Order, error) {
+	var o domain.Order
+	if err := scanOrder(r.db.QueryRow(`SELECT `+orderColumns+` FROM order_table WHERE order_number = ?`, orderNum), &o); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *mysqlRepo) GetOrderByPaymentID(paymentID string) (*domain.Order, error) {
+	var o domain.Order
+	if err := scanOrder(r.db.QueryRow(`SELECT `+orderColumns+` FROM order_table WHERE payment_id = ?`, paymentID), &o); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &o, nil
+}
+
+// GetOrdersByUser: 회원의 주문 내역 (최신순)
+func (r *mysqlRepo) GetOrdersByUser(userNum int64) ([]domain.Order, error) {
+	rows, err := r.db.Query(`SELECT `+orderColumns+` FROM order_table WHERE fk_user_number = ? ORDER BY order_number DESC`, userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] GetOrdersByUser: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Order{}
+	for rows.Next() {
+		var o domain.Order
+		if err := scanOrder(rows, &o); err != nil {
+			log.Printf("[DB ERROR] Scan Order: %v", err)
+			continue
+		}
+		list = append(list, o)
+	}
+	return list, nil
+}
+
+// ConfirmOrder: 결제 완료 처리 - 이용권 발급, 매출 기록, 주문 PAID 전환을 한 트랜잭션으로
+// 웹훅은 중복 전달될 수 있으므로 PENDING이 아닌 주문은 ErrOrderNotPending으로 알려 멱등하게 처리한다.
+func (r *mysqlRepo) ConfirmOrder(orderNum int64, pass *domain.Pass, sale *domain.Sale) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow(`SELECT order_status FROM order_table WHERE order_number = ? FOR UPDATE`, orderNum).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if status != domain.OrderPending {
+		return ErrOrderNotPending
+	}
+
+	if err := insertPass(tx, pass); err != nil {
+		return err
+	}
+	sale.OrderNumber = orderNum
+	if err := insertSale(tx, sale); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE order_table SET order_status = 'PAID', paid_at = UTC_TIMESTAMP(), fk_pass_number = ?, fk_sales_number = ?
+                      WHERE order_number = ?`, pass.PassNumber, sale.SalesNumber, orderNum)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE coupon_redemption_table SET redemption_status = 'REDEEMED' WHERE fk_order_number = ?`, orderNum)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FailOrder: 결제 실패 처리 (PENDING 주문만, 적용했던 쿠폰은 한도로 반환)
+func (r *mysqlRepo) FailOrder(orderNum int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE order_table SET order_status = 'FAILED' WHERE order_number = ? AND order_status = 'PENDING'`, orderNum)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		_, err = tx.Exec(`UPDATE coupon_redemption_table SET redemption_status = 'RELEASED' WHERE fk_order_number = ?`, orderNum)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+	"time"
+)
+
+// CreatePasswordReset: 새 재설정 코드 저장 (같은 회원의 이전 미사용 코드는 폐기)
+func (r *mysqlRepo) CreatePasswordReset(pr *domain.PasswordReset) error {
+	if pr.CreatedAt.IsZero() {
+		pr.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE password_reset_table SET used_at = UTC_TIMESTAMP() WHERE fk_user_number = ? AND used_at IS NULL`, pr.UserNumber); err != nil {
+		return err
+	}
+	result, err := tx.Exec(`INSERT INTO password_reset_table (fk_user_number, code_hash, expires_at, created_at) VALUES (?, ?, ?, ?)`,
+		pr.UserNumber, pr.CodeHash, pr.ExpiresAt.UTC(), pr.CreatedAt.UTC())
+	if err != nil {
+		log.Printf("[DB ERROR] CreatePasswordReset: %v", err)
+		return err
+	}
+	pr.ResetNumber, _ = result.LastInsertId()
+	return tx.Commit()
+}
+
+func (r *mysqlRepo) GetActivePasswordReset(userNum int64) (*domain.PasswordReset, error) {
+	var pr domain.PasswordReset
+	err := r.db.QueryRow(`SELECT reset_number, fk_user_number, code_hash, attempts, expires_at, created_at
+                          FROM password_reset_table WHERE fk_user_number = ? AND used_at IS NULL
+                          ORDER BY reset_number DESC LIMIT 1`, userNum).
+		Scan(&pr.ResetNumber, &pr.UserNumber, &pr.CodeHash, &pr.Attempts, &pr.ExpiresAt, &pr.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UsePasswordResetAttempt: 코드를 비교하기 전에 시도 1회를 조건부로 증가
+// 동시에 여러 코드를 보내도 한도를 넘는 요청은 비교 단계까지 가지 못한다.
+func (r *mysqlRepo) UsePasswordResetAttempt(resetNum int64, maxAttempts int) error {
+	res, err := r.db.Exec(`UPDATE password_reset_table SET attempts = attempts + 1
+                           WHERE reset_number = ? AND used_at IS NULL AND expires_at > UTC_TIMESTAMP() AND attempts < ?`, resetNum, maxAttempts)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CompletePasswordReset: 코드 사용 처리 -> 비밀번호 변경 -> 회원의 모든 리프레시 토큰 폐기
+// 같은 코드로 동시에 요청해도 조건부 UPDATE로 하나만 성공한다 (나머지는 ErrNotFound).
+func (r *mysqlRepo) CompletePasswordReset(resetNum, userNum int64, passwordHash string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`UPDATE password_reset_table SET used_at = UTC_TIMESTAMP()
+                         WHERE reset_number = ? AND fk_user_number = ? AND used_at IS NULL AND expires_at > UTC_TIMESTAMP()`, resetNum, userNum)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.Exec(`UPDATE user_table SET user_pw = ? WHERE user_number = ?`, passwordHash, userNum); err != nil {
+		log.Printf("[DB ERROR] CompletePasswordReset: %v", err)
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE refresh_token_table SET revoked_at = UTC_TIMESTAMP()
+                          WHERE principal_type = ? AND principal_number = ? AND revoked_at IS NULL`, domain.PrincipalUser, userNum); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *mysqlRepo) RehashPassword(principalType string, principalNumber int64, oldHash, newHash string) error {
+	query := `UPDATE user_table SET user_pw = ? WHERE user_number = ? AND user_pw = ?`
+	if principalType == domain.PrincipalAdmin {
+		query = `UPDATE admin_table SET admin_pw = ? WHERE admin_number = ? AND admin_pw = ?`
+	}
+	_, err := r.db.Exec(query, newHash, principalNumber, oldHash)
+	return err
+}
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+)
+
+const productColumns = `product_number, fk_guss_number, product_name, product_type, product_price,
+                        COALESCE(visit_count, 0), validity_days, is_active`
+
+func scanProduct(row interface{ Scan(...interface{}) error }, p *domain.Product) error {
+	return row.Scan(&p.ProductNumber, &p.GymID, &p.Name, &p.Type, &p.Price, &p.VisitCount, &p.ValidityDays, &p.IsActive)
+}
+
+// GetProducts: 지점별 상품 목록 (activeOnly면 판매 중인 상품만)
+func (r *mysqlRepo) GetProducts(gymID int64, activeOnly bool) ([]domain.Product, error) {
+	query := `SELECT ` + productColumns + ` FROM product_table WHERE fk_guss_number = ?`
+	if activeOnly {
+		query += ` AND is_active = 1`
+	}
+	query += ` ORDER BY product_price`
+
+	rows, err := r.db.Query(query, gymID)
+	if err != nil {
+		log.Printf("[DB ERROR] GetProducts: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Product{}
+	for rows.Next() {
+		var p domain.Product
+		if err := scanProduct(rows, &p); err != nil {
+			log.Printf("[DB ERROR] Scan Product: %v", err)
+			continue
+		}
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+func (r *mysqlRepo) GetProductByID(productNum int64) (*domain.Product, error) {
+	var p domain.Product
+	err := scanProduct(r.db.QueryRow(`SELECT `+productColumns+` FROM product_table WHERE product_number = ?`, productNum), &p)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *mysqlRepo) CreateProduct(p *domain.Product) error {
+	query := `INSERT INTO product_table (fk_guss_number, product_name, product_type, product_price, visit_count, validity_days, is_active)
+              VALUES (?, ?, ?, ?, NULLIF(?, 0), ?, ?)`
+	result, err := r.db.Exec(query, p.GymID, p.Name, p.Type, p.Price, p.VisitCount, p.ValidityDays, p.IsActive)
+	if err != nil {
+		log.Printf("[DB ERROR] CreateProduct: %v", err)
+		return err
+	}
+	p.ProductNumber, _ = result.LastInsertId()
+	return nil
+}
+
+// UpdateProduct: 상품 정보 수정 (소속 지점은 변경 불가, 이미 발급된 이용권에는 영향 없음)
+func (r *mysqlRepo) UpdateProduct(gymScope int64, p *domain.Product) error {
+	var gymID int64
+	err := r.db.QueryRow(`SELECT fk_guss_number FROM product_table WHERE product_number = ?`, p.ProductNumber).Scan(&gymID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if gymScope != 0 && gymID != gymScope {
+		return ErrGymScopeDenied
+	}
+
+	query := `UPDATE product_table SET product_name=?, product_type=?, product_price=?, visit_count=NULLIF(?, 0), validity_days=?, is_active=?
+              WHERE product_number=?`
+	_, err = r.db.Exec(query, p.Name, p.Type, p.Price, p.VisitCount, p.ValidityDays, p.IsActive, p.ProductNumber)
+	return err
+}
+
+func insertPass(db execer, pass *domain.Pass) error {
+	result, err := db.Exec(`INSERT INTO pass_table (fk_user_number, fk_product_number, fk_guss_number, remaining_visits, starts_at, expires_at, pass_status)
+                            VALUES (?, ?, ?, ?, ?, ?, 'ACTIVE')`,
+		pass.UserNumber, pass.ProductNumber, pass.GymID, pass.RemainingVisits, pass.StartsAt.UTC(), pass.ExpiresAt.UTC())
+	if err != nil {
+		log.Printf("[DB ERROR] insertPass: %v", err)
+		return err
+	}
+	pass.PassNumber, _ = result.LastInsertId()
+	pass.Status = domain.PassActive
+	return nil
+}
+
+// IssuePass: 이용권 발급 + 매출 기록 (둘 중 하나라도 실패하면 모두 롤백)
+func (r *mysqlRepo) IssuePass(pass *domain.Pass, sale *domain.Sale) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPass(tx, pass); err != nil {
+		return err
+	}
+
+	if sale != nil {
+		if err := insertSale(tx, sale); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+const passColumns = `p.pass_number, p.fk_user_number, p.fk_product_number, p.fk_guss_number, p.remaining_visits,
+                     p.starts_at, p.expires_at, p.pass_status, pr.product_name, pr.product_type`
+
+func scanPass(row interface{ Scan(...interface{}) error }, p *domain.Pass) error {
+	var remaining sql.NullInt64
+	err := row.Scan(&p.PassNumber, &p.UserNumber, &p.ProductNumber, &p.GymID, &remaining,
+		&p.StartsAt, &p.ExpiresAt, &p.Status, &p.ProductName, &p.ProductType)
+	if err != nil {
+		return err
+	}
+	if remaining.Valid {
+		v := int(remaining.Int64)
+		p.RemainingVisits = &v
+	}
+	return nil
+}
+
+// GetPassesByUser: 회원이 보유한 이용권 목록 (최근 발급순)
+func (r *mysqlRepo) GetPassesByUser(userNum int64) ([]domain.Pass, error) {
+	query := `SELECT ` + passColumns + `
+              FROM pass_table p
+              JOIN product_table pr ON p.fk_product_number = pr.product_number
+              WHERE p.fk_user_number = ?
+              ORDER BY p.pass_number DESC`
+
+	rows, err := r.db.Query(query, userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] GetPassesByUser: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Pass{}
+	for rows.Next() {
+		var p domain.Pass
+		if err := scanPass(rows, &p); err != nil {
+			log.Printf("[DB ERROR] Scan Pass: %v", err)
+			continue
+		}
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+func (r *mysqlRepo) GetPassByID(passNum int64) (*domain.Pass, error) {
+	var p domain.Pass
+	query := `SELECT ` + passColumns + `
+              FROM pass_table p
+              JOIN product_table pr ON p.fk_product_number = pr.product_number
+              WHERE p.pass_number = ?`
+	if err := scanPass(r.db.QueryRow(query, passNum), &p); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// querier: *sql.DB 와 *sql.Tx 공통 조회 인터페이스 (잠금 트랜잭션 안에서 같은 조회 재사용)
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// GetPassFreezes: 이용권의 일시정지 이력 (시작일순)
+func (r *mysqlRepo) GetPassFreezes(passNum int64) ([]domain.PassFreeze, error) {
+	return queryPassFreezes(r.db, passNum)
+}
+
+func queryPassFreezes(db querier, passNum int64) ([]domain.PassFreeze, error) {
+	rows, err := db.Query(`SELECT freeze_number, fk_pass_number, start_at, end_at, freeze_days, created_at
+                           FROM pass_freeze_table WHERE fk_pass_number = ? ORDER BY start_at`, passNum)
+	if err != nil {
+		log.Printf("[DB ERROR] GetPassFreezes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.PassFreeze{}
+	for rows.Next() {
+		var f domain.PassFreeze
+		if err := rows.Scan(&f.FreezeNumber, &f.PassNumber, &f.StartAt, &f.EndAt, &f.Days, &f.CreatedAt); err != nil {
+			log.Printf("[DB ERROR] Scan PassFreeze: %v", err)
+			continue
+		}
+		list = append(list, f)
+	}
+	return list, rows.Err()
+}
+
+// FreezePass: 일시정지 기록 후 정지 일수만큼 만료일 연장
+// 이용권 행을 잠근 상태에서 정지 이력을 읽어 check(정책 검사)에 넘기므로, 동시에 들어온 요청이 연간 한도를 넘길 수 없다.
+func (r *mysqlRepo) FreezePass(userNum int64, f *domain.PassFreeze, check func(history []domain.PassFreeze) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var owner int64
+	err = tx.QueryRow(`SELECT fk_user_number FROM pass_table WHERE pass_number = ? FOR UPDATE`, f.PassNumber).Scan(&owner)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if owner != userNum {
+		return ErrNotFound
+	}
+
+	history, err := queryPassFreezes(tx, f.PassNumber)
+	if err != nil {
+		return err
+	}
+	if err := check(history); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`INSERT INTO pass_freeze_table (fk_pass_number, start_at, end_at, freeze_days) VALUES (?, ?, ?, ?)`,
+		f.PassNumber, f.StartAt.UTC(), f.EndAt.UTC(), f.Days)
+	if err != nil {
+		log.Printf("[DB ERROR] FreezePass: %v", err)
+		return err
+	}
+	f.FreezeNumber, _ = result.LastInsertId()
+
+	_, err = tx.Exec(`UPDATE pass_table SET expires_at = DATE_ADD(expires_at, INTERVAL ? DAY) WHERE pass_number = ?`, f.Days, f.PassNumber)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TransferPass: 이용권 양도 (입장 중이거나 정지 중/정지 예정인 이용권은 불가)
+func (r *mysqlRepo) TransferPass(passNum, fromUser, toUser int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var owner int64
+	err = tx.QueryRow(`SELECT fk_user_number FROM pass_table WHERE pass_number = ? FOR UPDATE`, passNum).Scan(&owner)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if owner != fromUser {
+		return ErrNotFound
+	}
+
+	var inUse int
+	err = tx.QueryRow(`SELECT
+                           (SELECT COUNT(*) FROM revs_table WHERE fk_pass_number = ? AND revs_status = 'CONFIRMED') +
+                           (SELECT COUNT(*) FROM pass_freeze_table WHERE fk_pass_number = ? AND end_at > UTC_TIMESTAMP())`,
+		passNum, passNum).Scan(&inUse)
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return ErrPassInUse
+	}
+
+	if _, err = tx.Exec(`UPDATE pass_table SET fk_user_number = ? WHERE pass_number = ?`, toUser, passNum); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO pass_transfer_table (fk_pass_number, from_user_number, to_user_number) VALUES (?, ?, ?)`,
+		passNum, fromUser, toUser)
+	if err != nil {
+		log.Printf("[DB ERROR] TransferPass: %v", err)
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+	"time"
+)
+
+func (r *mysqlRepo) GetUserByNumber(userNum int64) (*domain.User, error) {
+	var u domain.User
+	var verifiedAt sql.NullTime
+	err := r.db.QueryRow(`SELECT user_number, user_name, user_phone, user_id, user_pw, phone_verified_at
+                          FROM user_table WHERE user_number = ? AND deleted_at IS NULL`, userNum).
+		Scan(&u.UserNumber, &u.UserName, &u.UserPhone, &u.UserID, &u.UserPW, &verifiedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if verifiedAt.Valid {
+		u.PhoneVerifiedAt = &verifiedAt.Time
+	}
+	return &u, nil
+}
+
+func (r *mysqlRepo) UpdateUserName(userNum int64, name string) error {
+	res, err := r.db.Exec(`UPDATE user_table SET user_name = ? WHERE user_number = ? AND deleted_at IS NULL`, name, userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] UpdateUserName: %v", err)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		// 같은 이름으로 바꾸면 영향 행이 0이므로 회원이 남아 있는지 다시 확인
+		if _, err := r.GetUserByNumber(userNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreatePhoneChange: 번호 변경 요청 저장 (같은 회원의 이전 미사용 요청은 폐기)
+func (r *mysqlRepo) CreatePhoneChange(pc *domain.PhoneChange) error {
+	if pc.CreatedAt.IsZero() {
+		pc.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE phone_change_table SET used_at = UTC_TIMESTAMP() WHERE fk_user_number = ? AND used_at IS NULL`, pc.UserNumber); err != nil {
+		return err
+	}
+	result, err := tx.Exec(`INSERT INTO phone_change_table (fk_user_number, new_phone, code_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?)`,
+		pc.UserNumber, pc.NewPhone, pc.CodeHash, pc.ExpiresAt.UTC(), pc.CreatedAt.UTC())
+	if err != nil {
+		log.Printf("[DB ERROR] CreatePhoneChange: %v", err)
+		return err
+	}
+	pc.ChangeNumber, _ = result.LastInsertId()
+	return tx.Commit()
+}
+
+func (r *mysqlRepo) GetActivePhoneChange(userNum int64) (*domain.PhoneChange, error) {
+	var pc domain.PhoneChange
+	err := r.db.QueryRow(`SELECT change_number, fk_user_number, new_phone, code_hash, attempts, expires_at, created_at
+                          FROM phone_change_table WHERE fk_user_number = ? AND used_at IS NULL
+                          ORDER BY change_number DESC LIMIT 1`, userNum).
+		Scan(&pc.ChangeNumber, &pc.UserNumber, &pc.NewPhone, &pc.CodeHash, &pc.Attempts, &pc.ExpiresAt, &pc.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &pc, nil
+}
+
+// UsePhoneChangeAttempt: 코드를 비교하기 전에 시도 1회를 조건부로 증가 (한도를 넘는 동시 요청은 비교하지 못함)
+func (r *mysqlRepo) UsePhoneChangeAttempt(changeNum int64, maxAttempts int) error {
+	res, err := r.db.Exec(`UPDATE phone_change_table SET attempts = attempts + 1
+                           WHERE change_number = ? AND used_at IS NULL AND expires_at > UTC_TIMESTAMP() AND attempts < ?`, changeNum, maxAttempts)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CompletePhoneChange: 요청 사용 처리 후 회원 번호 교체 (같은 요청으로 동시에 들어와도 한 번만 반영)
+func (r *mysqlRepo) CompletePhoneChange(changeNum, userNum int64) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var phone string
+	err = tx.QueryRow(`SELECT new_phone FROM phone_change_table
+                       WHERE change_number = ? AND fk_user_number = ? AND used_at IS NULL AND expires_at > UTC_TIMESTAMP() FOR UPDATE`,
+		changeNum, userNum).Scan(&phone)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	if _, err := tx.Exec(`UPDATE phone_change_table SET used_at = UTC_TIMESTAMP() WHERE change_number = ?`, changeNum); err != nil {
+		return "", err
+	}
+	res, err := tx.Exec(`UPDATE user_table SET user_phone = ?, phone_verified_at = UTC_TIMESTAMP()
+                         WHERE user_number = ? AND deleted_at IS NULL`, phone, userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] CompletePhoneChange: %v", err)
+		return "", duplicateKeyError(err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return "", ErrNotFound
+	}
+	return phone, tx.Commit()
+}
+
+func (r *mysqlRepo) ChangePassword(userNum int64, oldHash, newHash string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`UPDATE user_table SET user_pw = ? WHERE user_number = ? AND user_pw = ? AND deleted_at IS NULL`, newHash, userNum, oldHash)
+	if err != nil {
+		log.Printf("[DB ERROR] ChangePassword: %v", err)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	if _, err := tx.Exec(`UPDATE refresh_token_table SET revoked_at = UTC_TIMESTAMP()
+                          WHERE principal_type = ? AND principal_number = ? AND revoked_at IS NULL`, domain.PrincipalUser, userNum); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AnonymizeUser: 회원 행은 남겨 예약/이용권/매출/영수증 이력(FK)을 보존하고, 식별 정보만 지운다.
+// 아이디/번호는 UNIQUE 제약 때문에 회원 번호 기반의 값으로 바꾸며, 빈 비밀번호 해시로는 로그인할 수 없다.
+func (r *mysqlRepo) AnonymizeUser(userNum int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var deletedAt sql.NullTime
+	if err := tx.QueryRow(`SELECT deleted_at FROM user_table WHERE user_number = ? FOR UPDATE`, userNum).Scan(&deletedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if deletedAt.Valid {
+		return ErrNotFound
+	}
+
+	var checkedIn bool
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM revs_table WHERE fk_user_number = ? AND revs_status = 'CONFIRMED')`, userNum).Scan(&checkedIn); err != nil {
+		return err
+	}
+	if checkedIn {
+		return ErrAccountInUse
+	}
+
+	_, err = tx.Exec(`UPDATE user_table
+                      SET user_name = ?, user_id = ?, user_phone = CONCAT('deleted-', user_number),
+                          user_pw = '', phone_verified_at = NULL, deleted_at = UTC_TIMESTAMP()
+                      WHERE user_number = ?`, domain.DeletedUserName, domain.DeletedUserID(userNum), userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] AnonymizeUser: %v", err)
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE refresh_token_table SET revoked_at = UTC_TIMESTAMP()
+                          WHERE principal_type = ? AND principal_number = ? AND revoked_at IS NULL`, domain.PrincipalUser, userNum); err != nil {
+		return err
+	}
+	cleanup := []string{
+		`UPDATE password_reset_table SET used_at = UTC_TIMESTAMP() WHERE fk_user_number = ? AND used_at IS NULL`,
+		`DELETE FROM phone_change_table WHERE fk_user_number = ?`,
+		`UPDATE equip_booking_table SET booking_status = 'CANCELLED' WHERE fk_user_number = ? AND booking_status = 'BOOKED'`,
+	}
+	for _, query := range cleanup {
+		if _, err := tx.Exec(query, userNum); err != nil {
+			log.Printf("[DB ERROR] AnonymizeUser cleanup: %v", err)
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"guss-backend/internal/algo"
+	"guss-backend/internal/domain"
+	"log"
+)
+
+// insertReceipt: 매출 한 건에 대한 영수증 발행 (insertSale과 같은 트랜잭션에서 호출)
+// 지점별 일련번호는 receipt_seq_table 행을 갱신하면서 LAST_INSERT_ID로 받아오므로,
+// 트랜잭션이 끝날 때까지 같은 지점의 다른 발행은 대기하고 롤백되면 번호도 되돌아간다.
+func insertReceipt(db execer, sale *domain.Sale) error {
+	result, err := db.Exec(`INSERT INTO receipt_seq_table (fk_guss_number, last_seq) VALUES (?, LAST_INSERT_ID(1))
+                            ON DUPLICATE KEY UPDATE last_seq = LAST_INSERT_ID(last_seq + 1)`, sale.GymID)
+	if err != nil {
+		log.Printf("[DB ERROR] insertReceipt seq: %v", err)
+		return err
+	}
+	seq, _ := result.LastInsertId()
+
+	lines := sale.Items
+	if len(lines) == 0 {
+		lines = []domain.ReceiptLine{{Description: sale.SalesType, Quantity: 1, UnitPrice: sale.SalesAmount, Amount: sale.SalesAmount}}
+	}
+
+	supply, vat := algo.SplitVAT(sale.SalesAmount)
+	result, err = db.Exec(`INSERT INTO receipt_table (fk_guss_number, receipt_seq, fk_sales_number, fk_user_number, fk_order_number,
+                                                      issued_at, total_amount, supply_amount, vat_amount)
+                           VALUES (?, ?, ?, NULLIF(?, 0), NULLIF(?, 0), ?, ?, ?, ?)`,
+		sale.GymID, seq, sale.SalesNumber, sale.UserNumber, sale.OrderNumber, sale.SalesDate.UTC(), sale.SalesAmount, supply, vat)
+	if err != nil {
+		log.Printf("[DB ERROR] insertReceipt: %v", err)
+		return err
+	}
+	receiptNum, _ := result.LastInsertId()
+
+	for _, l := range lines {
+		_, err = db.Exec(`INSERT INTO receipt_line_table (fk_receipt_number, line_description, quantity, unit_price, line_amount)
+                          VALUES (?, ?, ?, ?, ?)`, receiptNum, l.Description, l.Quantity, l.UnitPrice, l.Amount)
+		if err != nil {
+			log.Printf("[DB ERROR] insertReceipt line: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func receiptNo(gymID, seq int64) string {
+	return fmt.Sprintf("%03d-%06d", gymID, seq)
+}
+
+const receiptColumns = `rc.receipt_number, rc.fk_guss_number, rc.receipt_seq, rc.fk_sales_number, COALESCE(rc.fk_user_number, 0),
+                        COALESCE(rc.fk_order_number, 0), rc.issued_at, rc.total_amount, rc.supply_amount, rc.vat_amount,
+                        g.guss_name, COALESCE(g.guss_address, ''), COALESCE(g.guss_phone, '')`
+
+func scanReceipt(row interface{ Scan(...interface{}) error }, rc *domain.Receipt) error {
+	err := row.Scan(&rc.ReceiptNumber, &rc.GymID, &rc.Seq, &rc.SalesNumber, &rc.UserNumber,
+		&rc.OrderNumber, &rc.IssuedAt, &rc.Total, &rc.Supply, &rc.VAT,
+		&rc.GymName, &rc.GymAddress, &rc.GymPhone)
+	if err != nil {
+		return err
+	}
+	rc.ReceiptNo = receiptNo(rc.GymID, rc.Seq)
+	return nil
+}
+
+// GetReceiptByID: 영수증 상세 (항목 포함)
+func (r *mysqlRepo) GetReceiptByID(receiptNum int64) (*domain.Receipt, error) {
+	var rc domain.Receipt
+	query := `SELECT ` + receiptColumns + `
+              FROM receipt_table rc
+              JOIN guss_table g ON rc.fk_guss_number = g.guss_number
+              WHERE rc.receipt_number = ?`
+	if err := scanReceipt(r.db.QueryRow(query, receiptNum), &rc); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT line_description, quantity, unit_price, line_amount
+                             FROM receipt_line_table WHERE fk_receipt_number = ? ORDER BY line_number`, receiptNum)
+	if err != nil {
+		log.Printf("[DB ERROR] GetReceiptByID lines: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rc.Lines = []domain.ReceiptLine{}
+	for rows.Next() {
+		var l domain.ReceiptLine
+		if err := rows.Scan(&l.Description, &l.Quantity, &l.UnitPrice, &l.Amount); err != nil {
+			return nil, err
+		}
+		rc.Lines = append(rc.Lines, l)
+	}
+	return &rc, rows.Err()
+}
+
+// GetReceiptsByUser: 회원의 영수증 목록 (최신순, 항목 제외)
+func (r *mysqlRepo) GetReceiptsByUser(userNum int64) ([]domain.Receipt, error) {
+	query := `SELECT ` + receiptColumns + `
+              FROM receipt_table rc
+              JOIN guss_table g ON rc.fk_guss_number = g.guss_number
+              WHERE rc.fk_user_number = ?
+              ORDER BY rc.issued_at DESC, rc.receipt_number DESC`
+
+	rows, err := r.db.Query(query, userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] GetReceiptsByUser: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Receipt{}
+	for rows.Next() {
+		var rc domain.Receipt
+		if err := scanReceipt(rows, &rc); err != nil {
+			log.Printf("[DB ERROR] Scan Receipt: %v", err)
+			continue
+		}
+		list = append(list, rc)
+	}
+	return list, nil
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+)
+
+const refundColumns = `refund_number, fk_order_number, fk_user_number, fk_guss_number, paid_amount, refund_amount, refund_status,
+                       COALESCE(refund_reason, ''), COALESCE(admin_note, ''), COALESCE(processed_by, ''), COALESCE(fk_sales_number, 0),
+                       created_at, processed_at`
+
+func scanRefund(row interface{ Scan(...interface{}) error }, rf *domain.Refund) error {
+	var processedAt sql.NullTime
+	err := row.Scan(&rf.RefundNumber, &rf.OrderNumber, &rf.UserNumber, &rf.GymID, &rf.PaidAmount, &rf.Amount, &rf.Status,
+		&rf.Reason, &rf.AdminNote, &rf.ProcessedBy, &rf.SalesNumber, &rf.CreatedAt, &processedAt)
+	if err != nil {
+		return err
+	}
+	if processedAt.Valid {
+		rf.ProcessedAt = &processedAt.Time
+	}
+	return nil
+}
+
+// GetPassUsage: 이용권으로 입장한 횟수와 미방문 처리된 예약 수
+func (r *mysqlRepo) GetPassUsage(passNum int64) (int, int, error) {
+	var visits, noShows int
+	err := r.db.QueryRow(`SELECT COALESCE(SUM(revs_status <> 'NO_SHOW'), 0), COALESCE(SUM(revs_status = 'NO_SHOW'), 0)
+                          FROM revs_table WHERE fk_pass_number = ?`, passNum).Scan(&visits, &noShows)
+	return visits, noShows, err
+}
+
+// CreateRefund: 환불 요청 생성 (결제 완료 주문당 진행 중/완료된 환불은 하나만)
+func (r *mysqlRepo) CreateRefund(rf *domain.Refund) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow(`SELECT order_status FROM order_table WHERE order_number = ? FOR UPDATE`, rf.OrderNumber).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if status != domain.OrderPaid {
+		return ErrRefundExists
+	}
+
+	var open int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM refund_table WHERE fk_order_number = ? AND refund_status <> 'REJECTED'`, rf.OrderNumber).Scan(&open)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrRefundExists
+	}
+
+	result, err := tx.Exec(`INSERT INTO refund_table (fk_order_number, fk_user_number, fk_guss_number, paid_amount, refund_amount, refund_status, refund_reason)
+                            VALUES (?, ?, ?, ?, ?, 'REQUESTED', ?)`,
+		rf.OrderNumber, rf.UserNumber, rf.GymID, rf.PaidAmount, rf.Amount, rf.Reason)
+	if err != nil {
+		log.Printf("[DB ERROR] CreateRefund: %v", err)
+		return err
+	}
+	rf.RefundNumber, _ = result.LastInsertId()
+	rf.Status = domain.RefundRequested
+
+	return tx.Commit()
+}
+
+func (r *mysqlRepo) GetRefundByID(refundNum int64) (*domain.Refund, error) {
+	var rf domain.Refund
+	if err := scanRefund(r.db.QueryRow(`SELECT `+refundColumns+` FROM refund_table WHERE refund_number = ?`, refundNum), &rf); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &rf, nil
+}
+
+// GetRefunds: 관리자용 환불 목록 (gymScope 0이면 전체 지점, status 빈 값이면 전체 상태)
+func (r *mysqlRepo) GetRefunds(gymScope int64, status string) ([]domain.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refund_table WHERE 1=1`
+	var args []interface{}
+	if gymScope != 0 {
+		query += ` AND fk_guss_number = ?`
+		args = append(args, gymScope)
+	}
+	if status != "" {
+		query += ` AND refund_status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY refund_number DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		log.Printf("[DB ERROR] GetRefunds: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Refund{}
+	for rows.Next() {
+		var rf domain.Refund
+		if err := scanRefund(rows, &rf); err != nil {
+			log.Printf("[DB ERROR] Scan Refund: %v", err)
+			continue
+		}
+		list = append(list, rf)
+	}
+	return list, nil
+}
+
+// GetRefundsByUser: 회원의 환불 요청 이력 (개인정보 열람용)
+func (r *mysqlRepo) GetRefundsByUser(userNum int64) ([]domain.Refund, error) {
+	rows, err := r.db.Query(`SELECT `+refundColumns+` FROM refund_table WHERE fk_user_number = ? ORDER BY refund_number DESC`, userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] GetRefundsByUser: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Refund{}
+	for rows.Next() {
+		var rf domain.Refund
+		if err := scanRefund(rows, &rf); err != nil {
+			log.Printf("[DB ERROR] Scan Refund: %v", err)
+			continue
+		}
+		list = append(list, rf)
+	}
+	return list, rows.Err()
+}
+
+// ClaimRefund: REQUESTED -> PROCESSING 전환 (동시에 두 번 승인되어 결제사 환불이 중복되지 않도록)
+func (r *mysqlRepo) ClaimRefund(refundNum, amount int64) error {
+	result, err := r.db.Exec(`UPDATE refund_table SET refund_status = 'PROCESSING', refund_amount = ?
+                              WHERE refund_number = ? AND refund_status = 'REQUESTED'`, amount, refundNum)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrRefundNotPending
+	}
+	return nil
+}
+
+// ReleaseRefund: 결제사 환불 실패 시 PROCESSING -> REQUESTED 로 되돌려 재시도 가능하게
+func (r *mysqlRepo) ReleaseRefund(refundNum int64) error {
+	_, err := r.db.Exec(`UPDATE refund_table SET refund_status = 'REQUESTED' WHERE refund_number = ? AND refund_status = 'PROCESSING'`, refundNum)
+	return err
+}
+
+// MarkRefundProviderDone: 결제사 환불 성공을 장부 기록 전에 남겨, 기록이 실패해도 재시도 때 결제사 환불을 반복하지 않게
+func (r *mysqlRepo) MarkRefundProviderDone(refundNum int64) error {
+	result, err := r.db.Exec(`UPDATE refund_table SET refund_status = 'PROVIDER_DONE' WHERE refund_number = ? AND refund_status = 'PROCESSING'`, refundNum)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrRefundNotPending
+	}
+	return nil
+}
+
+// CompleteRefund: 결제사 환불 후 음수 매출 기록, 주문 REFUNDED, 이용권 취소를 한 트랜잭션으로
+func (r *mysqlRepo) CompleteRefund(rf *domain.Refund, passNum int64, sale *domain.Sale) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow(`SELECT refund_status FROM refund_table WHERE refund_number = ? FOR UPDATE`, rf.RefundNumber).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if status != domain.RefundProcessing && status != domain.RefundProviderDone {
+		return ErrRefundNotPending
+	}
+
+	if err := insertSale(tx, sale); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE refund_table SET refund_status = 'REFUNDED', processed_by = NULLIF(?, ''), processed_at = UTC_TIMESTAMP(), fk_sales_number = ?
+                      WHERE refund_number = ?`, rf.ProcessedBy, sale.SalesNumber, rf.RefundNumber)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`UPDATE order_table SET order_status = 'REFUNDED' WHERE order_number = ?`, rf.OrderNumber); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`UPDATE pass_table SET pass_status = 'CANCELLED' WHERE pass_number = ?`, passNum); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	rf.Status = domain.RefundCompleted
+	rf.SalesNumber = sale.SalesNumber
+	return nil
+}
+
+// RejectRefund: 승인 대기 중인 환불 거절
+func (r *mysqlRepo) RejectRefund(refundNum int64, adminID, note string) error {
+	result, err := r.db.Exec(`UPDATE refund_table SET refund_status = 'REJECTED', processed_by = ?, admin_note = ?, processed_at = UTC_TIMESTAMP()
+                              WHERE refund_number = ? AND refund_status = 'REQUESTED'`, adminID, note, refundNum)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrRefundNotPending
+	}
+	return nil
+}
+
+// RefundCounterPass: 현장(관리자 발급) 이용권 환불 - 결제사를 거치지 않으므로 환불 행 없이 음수 매출과 이용권 취소만 기록
+// 온라인 주문으로 발급된 이용권은 결제사 환불이 필요하므로 거부한다.
+func (r *mysqlRepo) RefundCounterPass(passNum int64, sale *domain.Sale) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow(`SELECT pass_status FROM pass_table WHERE pass_number = ? FOR UPDATE`, passNum).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if status == domain.PassCancelled {
+		return ErrRefundNotPending
+	}
+
+	var orders int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM order_table WHERE fk_pass_number = ?`, passNum).Scan(&orders); err != nil {
+		return err
+	}
+	if orders > 0 {
+		return ErrPassHasOrder
+	}
+
+	if err := insertSale(tx, sale); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`UPDATE pass_table SET pass_status = 'CANCELLED' WHERE pass_number = ?`, passNum); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// MarkNoShow: 입장 확정(CONFIRMED) 예약을 미방문 처리 (현재 인원 감소, 기구 예약 해제)
+func (r *mysqlRepo) MarkNoShow(gymScope, revsNum int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var gymNum int64
+	var status string
+	err = tx.QueryRow(`SELECT fk_guss_number, revs_status FROM revs_table WHERE revs_number = ? FOR UPDATE`, revsNum).Scan(&gymNum, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if gymScope != 0 && gymNum != gymScope {
+		return ErrGymScopeDenied
+	}
+	if status != domain.RevsConfirmed {
+		return ErrNoActiveReservation
+	}
+
+	if _, err = tx.Exec(`UPDATE revs_table SET revs_status = 'NO_SHOW' WHERE revs_number = ?`, revsNum); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE guss_table SET guss_user_count = GREATEST(guss_user_count - 1, 0) WHERE guss_number = ?`, gymNum)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE equip_booking_table SET booking_status = 'RELEASED'
+                      WHERE fk_revs_number = ? AND booking_status = 'BOOKED'`, revsNum)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -75,7 +75,7 @@ func (r *mysqlRepo) GetGymDetail(id int64) (*domain.Gym, error) {
 // 3. 유저 ID로 조회 (로그인 인증용)
 func (r *mysqlRepo) GetUserByID(id string) (*domain.User, error) {
 	var u domain.User
-	query := `SELECT user_number, user_name, user_phone, user_id, user_pw FROM user_table WHERE user_id = ?`
+	query := `SELECT user_number, user_name, user_phone, user_id, user_pw FROM user_table WHERE user_id = ? AND deleted_at IS NULL`
 	err := r.db.QueryRow(query, id).Scan(&u.UserNumber, &u.UserName, &u.UserPhone, &u.UserID, &u.UserPW)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -92,7 +92,7 @@ func (r *mysqlRepo) CreateUser(u *domain.User) error {
 	result, err := r.db.Exec(query, u.UserName, u.UserPhone, u.UserID, u.UserPW)
 	if err != nil {
 		log.Printf("[DB ERROR] CreateUser: %v", err)
-		return err
+		return duplicateKeyError(err)
 	}
 	u.UserNumber, _ = result.LastInsertId()
 	return nil
@@ -117,9 +117,37 @@ func (r *mysqlRepo) CreateReservation(userNum, gymNum int64) (string, error) {
 	}
 	defer tx.Rollback()
 
+	// 이용권 차감: 기간제(무제한) 회원권을 우선 사용하고, 횟수권은 만료가 가까운 것부터 사용 (일시정지 중인 이용권 제외)
+	var passNum int64
+	var remaining sql.NullInt64
+	err = tx.QueryRow(`SELECT pass_number, remaining_visits FROM pass_table
+                       WHERE fk_user_number = ? AND fk_guss_number = ? AND pass_status = 'ACTIVE'
+                         AND starts_at <= UTC_TIMESTAMP() AND expires_at > UTC_TIMESTAMP()
+                         AND (remaining_visits IS NULL OR remaining_visits > 0)
+                         AND NOT EXISTS (SELECT 1 FROM pass_freeze_table f
+                                         WHERE f.fk_pass_number = pass_table.pass_number
+                                           AND f.start_at <= UTC_TIMESTAMP() AND f.end_at > UTC_TIMESTAMP())
+                       ORDER BY (remaining_visits IS NULL) DESC, expires_at ASC
+                       LIMIT 1 FOR UPDATE`, userNum, gymNum).Scan(&passNum, &remaining)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrNoValidPass
+		}
+		return "", err
+	}
+	if remaining.Valid {
+		_, err = tx.Exec(`UPDATE pass_table
+                          SET remaining_visits = remaining_visits - 1,
+                              pass_status = IF(remaining_visits = 0, 'USED_UP', pass_status)
+                          WHERE pass_number = ?`, passNum)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	// 예약 정보 삽입
-	_, err = tx.Exec(`INSERT INTO revs_table (fk_user_number, fk_guss_number, revs_status, revs_time) 
-                      VALUES (?, ?, 'CONFIRMED', NOW())`, userNum, gymNum)
+	_, err = tx.Exec(`INSERT INTO revs_table (fk_user_number, fk_guss_number, revs_status, revs_time, fk_pass_number) 
+                      VALUES (?, ?, 'CONFIRMED', NOW(), ?)`, userNum, gymNum, passNum)
 	if err != nil {
 		return "", err
 	}
@@ -158,9 +186,33 @@ func (r *mysqlRepo) GetReservationsByGym(gymID int64) ([]domain.Reservation, err
 	return list, nil
 }
 
+// GetReservationsByUser: 회원의 전체 예약 이력 (개인정보 열람용, 최신순)
+func (r *mysqlRepo) GetReservationsByUser(userNum int64) ([]domain.Reservation, error) {
+	rows, err := r.db.Query(`SELECT revs_number, fk_user_number, fk_guss_number, revs_status, revs_time
+                             FROM revs_table WHERE fk_user_number = ? ORDER BY revs_time DESC`, userNum)
+	if err != nil {
+		log.Printf("[DB ERROR] GetReservationsByUser: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Reservation{}
+	for rows.Next() {
+		var res domain.Reservation
+		if err := rows.Scan(&res.RevsNumber, &res.FKUserID, &res.FKGussID, &res.RevsStatus, &res.RevsTime); err != nil {
+			log.Printf("[DB ERROR] Scan Reservation: %v", err)
+			continue
+		}
+		list = append(list, res)
+	}
+	return list, rows.Err()
+}
+
 // 7. 기구 관리 로직 (프론트엔드 map 에러 방지 적용)
 func (r *mysqlRepo) GetEquipmentsByGymID(id int64) ([]domain.Equipment, error) {
-	query := `SELECT equip_id, fk_guss_number, equip_name, equip_category, equip_quantity, equip_status, purchase_date 
+	query := `SELECT equip_id, fk_guss_number, equip_name, equip_category, equip_quantity, equip_status, COALESCE(DATE_FORMAT(purchase_date, '%Y-%m-%d'), ''),
+                     COALESCE(purchase_price, 0), COALESCE(supplier, ''), COALESCE(DATE_FORMAT(warranty_expiry, '%Y-%m-%d'), ''),
+                     COALESCE(depreciation_method, 'straight_line'), COALESCE(useful_life_years, 0), COALESCE(salvage_value, 0)
               FROM equipment_table WHERE fk_guss_number = ?`
 
 	rows, err := r.db.Query(query, id)
@@ -174,7 +226,9 @@ func (r *mysqlRepo) GetEquipmentsByGymID(id int64) ([]domain.Equipment, error) {
 	list := []domain.Equipment{}
 	for rows.Next() {
 		var e domain.Equipment
-		err := rows.Scan(&e.ID, &e.GymID, &e.Name, &e.Category, &e.Quantity, &e.Status, &e.PurchaseDate)
+		err := rows.Scan(&e.ID, &e.GymID, &e.Name, &e.Category, &e.Quantity, &e.Status, &e.PurchaseDate,
+			&e.PurchasePrice, &e.Supplier, &e.WarrantyExpiry,
+			&e.DepreciationMethod, &e.UsefulLifeYears, &e.SalvageValue)
 		if err != nil {
 			log.Printf("[DB ERROR] Scan Equipment: %v", err)
 			continue
@@ -184,31 +238,86 @@ func (r *mysqlRepo) GetEquipmentsByGymID(id int64) ([]domain.Equipment, error) {
 	return list, nil
 }
 
+func (r *mysqlRepo) GetEquipmentByID(id int64) (*domain.Equipment, error) {
+	var e domain.Equipment
+	query := `SELECT equip_id, fk_guss_number, equip_name, equip_category, equip_quantity, equip_status, COALESCE(DATE_FORMAT(purchase_date, '%Y-%m-%d'), ''),
+                     COALESCE(purchase_price, 0), COALESCE(supplier, ''), COALESCE(DATE_FORMAT(warranty_expiry, '%Y-%m-%d'), ''),
+                     COALESCE(depreciation_method, 'straight_line'), COALESCE(useful_life_years, 0), COALESCE(salvage_value, 0)
+              FROM equipment_table WHERE equip_id = ?`
+
+	err := r.db.QueryRow(query, id).Scan(&e.ID, &e.GymID, &e.Name, &e.Category, &e.Quantity, &e.Status, &e.PurchaseDate,
+		&e.PurchasePrice, &e.Supplier, &e.WarrantyExpiry,
+		&e.DepreciationMethod, &e.UsefulLifeYears, &e.SalvageValue)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("기구를 찾을 수 없습니다")
+		}
+		log.Printf("[DB ERROR] GetEquipmentByID(%d): %v", id, err)
+		return nil, err
+	}
+	return &e, nil
+}
+
 func (r *mysqlRepo) AddEquipment(eq *domain.Equipment) error {
-	query := `INSERT INTO equipment_table (fk_guss_number, equip_name, equip_category, equip_quantity, equip_status, purchase_date) 
-              VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := r.db.Exec(query, eq.GymID, eq.Name, eq.Category, eq.Quantity, eq.Status, eq.PurchaseDate)
+	query := `INSERT INTO equipment_table (fk_guss_number, equip_name, equip_category, equip_quantity, equip_status, purchase_date,
+                     purchase_price, supplier, warranty_expiry, depreciation_method, useful_life_years, salvage_value) 
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?, ?, ?)`
+	_, err := r.db.Exec(query, eq.GymID, eq.Name, eq.Category, eq.Quantity, eq.Status, eq.PurchaseDate,
+		eq.PurchasePrice, eq.Supplier, eq.WarrantyExpiry, eq.DepreciationMethod, eq.UsefulLifeYears, eq.SalvageValue)
 	return err
 }
 
-func (r *mysqlRepo) UpdateEquipment(eq *domain.Equipment) error {
-	query := `UPDATE equipment_table SET equip_name=?, equip_category=?, equip_quantity=?, equip_status=? WHERE equip_id=?`
-	_, err := r.db.Exec(query, eq.Name, eq.Category, eq.Quantity, eq.Status, eq.ID)
-	return err
+// checkEquipmentScope: 기구가 존재하고 호출자의 담당 지점 소속인지 확인
+func (r *mysqlRepo) checkEquipmentScope(gymScope, eqID int64) error {
+	var gymID int64
+	err := r.db.QueryRow(`SELECT fk_guss_number FROM equipment_table WHERE equip_id = ?`, eqID).Scan(&gymID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if gymScope != 0 && gymID != gymScope {
+		log.Printf("[SECURITY] 지점 %d 관리자가 지점 %d의 기구 %d번 접근 시도", gymScope, gymID, eqID)
+		return ErrGymScopeDenied
+	}
+	return nil
 }
 
-func (r *mysqlRepo) DeleteEquipment(id int64) error {
-	_, err := r.db.Exec(`DELETE FROM equipment_table WHERE equip_id = ?`, id)
-	return err
+func (r *mysqlRepo) UpdateEquipment(gymScope int64, eq *domain.Equipment) error {
+	if err := r.checkEquipmentScope(gymScope, eq.ID); err != nil {
+		return err
+	}
+	query := `UPDATE equipment_table SET equip_name=?, equip_category=?, equip_quantity=?, equip_status=?,
+                     purchase_price=?, supplier=?, warranty_expiry=NULLIF(?, ''), depreciation_method=?, useful_life_years=?, salvage_value=?
+              WHERE equip_id=? AND (? = 0 OR fk_guss_number = ?)`
+	// 확인과 수정 사이에 지점이 바뀌는 경우까지 막기 위해 조건을 한 번 더 건다
+	result, err := r.db.Exec(query, eq.Name, eq.Category, eq.Quantity, eq.Status,
+		eq.PurchasePrice, eq.Supplier, eq.WarrantyExpiry, eq.DepreciationMethod, eq.UsefulLifeYears, eq.SalvageValue, eq.ID,
+		gymScope, gymScope)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// 0건이면 그사이 다른 지점으로 옮겨졌거나(ErrGymScopeDenied) 삭제된 것이다.
+		// MySQL은 값이 그대로인 행도 0건으로 세므로, 지점 조건에 여전히 맞으면 변경 없음으로 본다.
+		return r.checkEquipmentScope(gymScope, eq.ID)
+	}
+	return nil
 }
 
-func (r *mysqlRepo) GetSalesByGym(id int64) ([]map[string]interface{}, error) {
-	return []map[string]interface{}{}, nil
+func (r *mysqlRepo) DeleteEquipment(gymScope, id int64) error {
+	if err := r.checkEquipmentScope(gymScope, id); err != nil {
+		return err
+	}
+	// 확인과 삭제 사이에 지점이 바뀌는 경우까지 막기 위해 조건을 한 번 더 건다
+	_, err := r.db.Exec(`DELETE FROM equipment_table WHERE equip_id = ? AND (? = 0 OR fk_guss_number = ?)`, id, gymScope, gymScope)
+	return err
 }
 
 func (r *mysqlRepo) GetAdminByID(id string) (*domain.Admin, error) {
 	var a domain.Admin
-	query := `SELECT admin_number, admin_id, admin_pw, fk_guss_number 
+	query := `SELECT admin_number, admin_id, admin_pw, admin_role, fk_guss_number 
               FROM admin_table WHERE admin_id = ?`
 
 	// [수정] &a.FKGussID (NullInt64)로 스캔
@@ -216,6 +325,7 @@ func (r *mysqlRepo) GetAdminByID(id string) (*domain.Admin, error) {
 		&a.AdminNumber,
 		&a.AdminID,
 		&a.AdminPW,
+		&a.AdminRole,
 		&a.FKGussID,
 	)
 	if err != nil {
@@ -223,3 +333,31 @@ func (r *mysqlRepo) GetAdminByID(id string) (*domain.Admin, error) {
 	}
 	return &a, nil
 }
+
+// GetRolePermissions: 역할에 부여된 세부 권한 목록 (없는 역할이면 ErrNotFound)
+func (r *mysqlRepo) GetRolePermissions(role string) ([]string, error) {
+	var exists int
+	if err := r.db.QueryRow(`SELECT 1 FROM role_table WHERE role_code = ?`, role).Scan(&exists); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT permission FROM role_permission_table WHERE fk_role_code = ? ORDER BY permission`, role)
+	if err != nil {
+		log.Printf("[DB ERROR] GetRolePermissions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	perms := []string{}
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		perms = append(perms, p)
+	}
+	return perms, rows.Err()
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"log"
+	"time"
+)
+
+// purgeBatchSize: 보존 기간 정리 시 한 번에 지우는 행 수 (큰 테이블을 오래 잠그지 않도록 나눠서 실행)
+const purgeBatchSize = 1000
+
+// execInBatches: LIMIT를 붙여 영향 행이 없을 때까지 반복 (단일 테이블 DELETE/UPDATE 전용)
+func (r *mysqlRepo) execInBatches(query string, args ...interface{}) (int64, error) {
+	var total int64
+	for {
+		res, err := r.db.Exec(query+` LIMIT ?`, append(args, purgeBatchSize)...)
+		if err != nil {
+			return total, err
+		}
+		n, _ := res.RowsAffected()
+		total += n
+		if n < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// execAll: 정리 쿼리를 순서대로 실행하고 영향 행 수 합계 반환
+func (r *mysqlRepo) execAll(name string, queries []string, args ...interface{}) (int64, error) {
+	var total int64
+	for _, query := range queries {
+		n, err := r.execInBatches(query, args...)
+		total += n
+		if err != nil {
+			log.Printf("[DB ERROR] %s: %v", name, err)
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (r *mysqlRepo) PurgeExpiredAuthData(before time.Time) (int64, error) {
+	cutoff := before.UTC()
+	total, err := r.execAll("PurgeExpiredAuthData", []string{
+		`DELETE FROM password_reset_table WHERE expires_at < ?`,
+		`DELETE FROM signup_verification_table WHERE expires_at < ?`,
+		`DELETE FROM phone_change_table WHERE expires_at < ?`,
+		`DELETE FROM refresh_token_table WHERE expires_at < ?`,
+	}, cutoff)
+	if err != nil {
+		return total, err
+	}
+
+	// 잠금이 아직 유지 중인 집계는 남긴다.
+	n, err := r.execInBatches(`DELETE FROM login_attempt_table
+                               WHERE last_failure < ? AND (locked_until IS NULL OR locked_until < UTC_TIMESTAMP())`, cutoff)
+	if err != nil {
+		log.Printf("[DB ERROR] PurgeExpiredAuthData login attempts: %v", err)
+	}
+	return total + n, err
+}
+
+// PurgeReservations: 입장 중(CONFIRMED)이 아닌 오래된 예약 삭제 (기구 예약은 FK CASCADE로 함께 삭제)
+func (r *mysqlRepo) PurgeReservations(before time.Time) (int64, error) {
+	return r.execAll("PurgeReservations", []string{
+		`DELETE FROM revs_table WHERE revs_time < ? AND revs_status <> 'CONFIRMED'`,
+	}, before.UTC())
+}
+
+// PurgePaymentRecords: 법정 보관 기간이 지난 거래 기록 정리
+// 이용권(정지/양도 이력 포함)과 주문(환불/쿠폰 사용 이력 포함)은 삭제하고,
+// 매출/영수증은 지점 통계와 일련번호 연속성을 위해 금액은 남긴 채 회원/주문 연결만 끊는다.
+func (r *mysqlRepo) PurgePaymentRecords(before time.Time) (int64, error) {
+	return r.execAll("PurgePaymentRecords", []string{
+		`DELETE FROM pass_table WHERE expires_at < ?`,
+		`DELETE FROM order_table WHERE created_at < ? AND order_status <> 'PENDING'`,
+		`UPDATE sales_table SET fk_user_number = NULL, fk_order_number = NULL
+         WHERE sales_date < ? AND (fk_user_number IS NOT NULL OR fk_order_number IS NOT NULL)`,
+		`UPDATE receipt_table SET fk_user_number = NULL, fk_order_number = NULL
+         WHERE issued_at < ? AND (fk_user_number IS NOT NULL OR fk_order_number IS NOT NULL)`,
+	}, before.UTC())
+}
+
+// PurgeWithdrawnUsers: 탈퇴 후 보관 기간이 지난 회원 행 삭제
+// FK가 없는 매출/영수증은 먼저 회원 연결을 끊고, 나머지 이력(예약/이용권/주문 등)은 FK CASCADE로 함께 삭제된다.
+func (r *mysqlRepo) PurgeWithdrawnUsers(before time.Time) (int64, error) {
+	cutoff := before.UTC()
+	for _, query := range []string{
+		`UPDATE sales_table s JOIN user_table u ON s.fk_user_number = u.user_number
+         SET s.fk_user_number = NULL WHERE u.deleted_at < ?`,
+		`UPDATE receipt_table rc JOIN user_table u ON rc.fk_user_number = u.user_number
+         SET rc.fk_user_number = NULL WHERE u.deleted_at < ?`,
+	} {
+		if _, err := r.db.Exec(query, cutoff); err != nil {
+			log.Printf("[DB ERROR] PurgeWithdrawnUsers: %v", err)
+			return 0, err
+		}
+	}
+	return r.execAll("PurgeWithdrawnUsers", []string{
+		`DELETE FROM user_table WHERE deleted_at < ?`,
+	}, cutoff)
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+	"strings"
+	"time"
+)
+
+// execer: *sql.DB 와 *sql.Tx 공통 인터페이스 (트랜잭션 안팎에서 같은 INSERT 재사용)
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertSale: 매출 한 건 INSERT + 영수증 발행 (SalesDate가 비어 있으면 현재 시각)
+func insertSale(db execer, sale *domain.Sale) error {
+	if sale.SalesDate.IsZero() {
+		sale.SalesDate = time.Now()
+	}
+
+	query := `INSERT INTO sales_table (fk_guss_number, sales_type, sales_amount, sales_date, fk_user_number, fk_order_number)
+              VALUES (?, ?, ?, ?, NULLIF(?, 0), NULLIF(?, 0))`
+	result, err := db.Exec(query, sale.GymID, sale.SalesType, sale.SalesAmount, sale.SalesDate.UTC(), sale.UserNumber, sale.OrderNumber)
+	if err != nil {
+		log.Printf("[DB ERROR] insertSale: %v", err)
+		return err
+	}
+	sale.SalesNumber, _ = result.LastInsertId()
+
+	return insertReceipt(db, sale)
+}
+
+// CreateSale: 매출 한 건 기록 (영수증과 함께)
+func (r *mysqlRepo) CreateSale(sale *domain.Sale) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertSale(tx, sale); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetSales: 지점/기간/매출 유형 조건으로 매출 조회 (최신순)
+func (r *mysqlRepo) GetSales(f domain.SalesFilter) ([]domain.Sale, error) {
+	var conds []string
+	var args []interface{}
+
+	if f.GymID > 0 {
+		conds = append(conds, "fk_guss_number = ?")
+		args = append(args, f.GymID)
+	}
+	if !f.From.IsZero() {
+		conds = append(conds, "sales_date >= ?")
+		args = append(args, f.From.UTC())
+	}
+	if !f.To.IsZero() {
+		conds = append(conds, "sales_date < ?")
+		args = append(args, f.To.UTC())
+	}
+	if f.SalesType != "" {
+		conds = append(conds, "sales_type = ?")
+		args = append(args, f.SalesType)
+	}
+
+	query := `SELECT sales_number, fk_guss_number, COALESCE(sales_type, ''), sales_amount, sales_date,
+                     COALESCE(fk_user_number, 0), COALESCE(fk_order_number, 0)
+              FROM sales_table`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY sales_date DESC, sales_number DESC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		log.Printf("[DB ERROR] GetSales: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []domain.Sale{}
+	for rows.Next() {
+		var s domain.Sale
+		if err := rows.Scan(&s.SalesNumber, &s.GymID, &s.SalesType, &s.SalesAmount, &s.SalesDate, &s.UserNumber, &s.OrderNumber); err != nil {
+			log.Printf("[DB ERROR] Scan Sale: %v", err)
+			continue
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
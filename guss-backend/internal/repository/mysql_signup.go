@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"guss-backend/internal/domain"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// duplicateKeyError: UNIQUE 위반(1062)을 어떤 컬럼이 겹쳤는지에 따라 구분
+func duplicateKeyError(err error) error {
+	var myErr *mysql.MySQLError
+	if !errors.As(err, &myErr) || myErr.Number != 1062 {
+		return err
+	}
+	if strings.Contains(myErr.Message, "user_phone") {
+		return ErrDuplicatePhone
+	}
+	return ErrDuplicateUserID
+}
+
+// CheckUserDuplicate: 가입 전 아이디/휴대폰 번호 중복 확인 (최종 판단은 CompleteSignup의 UNIQUE 제약)
+func (r *mysqlRepo) CheckUserDuplicate(userID, phone string) (bool, bool, error) {
+	var idTaken, phoneTaken bool
+	err := r.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM user_table WHERE user_id = ?), EXISTS(SELECT 1 FROM user_table WHERE user_phone = ?)`,
+		userID, phone).Scan(&idTaken, &phoneTaken)
+	return idTaken, phoneTaken, err
+}
+
+// CreatePendingSignup: 인증 코드와 함께 가입 정보 보관 (같은 번호의 이전 요청과 만료된 대기 건은 정리)
+func (r *mysqlRepo) CreatePendingSignup(ps *domain.PendingSignup) error {
+	if ps.CreatedAt.IsZero() {
+		ps.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM signup_verification_table WHERE user_phone = ? OR expires_at < UTC_TIMESTAMP()`, ps.UserPhone); err != nil {
+		return err
+	}
+	result, err := tx.Exec(`INSERT INTO signup_verification_table (user_id, user_name, user_phone, user_pw, code_hash, expires_at, created_at)
+                            VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		ps.UserID, ps.UserName, ps.UserPhone, ps.PasswordHash, ps.CodeHash, ps.ExpiresAt.UTC(), ps.CreatedAt.UTC())
+	if err != nil {
+		log.Printf("[DB ERROR] CreatePendingSignup: %v", err)
+		return err
+	}
+	ps.SignupNumber, _ = result.LastInsertId()
+	return tx.Commit()
+}
+
+const pendingSignupColumns = `signup_number, user_id, user_name, user_phone, user_pw, code_hash, attempts, expires_at, created_at`
+
+func scanPendingSignup(row interface{ Scan(...interface{}) error }) (*domain.PendingSignup, error) {
+	var ps domain.PendingSignup
+	err := row.Scan(&ps.SignupNumber, &ps.UserID, &ps.UserName, &ps.UserPhone, &ps.PasswordHash, &ps.CodeHash,
+		&ps.Attempts, &ps.ExpiresAt, &ps.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &ps, nil
+}
+
+func (r *mysqlRepo) GetPendingSignup(phone string) (*domain.PendingSignup, error) {
+	return scanPendingSignup(r.db.QueryRow(`SELECT `+pendingSignupColumns+` FROM signup_verification_table
+                                            WHERE user_phone = ? AND expires_at > UTC_TIMESTAMP()
+                                            ORDER BY signup_number DESC LIMIT 1`, phone))
+}
+
+// UsePendingSignupAttempt: 코드를 비교하기 전에 시도 1회를 조건부로 증가 (한도를 넘는 동시 요청은 비교하지 못함)
+func (r *mysqlRepo) UsePendingSignupAttempt(signupNum int64, maxAttempts int) error {
+	res, err := r.db.Exec(`UPDATE signup_verification_table SET attempts = attempts + 1
+                           WHERE signup_number = ? AND expires_at > UTC_TIMESTAMP() AND attempts < ?`, signupNum, maxAttempts)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CompleteSignup: 대기 건을 잠근 상태로 회원 등록 후 삭제 (같은 코드로 동시에 요청해도 한 번만 가입됨)
+func (r *mysqlRepo) CompleteSignup(signupNum int64) (*domain.User, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ps, err := scanPendingSignup(tx.QueryRow(`SELECT `+pendingSignupColumns+` FROM signup_verification_table
+                                              WHERE signup_number = ? AND expires_at > UTC_TIMESTAMP() FOR UPDATE`, signupNum))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(`INSERT INTO user_table (user_name, user_phone, user_id, user_pw, phone_verified_at) VALUES (?, ?, ?, ?, UTC_TIMESTAMP())`,
+		ps.UserName, ps.UserPhone, ps.UserID, ps.PasswordHash)
+	if err != nil {
+		log.Printf("[DB ERROR] CompleteSignup: %v", err)
+		return nil, duplicateKeyError(err)
+	}
+	u := &domain.User{UserName: ps.UserName, UserPhone: ps.UserPhone, UserID: ps.UserID, UserPW: ps.PasswordHash}
+	u.UserNumber, _ = result.LastInsertId()
+
+	if _, err := tx.Exec(`DELETE FROM signup_verification_table WHERE signup_number = ?`, signupNum); err != nil {
+		return nil, err
+	}
+	return u, tx.Commit()
+}
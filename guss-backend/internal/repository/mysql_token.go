@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"database/sql"
+	"guss-backend/internal/domain"
+	"log"
+	"time"
+)
+
+const refreshTokenColumns = `token_number, token_hash, family_id, principal_type, principal_number, principal_id,
+                             expires_at, created_at, used_at, revoked_at`
+
+func scanRefreshToken(row interface{ Scan(...interface{}) error }, t *domain.RefreshToken) error {
+	var usedAt, revokedAt sql.NullTime
+	err := row.Scan(&t.TokenNumber, &t.TokenHash, &t.FamilyID, &t.PrincipalType, &t.PrincipalNumber, &t.PrincipalID,
+		&t.ExpiresAt, &t.CreatedAt, &usedAt, &revokedAt)
+	if err != nil {
+		return err
+	}
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	return nil
+}
+
+func insertRefreshToken(db execer, t *domain.RefreshToken) error {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	result, err := db.Exec(`INSERT INTO refresh_token_table (token_hash, family_id, principal_type, principal_number, principal_id, expires_at, created_at)
+                            VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		t.TokenHash, t.FamilyID, t.PrincipalType, t.PrincipalNumber, t.PrincipalID, t.ExpiresAt.UTC(), t.CreatedAt.UTC())
+	if err != nil {
+		log.Printf("[DB ERROR] insertRefreshToken: %v", err)
+		return err
+	}
+	t.TokenNumber, _ = result.LastInsertId()
+	return nil
+}
+
+// SaveRefreshToken: 로그인 시 새 토큰 묶음의 첫 리프레시 토큰 저장
+func (r *mysqlRepo) SaveRefreshToken(t *domain.RefreshToken) error {
+	return insertRefreshToken(r.db, t)
+}
+
+func (r *mysqlRepo) GetRefreshToken(hash string) (*domain.RefreshToken, error) {
+	var t domain.RefreshToken
+	if err := scanRefreshToken(r.db.QueryRow(`SELECT `+refreshTokenColumns+` FROM refresh_token_table WHERE token_hash = ?`, hash), &t); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// RotateRefreshToken: 리프레시 토큰 교체 (기존 토큰은 used 처리, 같은 묶음/주체로 next 저장)
+// 이미 교체됐거나 폐기된 토큰이 다시 오면 탈취로 보고 묶음 전체를 폐기한 뒤 ErrRefreshTokenReused를 돌려준다.
+func (r *mysqlRepo) RotateRefreshToken(oldHash string, next *domain.RefreshToken) (*domain.RefreshToken, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var old domain.RefreshToken
+	err = scanRefreshToken(tx.QueryRow(`SELECT `+refreshTokenColumns+` FROM refresh_token_table WHERE token_hash = ? FOR UPDATE`, oldHash), &old)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if old.UsedAt != nil || old.RevokedAt != nil {
+		if _, err := tx.Exec(`UPDATE refresh_token_table SET revoked_at = UTC_TIMESTAMP() WHERE family_id = ? AND revoked_at IS NULL`, old.FamilyID); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		log.Printf("[SECURITY] 리프레시 토큰 재사용 감지: %s %s (family %s) 전체 폐기", old.PrincipalType, old.PrincipalID, old.FamilyID)
+		return &old, ErrRefreshTokenReused
+	}
+	if !time.Now().Before(old.ExpiresAt) {
+		return &old, ErrRefreshTokenExpired
+	}
+
+	if _, err := tx.Exec(`UPDATE refresh_token_table SET used_at = UTC_TIMESTAMP() WHERE token_number = ?`, old.TokenNumber); err != nil {
+		return nil, err
+	}
+	next.FamilyID = old.FamilyID
+	next.PrincipalType = old.PrincipalType
+	next.PrincipalNumber = old.PrincipalNumber
+	next.PrincipalID = old.PrincipalID
+	if err := insertRefreshToken(tx, next); err != nil {
+		return nil, err
+	}
+
+	return &old, tx.Commit()
+}
+
+// RevokeTokenFamily: 로그아웃 - 해당 로그인 세션의 리프레시 토큰 묶음 폐기
+func (r *mysqlRepo) RevokeTokenFamily(familyID string) error {
+	_, err := r.db.Exec(`UPDATE refresh_token_table SET revoked_at = UTC_TIMESTAMP() WHERE family_id = ? AND revoked_at IS NULL`, familyID)
+	return err
+}
+
+// RevokeAllRefreshTokens: 주체의 모든 세션 폐기 (전체 로그아웃, 비밀번호 변경 등)
+func (r *mysqlRepo) RevokeAllRefreshTokens(principalType string, principalNumber int64) error {
+	_, err := r.db.Exec(`UPDATE refresh_token_table SET revoked_at = UTC_TIMESTAMP()
+                         WHERE principal_type = ? AND principal_number = ? AND revoked_at IS NULL`, principalType, principalNumber)
+	return err
+}
@@ -1,33 +1,182 @@
 package repository
 
-import "guss-backend/internal/domain"
+import (
+	"errors"
+	"guss-backend/internal/domain"
+	"time"
+)
+
+var (
+	ErrNotFound       = errors.New("대상을 찾을 수 없습니다")
+	ErrGymScopeDenied = errors.New("다른 지점의 데이터에는 접근할 수 없습니다")
+
+	ErrNoActiveReservation  = errors.New("해당 체육관에 활성화된 예약이 없습니다")
+	ErrEquipmentUnavailable = errors.New("현재 예약할 수 없는 기구입니다")
+	ErrBookingConflict      = errors.New("이미 예약된 시간대입니다")
+
+	ErrNoValidPass     = errors.New("사용 가능한 이용권이 없습니다. 이용권을 먼저 구매해 주세요")
+	ErrOrderNotPending = errors.New("이미 처리된 주문입니다")
+	ErrPassInUse       = errors.New("사용 중(입장 중 또는 일시정지 중)인 이용권은 양도할 수 없습니다")
+
+	ErrRefreshTokenReused  = errors.New("이미 사용된 리프레시 토큰입니다. 다시 로그인해 주세요")
+	ErrRefreshTokenExpired = errors.New("리프레시 토큰이 만료되었습니다. 다시 로그인해 주세요")
+
+	ErrDuplicateUserID = errors.New("이미 사용 중인 아이디입니다")
+	ErrDuplicatePhone  = errors.New("이미 가입된 휴대폰 번호입니다")
+	ErrAccountInUse    = errors.New("입장 중에는 탈퇴할 수 없습니다. 퇴실 후 다시 시도해 주세요")
+
+	ErrMFAAlreadyEnabled = errors.New("이미 OTP 인증이 등록되어 있습니다")
+	ErrMFACodeReused     = errors.New("이미 사용한 인증 코드입니다. 다음 코드를 입력해 주세요")
+
+	ErrCouponExhausted   = errors.New("쿠폰이 모두 소진되었습니다")
+	ErrCouponAlreadyUsed = errors.New("이미 사용한 쿠폰입니다")
+	ErrCouponNotEligible = errors.New("첫 구매 회원만 사용할 수 있는 쿠폰입니다")
+
+	ErrRefundExists     = errors.New("이미 환불이 요청되었거나 환불할 수 없는 주문입니다")
+	ErrRefundNotPending = errors.New("이미 처리 중이거나 처리된 환불입니다")
+	ErrPassHasOrder     = errors.New("온라인 결제로 구매한 이용권은 주문 환불로 처리해야 합니다")
+)
 
 type Repository interface {
 	// User 관련
 	CreateUser(u *domain.User) error
 	GetUserByID(id string) (*domain.User, error)
 
+	// 회원가입 휴대폰 인증 (인증 전에는 signup_verification_table에만 보관)
+	CheckUserDuplicate(userID, phone string) (idTaken, phoneTaken bool, err error)
+	CreatePendingSignup(ps *domain.PendingSignup) error             // 같은 번호의 이전 대기 건은 삭제
+	GetPendingSignup(phone string) (*domain.PendingSignup, error)   // 만료되지 않은 대기 건 (없으면 ErrNotFound)
+	UsePendingSignupAttempt(signupNum int64, maxAttempts int) error // 코드 비교 전 시도 1회 차감 (한도를 다 썼거나 만료됐으면 ErrNotFound)
+	CompleteSignup(signupNum int64) (*domain.User, error)           // 대기 건을 회원으로 등록 (중복이면 ErrDuplicateUserID/ErrDuplicatePhone)
+
+	// 비밀번호 재설정 (코드 발급 시 이전 코드 폐기, 완료 시 비밀번호 변경 + 모든 세션 폐기를 한 트랜잭션으로)
+	CreatePasswordReset(pr *domain.PasswordReset) error
+	GetActivePasswordReset(userNum int64) (*domain.PasswordReset, error) // 미사용 최신 코드 (없으면 ErrNotFound)
+	UsePasswordResetAttempt(resetNum int64, maxAttempts int) error       // 코드 비교 전 시도 1회 차감 (한도를 다 썼거나 사용/만료됐으면 ErrNotFound)
+	CompletePasswordReset(resetNum, userNum int64, passwordHash string) error
+	// RehashPassword: 로그인 시 bcrypt 비용 상향 (저장된 해시가 oldHash일 때만 교체, 그 사이 바뀌었으면 무시)
+	RehashPassword(principalType string, principalNumber int64, oldHash, newHash string) error
+
+	// 회원 정보 관리 (탈퇴 회원은 조회되지 않음)
+	GetUserByNumber(userNum int64) (*domain.User, error) // 없거나 탈퇴했으면 ErrNotFound
+	UpdateUserName(userNum int64, name string) error
+	CreatePhoneChange(pc *domain.PhoneChange) error                  // 같은 회원의 이전 변경 요청은 폐기
+	GetActivePhoneChange(userNum int64) (*domain.PhoneChange, error) // 미사용 최신 요청 (없으면 ErrNotFound)
+	UsePhoneChangeAttempt(changeNum int64, maxAttempts int) error    // 코드 비교 전 시도 1회 차감 (한도를 다 썼거나 사용/만료됐으면 ErrNotFound)
+	CompletePhoneChange(changeNum, userNum int64) (string, error)    // 새 번호 반영 후 반환 (이미 쓰는 번호면 ErrDuplicatePhone)
+	// ChangePassword: 저장된 해시가 oldHash일 때만 교체하고 회원의 모든 리프레시 토큰 폐기 (그 사이 바뀌었으면 ErrNotFound)
+	ChangePassword(userNum int64, oldHash, newHash string) error
+	// AnonymizeUser: 탈퇴 처리 - 개인정보 익명화, 세션/인증 코드/남은 기구 예약 정리 (입장 중이면 ErrAccountInUse)
+	AnonymizeUser(userNum int64) error
+
+	// 리프레시 토큰 관련 (해시로만 저장, 교체 시 재사용 감지)
+	SaveRefreshToken(t *domain.RefreshToken) error
+	GetRefreshToken(hash string) (*domain.RefreshToken, error)
+	RotateRefreshToken(oldHash string, next *domain.RefreshToken) (*domain.RefreshToken, error)
+	RevokeTokenFamily(familyID string) error
+	RevokeAllRefreshTokens(principalType string, principalNumber int64) error
+
 	// Gym 관련 (GetGyms로 이름 변경하여 핸들러와 통일)
 	GetGyms() ([]domain.Gym, error)
 	GetGymDetail(id int64) (*domain.Gym, error)
 
-	// Reservation 관련
+	// Reservation 관련 (유효한 이용권 1회 차감 후 예약)
 	CreateReservation(userNum, gymNum int64) (string, error)
 	GetReservationsByGym(gymID int64) ([]domain.Reservation, error)
+	CheckOut(userNum int64) error // 퇴실 처리 및 기구 예약 반납
+
+	// Equipment Booking 관련 (기구 단위 시간 예약)
+	CreateEquipmentBooking(b *domain.EquipmentBooking) error
+	GetEquipmentBookings(equipID int64, from, to time.Time) ([]domain.EquipmentBooking, error)
+	CancelEquipmentBooking(userNum, bookingNum int64) error
+	GetReservationsByUser(userNum int64) ([]domain.Reservation, error)
+	GetEquipmentBookingsByUser(userNum int64) ([]domain.EquipmentBooking, error)
 
 	GetAdminByID(id string) (*domain.Admin, error)
+	GetRolePermissions(role string) ([]string, error) // role_permission_table 기준 세부 권한
+
+	// 관리자 OTP 2단계 인증 (등록 시작 -> 첫 코드 확인으로 활성화, 복구 코드는 해시로만 저장)
+	GetRoleMFARequired(role string) (bool, error)
+	GetAdminMFA(adminNum int64) (*domain.AdminMFA, error)
+	SaveAdminMFASecret(adminNum int64, secret string) error // 활성화 전이면 비밀키 교체, 이미 활성화됐으면 ErrMFAAlreadyEnabled
+	ActivateAdminMFA(adminNum, step int64, recoveryHashes []string) error
+	UseAdminTOTPStep(adminNum, step int64) error                            // 같거나 이전 구간의 코드면 ErrMFACodeReused
+	UseRecoveryCode(adminNum int64, hash string) (remaining int, err error) // 없거나 이미 쓴 코드면 ErrNotFound
+	DisableAdminMFA(adminNum int64) error
 
 	// Equipment 관련 (메서드 명칭 통일)
+	// gymScope: 호출자가 접근 가능한 지점 ID (0이면 전체 지점, SUPER_ADMIN 전용)
 	GetEquipmentsByGymID(gymID int64) ([]domain.Equipment, error)
+	GetEquipmentByID(eqID int64) (*domain.Equipment, error)
 	AddEquipment(eq *domain.Equipment) error // domain 객체를 받도록 설정
-	UpdateEquipment(eq *domain.Equipment) error
-	DeleteEquipment(eqID int64) error
+	UpdateEquipment(gymScope int64, eq *domain.Equipment) error
+	DeleteEquipment(gymScope, eqID int64) error
+
+	// Product / Pass 관련 (이용권 카탈로그 및 회원 보유 이용권)
+	GetProducts(gymID int64, activeOnly bool) ([]domain.Product, error)
+	GetProductByID(productNum int64) (*domain.Product, error)
+	CreateProduct(p *domain.Product) error
+	UpdateProduct(gymScope int64, p *domain.Product) error
+	IssuePass(pass *domain.Pass, sale *domain.Sale) error // 이용권 발급과 매출 기록을 한 트랜잭션으로 처리
+	GetPassesByUser(userNum int64) ([]domain.Pass, error)
+	GetPassByID(passNum int64) (*domain.Pass, error)
+	GetPassFreezes(passNum int64) ([]domain.PassFreeze, error)
+	// FreezePass: 이용권 행을 잠근 채 읽은 정지 이력으로 check(정책 검사)를 실행한 뒤 이력 기록 + 만료일 연장
+	FreezePass(userNum int64, f *domain.PassFreeze, check func(history []domain.PassFreeze) error) error
+	TransferPass(passNum, fromUser, toUser int64) error // 소유자 변경 + 양도 이력 기록
+
+	// Order 관련 (온라인 결제: PENDING 생성 -> 웹훅 확인 후 ConfirmOrder)
+	CreateOrder(o *domain.Order) error // CouponNumber가 있으면 쿠폰 한도 확인 + 사용 이력 기록
+	SetOrderPayment(orderNum int64, paymentID string) error
+	GetOrderByID(orderNum int64) (*domain.Order, error)
+	GetOrderByPaymentID(paymentID string) (*domain.Order, error)
+	GetOrdersByUser(userNum int64) ([]domain.Order, error)
+	ConfirmOrder(orderNum int64, pass *domain.Pass, sale *domain.Sale) error
+	FailOrder(orderNum int64) error
 
-	// 매출 관련
-	GetSalesByGym(gymID int64) ([]map[string]interface{}, error)
+	// Coupon 관련
+	GetCouponByCode(code string) (*domain.Coupon, error)
+	GetCouponByID(couponNum int64) (*domain.Coupon, error)
+	GetCoupons(gymScope int64) ([]domain.Coupon, error)
+	CreateCoupon(c *domain.Coupon) error
+	UpdateCoupon(c *domain.Coupon) error
+	GetCouponUsage(gymScope int64, from, to time.Time) ([]domain.CouponUsage, error)
+
+	// Refund 관련 (REQUESTED -> PROCESSING -> PROVIDER_DONE -> REFUNDED, 또는 REJECTED)
+	GetPassUsage(passNum int64) (visits int, noShows int, err error)
+	CreateRefund(rf *domain.Refund) error
+	GetRefundByID(refundNum int64) (*domain.Refund, error)
+	GetRefunds(gymScope int64, status string) ([]domain.Refund, error)
+	ClaimRefund(refundNum, amount int64) error
+	ReleaseRefund(refundNum int64) error
+	MarkRefundProviderDone(refundNum int64) error                             // 결제사 환불 성공 직후 PROCESSING -> PROVIDER_DONE
+	CompleteRefund(rf *domain.Refund, passNum int64, sale *domain.Sale) error // 음수 매출 + 주문 REFUNDED + 이용권 취소
+	RejectRefund(refundNum int64, adminID, note string) error
+	RefundCounterPass(passNum int64, sale *domain.Sale) error // 현장 발급 이용권 취소 + 음수 매출 (주문이 있으면 ErrPassHasOrder)
+	MarkNoShow(gymScope, revsNum int64) error
+	GetRefundsByUser(userNum int64) ([]domain.Refund, error)
+
+	// 매출 관련 (매출 기록 시 영수증이 함께 발행됨)
+	CreateSale(sale *domain.Sale) error
+	GetSales(filter domain.SalesFilter) ([]domain.Sale, error)
+
+	// 영수증 관련
+	GetReceiptByID(receiptNum int64) (*domain.Receipt, error)
+	GetReceiptsByUser(userNum int64) ([]domain.Receipt, error)
+
+	// 보존 기간 정리 (before 이전 기록을 삭제하거나 회원과의 연결을 끊고, 영향받은 행 수 반환)
+	PurgeExpiredAuthData(before time.Time) (int64, error) // 만료된 인증 코드/리프레시 토큰, 오래된 로그인 실패 집계
+	PurgeReservations(before time.Time) (int64, error)    // 끝난 예약(기구 예약 포함) 삭제
+	PurgePaymentRecords(before time.Time) (int64, error)  // 만료 이용권/주문 삭제, 매출/영수증은 금액만 남기고 회원 연결 해제
+	PurgeWithdrawnUsers(before time.Time) (int64, error)  // 익명화된 탈퇴 회원 행과 남은 이력 삭제
 }
 
 type LogRepository interface {
 	SaveEqLog(gID int64, eID string, stat string) error
 	SaveUserLog(uID string, act string) error
+
+	// 개인정보 열람/파기
+	GetUserLogs(uID string) ([]domain.ActivityLog, error)
+	DeleteUserLogs(uID string) (int, error)
+	PurgeUserLogsBefore(before time.Time) (int, error)
 }